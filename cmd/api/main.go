@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"sprint-backlog/internal/config"
 	"sprint-backlog/internal/database"
 	"sprint-backlog/internal/router"
+	"sprint-backlog/internal/service"
 
 	_ "sprint-backlog/docs" // Swagger docs
 )
@@ -46,13 +53,59 @@ func main() {
 	database.RunMigrations()
 
 	// Setup router
-	r := router.Setup(database.DB)
+	r, trashService := router.Setup(database.DB)
+
+	// Stop background work and the server on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Purge the trash in the background
+	if config.AppConfig.TrashRetentionDays > 0 {
+		go purgeTrash(ctx, trashService)
+	}
 
 	// Start server
 	addr := ":" + config.AppConfig.Port
-	log.Printf("Server starting on %s", addr)
+	srv := &http.Server{Addr: addr, Handler: r}
+	go func() {
+		log.Printf("Server starting on %s", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down server: %v", err)
+	}
+}
 
-	if err := r.Run(addr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+// shutdownTimeout is how long in-flight requests get to finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
+// trashPurgeInterval is how often the trash is checked for rows past retention
+const trashPurgeInterval = time.Hour
+
+// purgeTrash permanently deletes what has been in the trash past the retention
+// period, once at startup and then periodically until ctx is done
+func purgeTrash(ctx context.Context, trashService service.TrashService) {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+	for {
+		result, err := trashService.Purge(ctx)
+		if err != nil {
+			log.Printf("Failed to purge trash: %v", err)
+		} else if result.Projects+result.Sprints+result.Items > 0 {
+			log.Printf("Purged %d projects, %d sprints and %d items from the trash", result.Projects, result.Sprints, result.Items)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
 }
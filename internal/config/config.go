@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -25,6 +27,26 @@ type Config struct {
 	// Google OAuth
 	GoogleClientID     string
 	GoogleClientSecret string
+
+	// Search
+	SearchLanguage string
+
+	// Storage of attachment files: "local" or "s3"
+	StorageBackend   string
+	StorageLocalPath string
+	S3Endpoint       string
+	S3Region         string
+	S3Bucket         string
+	S3AccessKey      string
+	S3SecretKey      string
+	S3PathStyle      bool
+
+	// Attachments
+	AttachmentMaxSize      int64
+	AttachmentAllowedTypes []string
+
+	// Trash: days deleted rows are kept before being purged, 0 keeps them forever
+	TrashRetentionDays int64
 }
 
 var AppConfig *Config
@@ -53,6 +75,29 @@ func Load() {
 		// Google OAuth
 		GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 		GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
+
+		// Search
+		SearchLanguage: getEnv("SEARCH_LANGUAGE", "english"),
+
+		// Storage
+		StorageBackend:   getEnv("STORAGE_BACKEND", "local"),
+		StorageLocalPath: getEnv("STORAGE_LOCAL_PATH", "./uploads"),
+		S3Endpoint:       getEnv("S3_ENDPOINT", ""),
+		S3Region:         getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:         getEnv("S3_BUCKET", ""),
+		S3AccessKey:      getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:      getEnv("S3_SECRET_KEY", ""),
+		S3PathStyle:      getEnv("S3_PATH_STYLE", "true") == "true",
+
+		// Attachments
+		AttachmentMaxSize: getEnvInt64("ATTACHMENT_MAX_SIZE", 10<<20),
+		AttachmentAllowedTypes: getEnvList("ATTACHMENT_ALLOWED_TYPES", []string{
+			"image/png", "image/jpeg", "image/gif", "image/webp",
+			"text/plain", "text/csv", "application/json", "application/pdf", "application/zip",
+		}),
+
+		// Trash
+		TrashRetentionDays: getEnvInt64("TRASH_RETENTION_DAYS", 30),
 	}
 
 	// Validate required config
@@ -74,6 +119,34 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvInt64(key string, defaultValue int64) int64 {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Printf("Warning: %s is not a number, using %d", key, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvList reads a comma-separated list
+func getEnvList(key string, defaultValue []string) []string {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 // GetDSN returns the PostgreSQL connection string
 func (c *Config) GetDSN() string {
 	return "host=" + c.DBHost +
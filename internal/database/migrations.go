@@ -1,14 +1,26 @@
 package database
 
 import (
+	"fmt"
 	"log"
 
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"sprint-backlog/internal/config"
 	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/rank"
 )
 
 func RunMigrations() {
 	log.Println("Running database migrations...")
 
+	// Watchers are backfilled once, when their table is created, so that later
+	// unwatches are not undone
+	watchersExisted := DB.Migrator().HasTable(&models.Watcher{})
+
 	err := DB.AutoMigrate(
 		&models.User{},
 		&models.Project{},
@@ -16,11 +28,250 @@ func RunMigrations() {
 		&models.Sprint{},
 		&models.ItemHistory{},
 		&models.SprintHistory{},
+		&models.Workflow{},
+		&models.ItemAssignee{},
+		&models.ItemLink{},
+		&models.SavedFilter{},
+		&models.Comment{},
+		&models.CommentEdit{},
+		&models.CommentMention{},
+		&models.Attachment{},
+		&models.ItemTemplate{},
+		&models.Worklog{},
+		&models.Watcher{},
+		&models.Label{},
+		&models.CustomField{},
 	)
 
 	if err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
+	if err := backfillItemNumbers(); err != nil {
+		log.Fatalf("Failed to backfill item numbers: %v", err)
+	}
+
+	if err := backfillItemRanks(); err != nil {
+		log.Fatalf("Failed to backfill item ranks: %v", err)
+	}
+
+	if err := backfillComments(); err != nil {
+		log.Fatalf("Failed to backfill comments: %v", err)
+	}
+
+	if !watchersExisted {
+		if err := backfillWatchers(); err != nil {
+			log.Fatalf("Failed to backfill watchers: %v", err)
+		}
+	}
+
+	if err := setupItemSearch(config.AppConfig.SearchLanguage); err != nil {
+		log.Fatalf("Failed to set up item search: %v", err)
+	}
+
 	log.Println("Database migrations completed successfully")
 }
+
+// backfillItemNumbers numbers items created before item keys existed, in creation
+// order per project, then enforces unique numbers within a project
+func backfillItemNumbers() error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			UPDATE backlog_items b SET number = n.seq + p.item_sequence
+			FROM (
+				SELECT id, project_id, ROW_NUMBER() OVER (PARTITION BY project_id ORDER BY created_at, id) AS seq
+				FROM backlog_items WHERE number = 0
+			) n
+			JOIN projects p ON p.id = n.project_id
+			WHERE b.id = n.id`).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			UPDATE projects p SET item_sequence = m.max_number
+			FROM (SELECT project_id, MAX(number) AS max_number FROM backlog_items GROUP BY project_id) m
+			WHERE p.id = m.project_id AND p.item_sequence < m.max_number`).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(
+			"CREATE UNIQUE INDEX IF NOT EXISTS idx_backlog_items_project_number ON backlog_items (project_id, number)",
+		).Error
+	})
+}
+
+// backfillItemRanks ranks the items of every project that still has unranked items.
+// Unranked items sort by their legacy position, so spreading fresh keys over the
+// current order keeps it.
+func backfillItemRanks() error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		var projectIDs []uuid.UUID
+		if err := tx.Raw("SELECT DISTINCT project_id FROM backlog_items WHERE rank = ''").
+			Scan(&projectIDs).Error; err != nil {
+			return err
+		}
+
+		for _, projectID := range projectIDs {
+			if err := tx.Exec("SELECT 1 FROM projects WHERE id = ? FOR UPDATE", projectID).Error; err != nil {
+				return err
+			}
+
+			var ids []string
+			if err := tx.Raw(`
+				SELECT id FROM backlog_items WHERE project_id = ?
+				ORDER BY rank COLLATE "C" ASC, position ASC, created_at DESC`, projectID).
+				Scan(&ids).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				UPDATE backlog_items SET rank = ranked.rank
+				FROM unnest(?::uuid[], ?::text[]) AS ranked(id, rank)
+				WHERE backlog_items.id = ranked.id`,
+				pq.Array(ids), pq.Array(rank.Spread(len(ids))),
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// backfillComments moves comments stored in item history into the comments table,
+// keeping the history entry's ID, author and timestamp. The history entry then only
+// refers to the comment. Mentions are not parsed for these comments.
+func backfillComments() error {
+	action := string(constants.ItemActionCommentAdded)
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO comments (id, item_id, author_id, content, created_at, updated_at)
+			SELECT h.id, h.item_id, h.user_id, h.comment, h.timestamp, h.timestamp
+			FROM item_histories h
+			WHERE h.action = ? AND h.comment IS NOT NULL
+			ON CONFLICT (id) DO NOTHING`, action).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE item_histories
+			SET comment = NULL, field_changed = 'comment',
+				new_value = jsonb_build_object('comment_id', id, 'parent_id', NULL)
+			WHERE action = ? AND comment IS NOT NULL`, action).Error
+	})
+}
+
+// backfillWatchers subscribes the creators, assignees and commenters of existing
+// items and the creators of existing sprints
+func backfillWatchers() error {
+	statements := []struct {
+		sql  string
+		args []interface{}
+	}{
+		{`INSERT INTO watchers (entity_type, entity_id, user_id, reason, created_at)
+			SELECT ?, id, created_by_id, ?, created_at FROM backlog_items WHERE deleted_at IS NULL
+			ON CONFLICT DO NOTHING`,
+			[]interface{}{constants.WatchEntityItem, constants.WatchReasonCreator}},
+		{`INSERT INTO watchers (entity_type, entity_id, user_id, reason, created_at)
+			SELECT ?, item_id, user_id, ?, created_at FROM item_assignees
+			ON CONFLICT DO NOTHING`,
+			[]interface{}{constants.WatchEntityItem, constants.WatchReasonAssignee}},
+		{`INSERT INTO watchers (entity_type, entity_id, user_id, reason, created_at)
+			SELECT ?, item_id, author_id, ?, MIN(created_at) FROM comments WHERE deleted_at IS NULL
+			GROUP BY item_id, author_id
+			ON CONFLICT DO NOTHING`,
+			[]interface{}{constants.WatchEntityItem, constants.WatchReasonCommenter}},
+		{`INSERT INTO watchers (entity_type, entity_id, user_id, reason, created_at)
+			SELECT ?, id, created_by_id, ?, created_at FROM sprints WHERE deleted_at IS NULL
+			ON CONFLICT DO NOTHING`,
+			[]interface{}{constants.WatchEntitySprint, constants.WatchReasonCreator}},
+	}
+	return DB.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement.sql, statement.args...).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// setupItemSearch maintains the full-text search vector of backlog items with triggers.
+// Title, labels, description and undeleted comments are weighted in that order. The text search
+// configuration is stored in backlog_search_config() so that queries stem terms the
+// same way; changing it recomputes every vector.
+func setupItemSearch(language string) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		var known bool
+		if err := tx.Raw("SELECT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = ?)", language).
+			Scan(&known).Error; err != nil {
+			return err
+		}
+		if !known {
+			return fmt.Errorf("unknown text search configuration %q", language)
+		}
+
+		var configured bool
+		if err := tx.Raw("SELECT to_regprocedure('backlog_search_config()') IS NOT NULL").
+			Scan(&configured).Error; err != nil {
+			return err
+		}
+		current := ""
+		if configured {
+			if err := tx.Raw("SELECT backlog_search_config()::text").Scan(&current).Error; err != nil {
+				return err
+			}
+		}
+
+		statements := []string{
+			"ALTER TABLE backlog_items ADD COLUMN IF NOT EXISTS search_vector tsvector",
+			"CREATE INDEX IF NOT EXISTS idx_backlog_items_search ON backlog_items USING GIN (search_vector)",
+			fmt.Sprintf(`CREATE OR REPLACE FUNCTION backlog_search_config() RETURNS regconfig
+				LANGUAGE sql IMMUTABLE AS $$ SELECT %s::regconfig $$`, pq.QuoteLiteral(language)),
+			`CREATE OR REPLACE FUNCTION backlog_item_search_vector(uuid, text, text[], text) RETURNS tsvector
+				LANGUAGE sql STABLE AS $$
+					SELECT setweight(to_tsvector(backlog_search_config(), COALESCE($2, '')), 'A') ||
+						setweight(to_tsvector(backlog_search_config(), COALESCE(array_to_string($3, ' '), '')), 'B') ||
+						setweight(to_tsvector(backlog_search_config(), COALESCE($4, '')), 'C') ||
+						setweight(to_tsvector(backlog_search_config(), COALESCE((
+							SELECT string_agg(c.content, ' ') FROM comments c
+							WHERE c.item_id = $1 AND c.deleted_at IS NULL), '')), 'D')
+				$$`,
+			`CREATE OR REPLACE FUNCTION backlog_items_search_trigger() RETURNS trigger
+				LANGUAGE plpgsql AS $$
+				BEGIN
+					NEW.search_vector := backlog_item_search_vector(NEW.id, NEW.title, NEW.labels, NEW.description);
+					RETURN NEW;
+				END $$`,
+			"DROP TRIGGER IF EXISTS backlog_items_search ON backlog_items",
+			`CREATE TRIGGER backlog_items_search BEFORE INSERT OR UPDATE OF title, labels, description ON backlog_items
+				FOR EACH ROW EXECUTE FUNCTION backlog_items_search_trigger()`,
+			// Comments used to be indexed from item history
+			"DROP TRIGGER IF EXISTS item_histories_search ON item_histories",
+			"DROP FUNCTION IF EXISTS item_histories_search_trigger()",
+			`CREATE OR REPLACE FUNCTION comments_search_trigger() RETURNS trigger
+				LANGUAGE plpgsql AS $$
+				BEGIN
+					UPDATE backlog_items b
+					SET search_vector = backlog_item_search_vector(b.id, b.title, b.labels, b.description)
+					WHERE b.id = NEW.item_id;
+					RETURN NULL;
+				END $$`,
+			"DROP TRIGGER IF EXISTS comments_search ON comments",
+			`CREATE TRIGGER comments_search AFTER INSERT OR UPDATE OF content, deleted_at ON comments
+				FOR EACH ROW EXECUTE FUNCTION comments_search_trigger()`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		// Index items that predate the search vector, or all of them when the
+		// configuration changed
+		backfill := "UPDATE backlog_items SET search_vector = backlog_item_search_vector(id, title, labels, description)"
+		if current == language {
+			backfill += " WHERE search_vector IS NULL"
+		}
+		return tx.Exec(backfill).Error
+	})
+}
@@ -6,20 +6,36 @@ import (
 	"sprint-backlog/pkg/constants"
 )
 
-// CreateBacklogItemRequest represents the request body for creating a backlog item
+// CreateBacklogItemRequest represents the request body for creating a backlog item.
+// TemplateID pre-fills the type, priority, description, story points and labels
+// that the request leaves empty, and creates the template's child items. Type and
+// priority are required unless the template provides them.
+// Story points must be on the project's estimation scale; on T-shirt and custom
+// scales the item can be estimated with a label instead, which sets the points.
 type CreateBacklogItemRequest struct {
 	ProjectID   uuid.UUID           `json:"project_id" binding:"required"`
+	TemplateID  *uuid.UUID          `json:"template_id"`
 	Title       string              `json:"title" binding:"required,min=1,max=200"`
 	Description string              `json:"description" binding:"max=5000"`
-	Type        constants.ItemType  `json:"type" binding:"required"`
-	Priority    constants.Priority  `json:"priority" binding:"required"`
+	Type        constants.ItemType  `json:"type"`
+	Priority    constants.Priority  `json:"priority"`
 	Status      constants.ItemStatus `json:"status"`
 	StoryPoints *int                `json:"story_points" binding:"omitempty,min=0,max=100"`
+	Estimate    *string             `json:"estimate" binding:"omitempty,min=1,max=20"`
 	Labels      []string            `json:"labels"`
 	SprintID    *uuid.UUID          `json:"sprint_id"`
+	ParentID    *uuid.UUID          `json:"parent_id"`
+	// DueDate is a calendar date in YYYY-MM-DD format
+	DueDate *string `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	// Estimates are in minutes; the remaining estimate defaults to the original one
+	OriginalEstimate  *int `json:"original_estimate" binding:"omitempty,min=0,max=100000"`
+	RemainingEstimate *int `json:"remaining_estimate" binding:"omitempty,min=0,max=100000"`
+	// CustomFields maps custom field keys of the project to their values
+	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
-// UpdateBacklogItemRequest represents the request body for updating a backlog item
+// UpdateBacklogItemRequest represents the request body for updating a backlog item.
+// Story points and estimate follow the project's estimation scale as on create.
 type UpdateBacklogItemRequest struct {
 	Title       string              `json:"title" binding:"omitempty,min=1,max=200"`
 	Description string              `json:"description" binding:"max=5000"`
@@ -27,13 +43,26 @@ type UpdateBacklogItemRequest struct {
 	Priority    constants.Priority  `json:"priority"`
 	Status      constants.ItemStatus `json:"status"`
 	StoryPoints *int                `json:"story_points" binding:"omitempty,min=0,max=100"`
+	Estimate    *string             `json:"estimate" binding:"omitempty,min=1,max=20"`
 	Labels      []string            `json:"labels"`
 	SprintID    *uuid.UUID          `json:"sprint_id"`
+	// DueDate is a calendar date in YYYY-MM-DD format; use PATCH /backlog/:id/due-date to clear it
+	DueDate *string `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	// Estimates are in minutes
+	OriginalEstimate  *int `json:"original_estimate" binding:"omitempty,min=0,max=100000"`
+	RemainingEstimate *int `json:"remaining_estimate" binding:"omitempty,min=0,max=100000"`
+	// CustomFields sets the given custom fields and leaves the others as they are;
+	// a null value clears a field
+	CustomFields map[string]interface{} `json:"custom_fields"`
+
+	OverrideWIPLimit bool `json:"override_wip_limit"`
 }
 
-// UpdateStatusRequest represents the request body for updating item status
+// UpdateStatusRequest represents the request body for updating item status.
+// OverrideWIPLimit allows the change even when the target column is full.
 type UpdateStatusRequest struct {
-	Status constants.ItemStatus `json:"status" binding:"required"`
+	Status           constants.ItemStatus `json:"status" binding:"required"`
+	OverrideWIPLimit bool                 `json:"override_wip_limit"`
 }
 
 // UpdatePriorityRequest represents the request body for updating item priority
@@ -46,19 +75,88 @@ type AddLabelRequest struct {
 	Label string `json:"label" binding:"required,min=1,max=50"`
 }
 
-// AddCommentRequest represents the request body for adding a comment
-type AddCommentRequest struct {
-	Content string `json:"content" binding:"required,min=1,max=2000"`
+// SetAssigneesRequest represents the request body for replacing an item's assignees.
+// An empty list clears all assignees.
+type SetAssigneesRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids" binding:"required,max=20"`
+}
+
+// SetParentRequest represents the request body for moving an item under a parent.
+// A null parent_id detaches the item from its parent.
+type SetParentRequest struct {
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+// SetDueDateRequest represents the request body for setting an item's due date.
+// The date uses YYYY-MM-DD format; a null due_date clears it.
+type SetDueDateRequest struct {
+	DueDate *string `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// RankItemRequest represents the request body for reordering an item. Exactly one
+// of before_id and after_id must be set: the item is placed directly before or
+// after that item in the project backlog.
+type RankItemRequest struct {
+	BeforeID *uuid.UUID `json:"before_id"`
+	AfterID  *uuid.UUID `json:"after_id"`
+}
+
+// CloneItemRequest represents the request body for cloning an item. The clone lands
+// in the item's project unless project_id names another one, and keeps the original
+// title unless title is set. Labels, direct children and links are only copied
+// when asked for.
+type CloneItemRequest struct {
+	ProjectID       *uuid.UUID `json:"project_id"`
+	Title           string     `json:"title" binding:"max=200"`
+	IncludeLabels   bool       `json:"include_labels"`
+	IncludeChildren bool       `json:"include_children"`
+	IncludeLinks    bool       `json:"include_links"`
+}
+
+// MoveItemRequest represents the request body for moving an item, together with
+// its descendants, to another project
+type MoveItemRequest struct {
+	ProjectID uuid.UUID `json:"project_id" binding:"required"`
+}
+
+// BulkUpdateRequest represents the request body for applying one operation to many
+// items. The field matching the operation carries its value: status, priority,
+// sprint_id, label or story_points. A null sprint_id or story_points clears the field.
+// set_story_points takes an estimate label instead on T-shirt and custom scales.
+type BulkUpdateRequest struct {
+	ItemIDs          []uuid.UUID             `json:"item_ids" binding:"required,min=1,max=100"`
+	Operation        constants.BulkOperation `json:"operation" binding:"required"`
+	Status           constants.ItemStatus    `json:"status"`
+	Priority         constants.Priority      `json:"priority"`
+	SprintID         *uuid.UUID              `json:"sprint_id"`
+	Label            string                  `json:"label" binding:"max=50"`
+	StoryPoints      *int                    `json:"story_points" binding:"omitempty,min=0,max=100"`
+	Estimate         *string                 `json:"estimate" binding:"omitempty,min=1,max=20"`
+	OverrideWIPLimit bool                    `json:"override_wip_limit"`
 }
 
 // BacklogQueryParams represents query parameters for listing backlog items
 type BacklogQueryParams struct {
 	Search   string   `form:"search"`
+	Q        string   `form:"q" binding:"max=2000"`
 	Type     []string `form:"type"`
 	Priority []string `form:"priority"`
 	Status   []string `form:"status"`
 	SprintID string   `form:"sprint_id"`
+	ParentID string   `form:"parent_id"`
 	Labels   []string `form:"labels"`
+	Assignee []string `form:"assignee"`
+	Sort     string   `form:"sort" binding:"max=200"`
 	Page     int      `form:"page" binding:"omitempty,min=1"`
 	Limit    int      `form:"limit" binding:"omitempty,min=1,max=100"`
+	// DueBefore and DueAfter match items due strictly before or after a YYYY-MM-DD date
+	DueBefore string `form:"due_before" binding:"omitempty,datetime=2006-01-02"`
+	DueAfter  string `form:"due_after" binding:"omitempty,datetime=2006-01-02"`
+	// Overdue matches items past their due date that are not done
+	Overdue bool `form:"overdue"`
+	// Cursor continues after the last item of a previous page; it takes precedence over Page
+	Cursor string `form:"cursor" binding:"max=1024"`
+	// IncludeTotal counts all matching items; it defaults to true for numbered pages
+	// and to false when paging by cursor
+	IncludeTotal *bool `form:"include_total"`
 }
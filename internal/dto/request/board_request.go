@@ -0,0 +1,23 @@
+package request
+
+import (
+	"sprint-backlog/pkg/constants"
+)
+
+// BoardQueryParams represents query parameters for loading a project board.
+// The embedded backlog filters narrow the cards shown in each column; sprint_id
+// additionally accepts "active" to load the project's active sprint.
+type BoardQueryParams struct {
+	ProjectID string `form:"project_id" binding:"required"`
+	BacklogQueryParams
+}
+
+// MoveBoardItemRequest represents the request body for moving a card on the board.
+// Position is the zero-based index within the target column and sprint_id selects
+// the same board scope as BoardQueryParams.
+type MoveBoardItemRequest struct {
+	Status           constants.ItemStatus `json:"status" binding:"required"`
+	Position         int                  `json:"position" binding:"min=0"`
+	SprintID         string               `json:"sprint_id"`
+	OverrideWIPLimit bool                 `json:"override_wip_limit"`
+}
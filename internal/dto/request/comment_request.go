@@ -0,0 +1,15 @@
+package request
+
+import "github.com/google/uuid"
+
+// CreateCommentRequest represents the request body for commenting on a backlog item.
+// ParentID replies to another comment of the item.
+type CreateCommentRequest struct {
+	Content  string     `json:"content" binding:"required,min=1,max=2000"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+// UpdateCommentRequest represents the request body for editing a comment
+type UpdateCommentRequest struct {
+	Content string `json:"content" binding:"required,min=1,max=2000"`
+}
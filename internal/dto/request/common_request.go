@@ -0,0 +1,13 @@
+package request
+
+// HistoryQueryParams represents query parameters for paging through a history list.
+// Without a limit or cursor the whole history is returned.
+type HistoryQueryParams struct {
+	Cursor string `form:"cursor" binding:"max=1024"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// Paginated reports whether a page of the history was requested
+func (p *HistoryQueryParams) Paginated() bool {
+	return p.Cursor != "" || p.Limit > 0
+}
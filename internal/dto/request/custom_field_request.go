@@ -0,0 +1,22 @@
+package request
+
+import "sprint-backlog/pkg/constants"
+
+// CreateCustomFieldRequest represents the request body for defining a custom field
+// of a project. The key names the field in item values and in queries as
+// cf.<key>; select and multi_select fields need options.
+type CreateCustomFieldRequest struct {
+	Key      string                    `json:"key" binding:"required,min=1,max=50"`
+	Name     string                    `json:"name" binding:"required,min=1,max=100"`
+	Type     constants.CustomFieldType `json:"type" binding:"required"`
+	Options  []string                  `json:"options" binding:"max=100,dive,min=1,max=100"`
+	Required bool                      `json:"required"`
+}
+
+// UpdateCustomFieldRequest represents the request body for updating a custom field.
+// The key and type cannot change; options replace the current ones.
+type UpdateCustomFieldRequest struct {
+	Name     string   `json:"name" binding:"omitempty,min=1,max=100"`
+	Options  []string `json:"options" binding:"omitempty,max=100,dive,min=1,max=100"`
+	Required *bool    `json:"required"`
+}
@@ -0,0 +1,14 @@
+package request
+
+import (
+	"github.com/google/uuid"
+
+	"sprint-backlog/pkg/constants"
+)
+
+// CreateItemLinkRequest represents the request body for linking two backlog items.
+// Type is read from the perspective of the item in the URL.
+type CreateItemLinkRequest struct {
+	TargetID uuid.UUID          `json:"target_id" binding:"required"`
+	Type     constants.LinkType `json:"type" binding:"required"`
+}
@@ -0,0 +1,30 @@
+package request
+
+import "sprint-backlog/pkg/constants"
+
+// TemplateChildRequest describes a standard child item of a template.
+// An empty priority inherits the priority of the new parent item.
+type TemplateChildRequest struct {
+	Title       string             `json:"title" binding:"required,min=1,max=200"`
+	Type        constants.ItemType `json:"type" binding:"required"`
+	Priority    constants.Priority `json:"priority"`
+	Description string             `json:"description" binding:"max=5000"`
+	StoryPoints *int               `json:"story_points" binding:"omitempty,min=0,max=100"`
+	Labels      []string           `json:"labels" binding:"max=20"`
+}
+
+// CreateItemTemplateRequest represents the request body for creating an item template.
+// An empty priority leaves the priority to the requests that use the template.
+type CreateItemTemplateRequest struct {
+	Name        string                 `json:"name" binding:"required,min=1,max=100"`
+	Type        constants.ItemType     `json:"type" binding:"required"`
+	Priority    constants.Priority     `json:"priority"`
+	Description string                 `json:"description" binding:"max=5000"`
+	StoryPoints *int                   `json:"story_points" binding:"omitempty,min=0,max=100"`
+	Labels      []string               `json:"labels" binding:"max=20"`
+	Children    []TemplateChildRequest `json:"children" binding:"max=20,dive"`
+}
+
+// UpdateItemTemplateRequest represents the request body for updating an item template.
+// It replaces all fields of the template.
+type UpdateItemTemplateRequest CreateItemTemplateRequest
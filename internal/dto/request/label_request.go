@@ -0,0 +1,31 @@
+package request
+
+// CreateLabelRequest represents the request body for adding a label to a project's
+// catalog. An empty color uses the default label color.
+type CreateLabelRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=50"`
+	Color       string `json:"color" binding:"omitempty,hexcolor"`
+	Description string `json:"description" binding:"max=200"`
+}
+
+// UpdateLabelRequest represents the request body for updating a catalog label.
+// A new name renames the label on every item of the project.
+type UpdateLabelRequest struct {
+	Name        string  `json:"name" binding:"omitempty,min=1,max=50"`
+	Color       string  `json:"color" binding:"omitempty,hexcolor"`
+	Description *string `json:"description" binding:"omitempty,max=200"`
+}
+
+// MergeLabelsRequest represents the request body for merging labels. The sources
+// may be catalog labels or labels only used on items; the target must be in the
+// catalog.
+type MergeLabelsRequest struct {
+	Sources []string `json:"sources" binding:"required,min=1,max=50,dive,min=1,max=50"`
+	Target  string   `json:"target" binding:"required,min=1,max=50"`
+}
+
+// LabelQueryParams represents query parameters for listing and autocompleting labels
+type LabelQueryParams struct {
+	Query string `form:"q" binding:"max=50"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
@@ -1,5 +1,7 @@
 package request
 
+import "sprint-backlog/pkg/constants"
+
 // CreateProjectRequest represents the request body for creating a project
 type CreateProjectRequest struct {
 	Name        string `json:"name" binding:"required,min=1,max=100"`
@@ -9,8 +11,23 @@ type CreateProjectRequest struct {
 
 // UpdateProjectRequest represents the request body for updating a project
 type UpdateProjectRequest struct {
-	Name        string `json:"name" binding:"omitempty,min=1,max=100"`
-	Description string `json:"description" binding:"max=500"`
+	Name         string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description  string `json:"description" binding:"max=500"`
+	StrictLabels *bool  `json:"strict_labels"`
+}
+
+// UpdateEstimationScaleRequest represents the request body for changing a project's
+// estimation scale. Options map labels to points on the tshirt scale, where they
+// default to XS through XXL, and on the custom scale, where they are required.
+type UpdateEstimationScaleRequest struct {
+	Scale   constants.EstimationScale `json:"scale" binding:"required"`
+	Options []EstimateOptionRequest   `json:"options" binding:"max=20,dive"`
+}
+
+// EstimateOptionRequest represents one label of an estimation scale and its points
+type EstimateOptionRequest struct {
+	Label  string `json:"label" binding:"required,min=1,max=20"`
+	Points *int   `json:"points" binding:"required,min=0,max=100"`
 }
 
 // ProjectQueryParams represents query parameters for listing projects
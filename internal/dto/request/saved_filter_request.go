@@ -0,0 +1,16 @@
+package request
+
+// CreateSavedFilterRequest represents the request body for saving a backlog query
+type CreateSavedFilterRequest struct {
+	Name   string `json:"name" binding:"required,min=1,max=100"`
+	Query  string `json:"query" binding:"required,max=2000"`
+	Shared bool   `json:"shared"`
+}
+
+// UpdateSavedFilterRequest represents the request body for updating a saved filter.
+// Omitted fields are left unchanged.
+type UpdateSavedFilterRequest struct {
+	Name   string  `json:"name" binding:"omitempty,min=1,max=100"`
+	Query  *string `json:"query" binding:"omitempty,max=2000"`
+	Shared *bool   `json:"shared"`
+}
@@ -32,6 +32,12 @@ type AddItemToSprintRequest struct {
 type SprintQueryParams struct {
 	ProjectID string   `form:"project_id"`
 	Status    []string `form:"status"`
+	Sort      string   `form:"sort" binding:"max=200"`
 	Page      int      `form:"page" binding:"omitempty,min=1"`
 	Limit     int      `form:"limit" binding:"omitempty,min=1,max=100"`
+	// Cursor continues after the last sprint of a previous page; it takes precedence over Page
+	Cursor string `form:"cursor" binding:"max=1024"`
+	// IncludeTotal counts all matching sprints; it defaults to true for numbered pages
+	// and to false when paging by cursor
+	IncludeTotal *bool `form:"include_total"`
 }
@@ -5,3 +5,10 @@ type UpdateProfileRequest struct {
 	Name      string `json:"name" binding:"omitempty,min=2,max=100"`
 	AvatarURL string `json:"avatar_url" binding:"omitempty,url"`
 }
+
+// ActivityQueryParams represents query parameters for listing user activities
+type ActivityQueryParams struct {
+	Cursor       string
+	Limit        int
+	IncludeTotal bool
+}
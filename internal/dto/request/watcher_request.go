@@ -0,0 +1,7 @@
+package request
+
+// WatchingQueryParams represents query parameters for the entities a user watches.
+// An empty type lists watched items and sprints.
+type WatchingQueryParams struct {
+	Type string `form:"type" binding:"omitempty,oneof=item sprint"`
+}
@@ -0,0 +1,31 @@
+package request
+
+import (
+	"sprint-backlog/pkg/constants"
+)
+
+// WorkflowStatusRequest represents a status in a workflow definition
+type WorkflowStatusRequest struct {
+	Name     constants.ItemStatus     `json:"name" binding:"required,min=1,max=50"`
+	Category constants.StatusCategory `json:"category" binding:"required"`
+	WIPLimit *int                     `json:"wip_limit" binding:"omitempty,min=1"`
+}
+
+// WorkflowTransitionRequest represents an allowed transition between two statuses
+type WorkflowTransitionRequest struct {
+	From constants.ItemStatus `json:"from" binding:"required"`
+	To   constants.ItemStatus `json:"to" binding:"required"`
+}
+
+// UpdateWorkflowRequest represents the request body for replacing a project workflow.
+// Leaving transitions empty allows any status change.
+type UpdateWorkflowRequest struct {
+	Statuses    []WorkflowStatusRequest     `json:"statuses" binding:"required,min=1,dive"`
+	Transitions []WorkflowTransitionRequest `json:"transitions" binding:"dive"`
+}
+
+// UpdateWIPLimitsRequest represents the request body for setting work-in-progress limits.
+// Limits are keyed by status; a null limit removes the limit from that column.
+type UpdateWIPLimitsRequest struct {
+	Limits map[constants.ItemStatus]*int `json:"limits" binding:"required"`
+}
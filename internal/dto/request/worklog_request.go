@@ -0,0 +1,24 @@
+package request
+
+// CreateWorklogRequest represents the request body for logging work on a backlog item.
+// Date is the day the work was done in YYYY-MM-DD format and defaults to today.
+type CreateWorklogRequest struct {
+	Minutes int    `json:"minutes" binding:"required,min=1,max=1440"`
+	Date    string `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	Note    string `json:"note" binding:"max=1000"`
+}
+
+// UpdateWorklogRequest represents the request body for editing a worklog. It replaces
+// the minutes and note; an empty date keeps the logged day.
+type UpdateWorklogRequest struct {
+	Minutes int    `json:"minutes" binding:"required,min=1,max=1440"`
+	Date    string `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	Note    string `json:"note" binding:"max=1000"`
+}
+
+// TimesheetQueryParams represents query parameters for a user's weekly timesheet.
+// Both days are inclusive in YYYY-MM-DD format.
+type TimesheetQueryParams struct {
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+}
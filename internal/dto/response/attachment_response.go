@@ -0,0 +1,51 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+)
+
+// AttachmentResponse represents an attached file in API responses
+type AttachmentResponse struct {
+	ID          uuid.UUID     `json:"id"`
+	ItemID      uuid.UUID     `json:"item_id"`
+	FileName    string        `json:"file_name"`
+	ContentType string        `json:"content_type"`
+	Size        int64         `json:"size"`
+	Checksum    string        `json:"checksum"`
+	UploadedBy  *UserResponse `json:"uploaded_by,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// ToAttachmentResponse converts an Attachment model to AttachmentResponse
+func ToAttachmentResponse(attachment *models.Attachment) *AttachmentResponse {
+	if attachment == nil {
+		return nil
+	}
+
+	resp := &AttachmentResponse{
+		ID:          attachment.ID,
+		ItemID:      attachment.ItemID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		CreatedAt:   attachment.CreatedAt,
+	}
+	if attachment.UploadedBy.ID != uuid.Nil {
+		resp.UploadedBy = ToUserResponse(&attachment.UploadedBy)
+	}
+	return resp
+}
+
+// ToAttachmentListResponse converts a slice of Attachment models
+func ToAttachmentListResponse(attachments []models.Attachment) []AttachmentResponse {
+	responses := make([]AttachmentResponse, len(attachments))
+	for i := range attachments {
+		responses[i] = *ToAttachmentResponse(&attachments[i])
+	}
+	return responses
+}
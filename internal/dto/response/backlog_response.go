@@ -13,20 +13,77 @@ import (
 // BacklogItemResponse represents a backlog item in API responses
 type BacklogItemResponse struct {
 	ID          uuid.UUID            `json:"id"`
+	Key         string               `json:"key"`
 	ProjectID   uuid.UUID            `json:"project_id"`
 	SprintID    *uuid.UUID           `json:"sprint_id"`
+	ParentID    *uuid.UUID           `json:"parent_id"`
 	Title       string               `json:"title"`
 	Description string               `json:"description"`
 	Type        constants.ItemType   `json:"type"`
 	Priority    constants.Priority   `json:"priority"`
 	Status      constants.ItemStatus `json:"status"`
 	StoryPoints *int                 `json:"story_points"`
+	Estimate    *string              `json:"estimate"`
 	Labels      []string             `json:"labels"`
+	DueDate     *string              `json:"due_date"`
 	Position    int                  `json:"position"`
+	Rank        string               `json:"rank"`
 	CreatedAt   time.Time            `json:"created_at"`
 	UpdatedAt   time.Time            `json:"updated_at"`
 	CreatedBy   *UserResponse        `json:"created_by,omitempty"`
 	Sprint      *SprintSummary       `json:"sprint,omitempty"`
+	Assignees   []UserResponse       `json:"assignees"`
+	Parent      *BacklogItemSummary  `json:"parent,omitempty"`
+	Rollup      *EpicRollupResponse  `json:"rollup,omitempty"`
+	Blocked     bool                 `json:"blocked"`
+	Warnings    []string             `json:"warnings,omitempty"`
+	Highlights  *SearchHighlights    `json:"highlights,omitempty"`
+
+	// Estimates and time spent are in minutes
+	OriginalEstimate  *int `json:"original_estimate"`
+	RemainingEstimate *int `json:"remaining_estimate"`
+	TimeSpent         int  `json:"time_spent"`
+
+	// CustomFields holds the values of the project's custom fields by field key
+	CustomFields map[string]interface{} `json:"custom_fields"`
+}
+
+// SearchHighlights holds HTML snippets of the fields that matched a full-text search:
+// the text is escaped and matching terms are wrapped in <mark> tags. Comment is the
+// best matching comment, if any.
+type SearchHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+// BulkItemResult reports the outcome of a bulk operation for one item. Changed is
+// false when the item already had the requested value.
+type BulkItemResult struct {
+	ID       uuid.UUID `json:"id"`
+	Key      string    `json:"key,omitempty"`
+	Changed  bool      `json:"changed"`
+	Error    string    `json:"error,omitempty"`
+	Warnings []string  `json:"warnings,omitempty"`
+}
+
+// BulkUpdateResponse reports the outcome of a bulk operation. Changes are applied
+// only when every item passes validation.
+type BulkUpdateResponse struct {
+	Operation constants.BulkOperation `json:"operation"`
+	Applied   bool                    `json:"applied"`
+	Changed   int                     `json:"changed"`
+	Failed    int                     `json:"failed"`
+	Results   []BulkItemResult        `json:"results"`
+}
+
+// EpicRollupResponse summarises the progress of an epic's child items
+type EpicRollupResponse struct {
+	ChildCount      int64   `json:"child_count"`
+	DoneCount       int64   `json:"done_count"`
+	TotalPoints     int64   `json:"total_points"`
+	CompletedPoints int64   `json:"completed_points"`
+	Progress        float64 `json:"progress"`
 }
 
 // SprintSummary represents a sprint summary in responses
@@ -35,13 +92,17 @@ type SprintSummary struct {
 	Name string    `json:"name"`
 }
 
-// BacklogListResponse represents a paginated list of backlog items
+// BacklogListResponse represents a paginated list of backlog items. Total and
+// TotalPages are only set when the total was requested; Page is not set when paging
+// by cursor.
 type BacklogListResponse struct {
 	Items      []BacklogItemResponse `json:"items"`
-	Total      int64                 `json:"total"`
-	Page       int                   `json:"page"`
+	Total      *int64                `json:"total,omitempty"`
+	Page       int                   `json:"page,omitempty"`
 	Limit      int                   `json:"limit"`
-	TotalPages int                   `json:"total_pages"`
+	TotalPages *int                  `json:"total_pages,omitempty"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+	HasMore    bool                  `json:"has_more"`
 }
 
 // ItemHistoryResponse represents an item history entry in API responses
@@ -66,17 +127,27 @@ func ToBacklogItemResponse(item *models.BacklogItem) *BacklogItemResponse {
 
 	resp := &BacklogItemResponse{
 		ID:          item.ID,
+		Key:         item.Key(),
 		ProjectID:   item.ProjectID,
 		SprintID:    item.SprintID,
+		ParentID:    item.ParentID,
 		Title:       item.Title,
 		Type:        item.Type,
 		Priority:    item.Priority,
 		Status:      item.Status,
 		StoryPoints: item.StoryPoints,
+		Estimate:    item.Estimate,
 		Labels:      item.Labels,
 		Position:    item.Position,
+		Rank:        item.Rank,
 		CreatedAt:   item.CreatedAt,
 		UpdatedAt:   item.UpdatedAt,
+
+		OriginalEstimate:  item.OriginalEstimate,
+		RemainingEstimate: item.RemainingEstimate,
+		TimeSpent:         item.TimeSpent,
+
+		CustomFields: item.CustomFields,
 	}
 
 	// Handle nullable description
@@ -84,11 +155,22 @@ func ToBacklogItemResponse(item *models.BacklogItem) *BacklogItemResponse {
 		resp.Description = *item.Description
 	}
 
+	// Handle nullable due date
+	if item.DueDate != nil {
+		dueDate := item.DueDate.Format(constants.DateLayout)
+		resp.DueDate = &dueDate
+	}
+
 	// Handle labels
 	if resp.Labels == nil {
 		resp.Labels = []string{}
 	}
 
+	// Handle custom fields
+	if resp.CustomFields == nil {
+		resp.CustomFields = map[string]interface{}{}
+	}
+
 	// Include CreatedBy if preloaded
 	if item.CreatedBy.ID != uuid.Nil {
 		resp.CreatedBy = ToUserResponse(&item.CreatedBy)
@@ -102,27 +184,41 @@ func ToBacklogItemResponse(item *models.BacklogItem) *BacklogItemResponse {
 		}
 	}
 
+	// Include Parent summary if preloaded
+	if item.Parent != nil && item.Parent.ID != uuid.Nil {
+		resp.Parent = &BacklogItemSummary{
+			ID:    item.Parent.ID,
+			Title: item.Parent.Title,
+			Type:  string(item.Parent.Type),
+		}
+	}
+
+	// Include assignees if preloaded
+	resp.Assignees = make([]UserResponse, 0, len(item.Assignees))
+	for _, assignee := range item.Assignees {
+		if assignee.User.ID != uuid.Nil {
+			resp.Assignees = append(resp.Assignees, *ToUserResponse(&assignee.User))
+		}
+	}
+
 	return resp
 }
 
 // ToBacklogListResponse converts a slice of BacklogItem models to BacklogListResponse
-func ToBacklogListResponse(items []models.BacklogItem, total int64, page, limit int) *BacklogListResponse {
+func ToBacklogListResponse(items []models.BacklogItem, total *int64, page, limit int, nextCursor string) *BacklogListResponse {
 	itemResponses := make([]BacklogItemResponse, len(items))
 	for i, item := range items {
 		itemResponses[i] = *ToBacklogItemResponse(&item)
 	}
 
-	totalPages := int(total) / limit
-	if int(total)%limit > 0 {
-		totalPages++
-	}
-
 	return &BacklogListResponse{
 		Items:      itemResponses,
 		Total:      total,
 		Page:       page,
 		Limit:      limit,
-		TotalPages: totalPages,
+		TotalPages: pageCount(total, limit),
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
 	}
 }
 
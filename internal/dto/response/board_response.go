@@ -0,0 +1,85 @@
+package response
+
+import (
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// BoardResponse represents a Kanban board for a project or sprint
+type BoardResponse struct {
+	Project     ProjectSummary        `json:"project"`
+	Sprint      *SprintSummary        `json:"sprint,omitempty"`
+	Columns     []BoardColumnResponse `json:"columns"`
+	TotalItems  int                   `json:"total_items"`
+	TotalPoints int                   `json:"total_points"`
+}
+
+// BoardColumnResponse represents a single status column on the board.
+// Count and TotalPoints cover the filtered cards, while Load is the number of
+// items in the column that counts towards its WIP limit.
+type BoardColumnResponse struct {
+	Status      constants.ItemStatus     `json:"status"`
+	Category    constants.StatusCategory `json:"category,omitempty"`
+	Items       []BacklogItemResponse    `json:"items"`
+	Count       int                      `json:"count"`
+	TotalPoints int                      `json:"total_points"`
+	WIPLimit    *int                     `json:"wip_limit"`
+	Load        int64                    `json:"load"`
+	Warnings    []string                 `json:"warnings,omitempty"`
+}
+
+// ToBoardColumnResponse converts the items of a status column to BoardColumnResponse
+func ToBoardColumnResponse(status constants.ItemStatus, items []models.BacklogItem) *BoardColumnResponse {
+	column := &BoardColumnResponse{
+		Status: status,
+		Items:  make([]BacklogItemResponse, len(items)),
+		Count:  len(items),
+	}
+
+	for i, item := range items {
+		column.Items[i] = *ToBacklogItemResponse(&item)
+		if item.StoryPoints != nil {
+			column.TotalPoints += *item.StoryPoints
+		}
+	}
+
+	return column
+}
+
+// ToBoardResponse groups items into one column per status, keeping the item order
+func ToBoardResponse(project *models.Project, sprint *models.Sprint, statuses []models.WorkflowStatus, loads map[constants.ItemStatus]int64, items []models.BacklogItem) *BoardResponse {
+	resp := &BoardResponse{
+		Project: ProjectSummary{
+			ID:   project.ID,
+			Name: project.Name,
+			Key:  project.Key,
+		},
+		Columns: make([]BoardColumnResponse, 0, len(statuses)),
+	}
+
+	if sprint != nil && sprint.ID != uuid.Nil {
+		resp.Sprint = &SprintSummary{
+			ID:   sprint.ID,
+			Name: sprint.Name,
+		}
+	}
+
+	grouped := make(map[constants.ItemStatus][]models.BacklogItem, len(statuses))
+	for _, item := range items {
+		grouped[item.Status] = append(grouped[item.Status], item)
+	}
+
+	for _, status := range statuses {
+		column := ToBoardColumnResponse(status.Name, grouped[status.Name])
+		column.Category = status.Category
+		column.WIPLimit = status.WIPLimit
+		column.Load = loads[status.Name]
+		resp.Columns = append(resp.Columns, *column)
+		resp.TotalItems += column.Count
+		resp.TotalPoints += column.TotalPoints
+	}
+
+	return resp
+}
@@ -0,0 +1,103 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+)
+
+// CommentResponse represents a comment in API responses. Deleted comments are only
+// listed to keep their thread together and have no content, author or mentions.
+type CommentResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	ItemID    uuid.UUID         `json:"item_id"`
+	ParentID  *uuid.UUID        `json:"parent_id"`
+	Content   string            `json:"content"`
+	Author    *UserResponse     `json:"author,omitempty"`
+	Mentions  []UserResponse    `json:"mentions"`
+	Deleted   bool              `json:"deleted"`
+	EditedAt  *time.Time        `json:"edited_at"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	Replies   []CommentResponse `json:"replies,omitempty"`
+}
+
+// CommentEditResponse represents a previous version of a comment
+type CommentEditResponse struct {
+	ID       uuid.UUID     `json:"id"`
+	Content  string        `json:"content"`
+	Editor   *UserResponse `json:"editor,omitempty"`
+	EditedAt time.Time     `json:"edited_at"`
+}
+
+// ToCommentResponse converts a Comment model to CommentResponse
+func ToCommentResponse(comment *models.Comment) *CommentResponse {
+	if comment == nil {
+		return nil
+	}
+
+	resp := &CommentResponse{
+		ID:        comment.ID,
+		ItemID:    comment.ItemID,
+		ParentID:  comment.ParentID,
+		Mentions:  []UserResponse{},
+		Deleted:   comment.DeletedAt.Valid,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+	if resp.Deleted {
+		return resp
+	}
+
+	resp.Content = comment.Content
+	resp.EditedAt = comment.EditedAt
+	if comment.Author.ID != uuid.Nil {
+		resp.Author = ToUserResponse(&comment.Author)
+	}
+	for _, m := range comment.Mentions {
+		if m.User.ID != uuid.Nil {
+			resp.Mentions = append(resp.Mentions, *ToUserResponse(&m.User))
+		}
+	}
+	return resp
+}
+
+// ToCommentThreadsResponse groups comments into threads: top-level comments in the
+// given order, each with its replies. Replies whose parent is missing are dropped.
+func ToCommentThreadsResponse(comments []models.Comment) []CommentResponse {
+	threads := make([]CommentResponse, 0, len(comments))
+	index := make(map[uuid.UUID]int)
+	for i := range comments {
+		if comments[i].ParentID == nil {
+			index[comments[i].ID] = len(threads)
+			threads = append(threads, *ToCommentResponse(&comments[i]))
+		}
+	}
+	for i := range comments {
+		if comments[i].ParentID == nil {
+			continue
+		}
+		if at, ok := index[*comments[i].ParentID]; ok {
+			threads[at].Replies = append(threads[at].Replies, *ToCommentResponse(&comments[i]))
+		}
+	}
+	return threads
+}
+
+// ToCommentEditListResponse converts a slice of CommentEdit models
+func ToCommentEditListResponse(edits []models.CommentEdit) []CommentEditResponse {
+	responses := make([]CommentEditResponse, len(edits))
+	for i, e := range edits {
+		responses[i] = CommentEditResponse{
+			ID:       e.ID,
+			Content:  e.Content,
+			EditedAt: e.EditedAt,
+		}
+		if e.Editor.ID != uuid.Nil {
+			responses[i].Editor = ToUserResponse(&e.Editor)
+		}
+	}
+	return responses
+}
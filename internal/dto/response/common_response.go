@@ -18,6 +18,7 @@ type ErrorResponse struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
 	Error   ErrorDetail `json:"error,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
 }
 
 // PaginationMeta represents pagination metadata
@@ -34,3 +35,30 @@ type PaginatedResponse struct {
 	Data    interface{}    `json:"data"`
 	Meta    PaginationMeta `json:"meta"`
 }
+
+// CursorMeta represents cursor pagination metadata
+type CursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// CursorPaginatedResponse represents a cursor-paginated API response
+type CursorPaginatedResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+	Meta    CursorMeta  `json:"meta"`
+}
+
+// pageCount returns the number of pages of the given size needed for total rows,
+// or nil when the total is unknown
+func pageCount(total *int64, limit int) *int {
+	if total == nil || limit < 1 {
+		return nil
+	}
+	pages := int(*total) / limit
+	if int(*total)%limit > 0 {
+		pages++
+	}
+	return &pages
+}
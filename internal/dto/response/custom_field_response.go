@@ -0,0 +1,45 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// CustomFieldResponse represents a custom field definition in API responses
+type CustomFieldResponse struct {
+	ID        uuid.UUID                 `json:"id"`
+	ProjectID uuid.UUID                 `json:"project_id"`
+	Key       string                    `json:"key"`
+	Name      string                    `json:"name"`
+	Type      constants.CustomFieldType `json:"type"`
+	Options   []string                  `json:"options"`
+	Required  bool                      `json:"required"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+}
+
+// ToCustomFieldResponse converts a CustomField model to CustomFieldResponse
+func ToCustomFieldResponse(field *models.CustomField) *CustomFieldResponse {
+	if field == nil {
+		return nil
+	}
+	resp := &CustomFieldResponse{
+		ID:        field.ID,
+		ProjectID: field.ProjectID,
+		Key:       field.Key,
+		Name:      field.Name,
+		Type:      field.Type,
+		Options:   field.Options,
+		Required:  field.Required,
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+	}
+	if resp.Options == nil {
+		resp.Options = []string{}
+	}
+	return resp
+}
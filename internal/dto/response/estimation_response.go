@@ -0,0 +1,35 @@
+package response
+
+import (
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// EstimationScaleResponse represents a project's estimation scale. Options lists the
+// values items may take and is empty on the linear scale, which takes any whole
+// number of points from 0 to 100. On labelled scales items are estimated with the
+// option labels. ItemsUpdated counts the items remapped by a scale change.
+type EstimationScaleResponse struct {
+	ProjectID    uuid.UUID                 `json:"project_id"`
+	Scale        constants.EstimationScale `json:"scale"`
+	Labelled     bool                      `json:"labelled"`
+	Options      []models.EstimateOption   `json:"options"`
+	ItemsUpdated int                       `json:"items_updated,omitempty"`
+}
+
+// ToEstimationScaleResponse converts a project's estimation scale to EstimationScaleResponse
+func ToEstimationScaleResponse(projectID uuid.UUID, scale constants.EstimationScale, options []models.EstimateOption, itemsUpdated int) *EstimationScaleResponse {
+	resp := &EstimationScaleResponse{
+		ProjectID:    projectID,
+		Scale:        scale,
+		Labelled:     scale.IsLabelled(),
+		Options:      options,
+		ItemsUpdated: itemsUpdated,
+	}
+	if resp.Options == nil {
+		resp.Options = []models.EstimateOption{}
+	}
+	return resp
+}
@@ -0,0 +1,53 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// ItemLinkResponse represents a link as seen from one of the linked items
+type ItemLinkResponse struct {
+	ID        uuid.UUID            `json:"id"`
+	Type      constants.LinkType   `json:"type"`
+	Item      BacklogItemSummary   `json:"item"`
+	Status    constants.ItemStatus `json:"status"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// ToItemLinkResponse converts an ItemLink model to the view of the given item.
+// Source and Target must be preloaded.
+func ToItemLinkResponse(link *models.ItemLink, itemID uuid.UUID) *ItemLinkResponse {
+	if link == nil {
+		return nil
+	}
+
+	linkType, other := link.Type, link.Target
+	if link.TargetID == itemID {
+		linkType, other = link.Type.Inverse(), link.Source
+	}
+
+	return &ItemLinkResponse{
+		ID:   link.ID,
+		Type: linkType,
+		Item: BacklogItemSummary{
+			ID:    other.ID,
+			Title: other.Title,
+			Type:  string(other.Type),
+		},
+		Status:    other.Status,
+		CreatedAt: link.CreatedAt,
+	}
+}
+
+// ToItemLinkListResponse converts a slice of ItemLink models to the view of the given item
+func ToItemLinkListResponse(links []models.ItemLink, itemID uuid.UUID) []ItemLinkResponse {
+	responses := make([]ItemLinkResponse, len(links))
+	for i := range links {
+		responses[i] = *ToItemLinkResponse(&links[i], itemID)
+	}
+	return responses
+}
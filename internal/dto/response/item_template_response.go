@@ -0,0 +1,74 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// TemplateChildResponse represents a standard child item of a template in API responses
+type TemplateChildResponse struct {
+	Title       string             `json:"title"`
+	Type        constants.ItemType `json:"type"`
+	Priority    constants.Priority `json:"priority,omitempty"`
+	Description string             `json:"description,omitempty"`
+	StoryPoints *int               `json:"story_points,omitempty"`
+	Labels      []string           `json:"labels,omitempty"`
+}
+
+// ItemTemplateResponse represents an item template in API responses
+type ItemTemplateResponse struct {
+	ID          uuid.UUID               `json:"id"`
+	ProjectID   uuid.UUID               `json:"project_id"`
+	Name        string                  `json:"name"`
+	Type        constants.ItemType      `json:"type"`
+	Priority    constants.Priority      `json:"priority,omitempty"`
+	Description *string                 `json:"description,omitempty"`
+	StoryPoints *int                    `json:"story_points,omitempty"`
+	Labels      []string                `json:"labels"`
+	Children    []TemplateChildResponse `json:"children"`
+	CreatedByID uuid.UUID               `json:"created_by_id"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+// ToItemTemplateResponse converts an ItemTemplate model to ItemTemplateResponse
+func ToItemTemplateResponse(template *models.ItemTemplate) *ItemTemplateResponse {
+	if template == nil {
+		return nil
+	}
+
+	resp := &ItemTemplateResponse{
+		ID:          template.ID,
+		ProjectID:   template.ProjectID,
+		Name:        template.Name,
+		Type:        template.Type,
+		Priority:    template.Priority,
+		Description: template.Description,
+		StoryPoints: template.StoryPoints,
+		Labels:      template.Labels,
+		Children:    make([]TemplateChildResponse, len(template.Children)),
+		CreatedByID: template.CreatedByID,
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}
+	if resp.Labels == nil {
+		resp.Labels = []string{}
+	}
+	for i, child := range template.Children {
+		resp.Children[i] = TemplateChildResponse(child)
+	}
+	return resp
+}
+
+// ToItemTemplateListResponse converts a slice of ItemTemplate models
+func ToItemTemplateListResponse(templates []models.ItemTemplate) []ItemTemplateResponse {
+	responses := make([]ItemTemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = *ToItemTemplateResponse(&templates[i])
+	}
+	return responses
+}
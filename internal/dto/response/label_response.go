@@ -0,0 +1,41 @@
+package response
+
+import (
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+)
+
+// LabelResponse represents a label of a project. Labels used on items but missing
+// from the catalog have no ID and are not managed.
+type LabelResponse struct {
+	ID          *uuid.UUID `json:"id,omitempty"`
+	Name        string     `json:"name"`
+	Color       string     `json:"color,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Managed     bool       `json:"managed"`
+	UsageCount  int64      `json:"usage_count"`
+}
+
+// LabelChangeResponse represents a label after a rename or merge, with the number
+// of items whose labels were rewritten
+type LabelChangeResponse struct {
+	Label        *LabelResponse `json:"label"`
+	ItemsUpdated int            `json:"items_updated"`
+}
+
+// ToLabelResponse converts a catalog Label model to LabelResponse
+func ToLabelResponse(label *models.Label, usageCount int64) *LabelResponse {
+	if label == nil {
+		return nil
+	}
+	id := label.ID
+	return &LabelResponse{
+		ID:          &id,
+		Name:        label.Name,
+		Color:       label.Color,
+		Description: label.Description,
+		Managed:     true,
+		UsageCount:  usageCount,
+	}
+}
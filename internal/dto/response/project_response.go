@@ -10,13 +10,14 @@ import (
 
 // ProjectResponse represents a project in API responses
 type ProjectResponse struct {
-	ID          uuid.UUID     `json:"id"`
-	Name        string        `json:"name"`
-	Key         string        `json:"key"`
-	Description string        `json:"description"`
-	CreatedBy   *UserResponse `json:"created_by,omitempty"`
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
+	ID           uuid.UUID     `json:"id"`
+	Name         string        `json:"name"`
+	Key          string        `json:"key"`
+	Description  string        `json:"description"`
+	StrictLabels bool          `json:"strict_labels"`
+	CreatedBy    *UserResponse `json:"created_by,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
 }
 
 // ProjectListResponse represents a paginated list of projects
@@ -35,11 +36,12 @@ func ToProjectResponse(project *models.Project) *ProjectResponse {
 	}
 
 	resp := &ProjectResponse{
-		ID:        project.ID,
-		Name:      project.Name,
-		Key:       project.Key,
-		CreatedAt: project.CreatedAt,
-		UpdatedAt: project.UpdatedAt,
+		ID:           project.ID,
+		Name:         project.Name,
+		Key:          project.Key,
+		StrictLabels: project.StrictLabels,
+		CreatedAt:    project.CreatedAt,
+		UpdatedAt:    project.UpdatedAt,
 	}
 
 	// Handle nullable description
@@ -0,0 +1,51 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+)
+
+// SavedFilterResponse represents a saved backlog query in API responses
+type SavedFilterResponse struct {
+	ID        uuid.UUID     `json:"id"`
+	ProjectID uuid.UUID     `json:"project_id"`
+	Name      string        `json:"name"`
+	Query     string        `json:"query"`
+	Shared    bool          `json:"shared"`
+	Owner     *UserResponse `json:"owner,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// ToSavedFilterResponse converts a SavedFilter model to SavedFilterResponse
+func ToSavedFilterResponse(filter *models.SavedFilter) *SavedFilterResponse {
+	if filter == nil {
+		return nil
+	}
+
+	resp := &SavedFilterResponse{
+		ID:        filter.ID,
+		ProjectID: filter.ProjectID,
+		Name:      filter.Name,
+		Query:     filter.Query,
+		Shared:    filter.Shared,
+		CreatedAt: filter.CreatedAt,
+		UpdatedAt: filter.UpdatedAt,
+	}
+	if filter.Owner.ID != uuid.Nil {
+		resp.Owner = ToUserResponse(&filter.Owner)
+	}
+	return resp
+}
+
+// ToSavedFilterListResponse converts a slice of SavedFilter models
+func ToSavedFilterListResponse(filters []models.SavedFilter) []SavedFilterResponse {
+	responses := make([]SavedFilterResponse, len(filters))
+	for i := range filters {
+		responses[i] = *ToSavedFilterResponse(&filters[i])
+	}
+	return responses
+}
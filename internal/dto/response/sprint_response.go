@@ -41,13 +41,16 @@ type SprintWithItemsResponse struct {
 	TotalPoints int                  `json:"total_points"`
 }
 
-// SprintListResponse represents a paginated list of sprints
+// SprintListResponse represents a paginated list of sprints. Total and TotalPages
+// are only set when the total was requested; Page is not set when paging by cursor.
 type SprintListResponse struct {
 	Sprints    []SprintResponse `json:"sprints"`
-	Total      int64            `json:"total"`
-	Page       int              `json:"page"`
+	Total      *int64           `json:"total,omitempty"`
+	Page       int              `json:"page,omitempty"`
 	Limit      int              `json:"limit"`
-	TotalPages int              `json:"total_pages"`
+	TotalPages *int             `json:"total_pages,omitempty"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+	HasMore    bool             `json:"has_more"`
 }
 
 // SprintHistoryResponse represents a sprint history entry in API responses
@@ -80,6 +83,14 @@ type SprintReportResponse struct {
 	CompletedStoryPoints int           `json:"completed_story_points"`
 	Velocity            int            `json:"velocity"`
 	CompletionPercentage float64       `json:"completion_percentage"`
+	// OverdueItems counts the items past their due date that are not done
+	OverdueItems         int           `json:"overdue_items"`
+	OverdueStoryPoints   int           `json:"overdue_story_points"`
+	// Estimates of the sprint's items and the time logged on them during the
+	// sprint, in minutes
+	OriginalEstimate     int           `json:"original_estimate"`
+	RemainingEstimate    int           `json:"remaining_estimate"`
+	TimeSpent            int           `json:"time_spent"`
 }
 
 // ToSprintResponse converts a Sprint model to SprintResponse
@@ -123,23 +134,20 @@ func ToSprintResponse(sprint *models.Sprint) *SprintResponse {
 }
 
 // ToSprintListResponse converts a slice of Sprint models to SprintListResponse
-func ToSprintListResponse(sprints []models.Sprint, total int64, page, limit int) *SprintListResponse {
+func ToSprintListResponse(sprints []models.Sprint, total *int64, page, limit int, nextCursor string) *SprintListResponse {
 	sprintResponses := make([]SprintResponse, len(sprints))
 	for i, sprint := range sprints {
 		sprintResponses[i] = *ToSprintResponse(&sprint)
 	}
 
-	totalPages := int(total) / limit
-	if int(total)%limit > 0 {
-		totalPages++
-	}
-
 	return &SprintListResponse{
 		Sprints:    sprintResponses,
 		Total:      total,
 		Page:       page,
 		Limit:      limit,
-		TotalPages: totalPages,
+		TotalPages: pageCount(total, limit),
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
 	}
 }
 
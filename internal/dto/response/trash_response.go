@@ -0,0 +1,118 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// TrashedProjectResponse represents a deleted project
+type TrashedProjectResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	Key       string     `json:"key"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `json:"purge_at,omitempty"`
+}
+
+// TrashedSprintResponse represents a deleted sprint
+type TrashedSprintResponse struct {
+	ID        uuid.UUID              `json:"id"`
+	Name      string                 `json:"name"`
+	Status    constants.SprintStatus `json:"status"`
+	DeletedAt time.Time              `json:"deleted_at"`
+	PurgeAt   *time.Time             `json:"purge_at,omitempty"`
+}
+
+// TrashedItemResponse represents a deleted backlog item
+type TrashedItemResponse struct {
+	ID        uuid.UUID            `json:"id"`
+	Key       string               `json:"key"`
+	Title     string               `json:"title"`
+	Type      constants.ItemType   `json:"type"`
+	Status    constants.ItemStatus `json:"status"`
+	DeletedAt time.Time            `json:"deleted_at"`
+	PurgeAt   *time.Time           `json:"purge_at,omitempty"`
+}
+
+// ProjectTrashResponse represents the trash of a project. Project is only set when
+// the project itself is deleted.
+type ProjectTrashResponse struct {
+	Project *TrashedProjectResponse `json:"project,omitempty"`
+	Sprints []TrashedSprintResponse `json:"sprints"`
+	Items   []TrashedItemResponse   `json:"items"`
+}
+
+// RestoreResponse lists what a restore brought back
+type RestoreResponse struct {
+	ProjectID *uuid.UUID  `json:"project_id,omitempty"`
+	SprintIDs []uuid.UUID `json:"sprint_ids"`
+	ItemIDs   []uuid.UUID `json:"item_ids"`
+}
+
+// purgeAt returns when a row deleted at the given time is purged, or nil when the
+// trash is never purged
+func purgeAt(deletedAt gorm.DeletedAt, retention time.Duration) *time.Time {
+	if retention <= 0 {
+		return nil
+	}
+	at := deletedAt.Time.Add(retention)
+	return &at
+}
+
+// ToTrashedProjectResponse converts a deleted Project model to TrashedProjectResponse
+func ToTrashedProjectResponse(project *models.Project, retention time.Duration) *TrashedProjectResponse {
+	return &TrashedProjectResponse{
+		ID:        project.ID,
+		Name:      project.Name,
+		Key:       project.Key,
+		DeletedAt: project.DeletedAt.Time,
+		PurgeAt:   purgeAt(project.DeletedAt, retention),
+	}
+}
+
+// ToTrashedProjectListResponse converts a slice of deleted Project models to TrashedProjectResponse slice
+func ToTrashedProjectListResponse(projects []models.Project, retention time.Duration) []TrashedProjectResponse {
+	responses := make([]TrashedProjectResponse, len(projects))
+	for i := range projects {
+		responses[i] = *ToTrashedProjectResponse(&projects[i], retention)
+	}
+	return responses
+}
+
+// ToTrashedSprintListResponse converts a slice of deleted Sprint models to TrashedSprintResponse slice
+func ToTrashedSprintListResponse(sprints []models.Sprint, retention time.Duration) []TrashedSprintResponse {
+	responses := make([]TrashedSprintResponse, len(sprints))
+	for i, sprint := range sprints {
+		responses[i] = TrashedSprintResponse{
+			ID:        sprint.ID,
+			Name:      sprint.Name,
+			Status:    sprint.Status,
+			DeletedAt: sprint.DeletedAt.Time,
+			PurgeAt:   purgeAt(sprint.DeletedAt, retention),
+		}
+	}
+	return responses
+}
+
+// ToTrashedItemListResponse converts a slice of deleted BacklogItem models to TrashedItemResponse slice
+func ToTrashedItemListResponse(items []models.BacklogItem, retention time.Duration) []TrashedItemResponse {
+	responses := make([]TrashedItemResponse, len(items))
+	for i := range items {
+		item := &items[i]
+		responses[i] = TrashedItemResponse{
+			ID:        item.ID,
+			Key:       item.Key(),
+			Title:     item.Title,
+			Type:      item.Type,
+			Status:    item.Status,
+			DeletedAt: item.DeletedAt.Time,
+			PurgeAt:   purgeAt(item.DeletedAt, retention),
+		}
+	}
+	return responses
+}
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
 )
 
 // UserActivityResponse represents a unified activity entry (from both item and sprint history)
@@ -20,15 +23,64 @@ type UserActivityResponse struct {
 	Sprint       *SprintSummaryFull  `json:"sprint,omitempty"`
 }
 
+// DueItemResponse represents an open item with a due date in a user's due list
+type DueItemResponse struct {
+	ID          uuid.UUID            `json:"id"`
+	Key         string               `json:"key"`
+	ProjectID   uuid.UUID            `json:"project_id"`
+	ProjectName string               `json:"project_name"`
+	Title       string               `json:"title"`
+	Type        constants.ItemType   `json:"type"`
+	Priority    constants.Priority   `json:"priority"`
+	Status      constants.ItemStatus `json:"status"`
+	DueDate     string               `json:"due_date"`
+	Sprint      *SprintSummary       `json:"sprint,omitempty"`
+}
+
+// DueItemsResponse lists a user's open items across projects that are overdue or
+// due within the next Days days, soonest first
+type DueItemsResponse struct {
+	Overdue  []DueItemResponse `json:"overdue"`
+	Upcoming []DueItemResponse `json:"upcoming"`
+	Days     int               `json:"days"`
+}
+
+// ToDueItemResponse converts a BacklogItem model with a due date to DueItemResponse
+func ToDueItemResponse(item *models.BacklogItem) DueItemResponse {
+	resp := DueItemResponse{
+		ID:          item.ID,
+		Key:         item.Key(),
+		ProjectID:   item.ProjectID,
+		ProjectName: item.Project.Name,
+		Title:       item.Title,
+		Type:        item.Type,
+		Priority:    item.Priority,
+		Status:      item.Status,
+	}
+	if item.DueDate != nil {
+		resp.DueDate = item.DueDate.Format(constants.DateLayout)
+	}
+	if item.Sprint != nil && item.Sprint.ID != uuid.Nil {
+		resp.Sprint = &SprintSummary{
+			ID:   item.Sprint.ID,
+			Name: item.Sprint.Name,
+		}
+	}
+	return resp
+}
+
 // SprintSummaryFull represents a sprint summary with more details
 type SprintSummaryFull struct {
 	ID   uuid.UUID `json:"id"`
 	Name string    `json:"name"`
 }
 
-// UserActivitiesResponse represents a paginated list of user activities
+// UserActivitiesResponse represents a paginated list of user activities. Total is
+// only set when it was requested.
 type UserActivitiesResponse struct {
 	Activities []UserActivityResponse `json:"activities"`
-	Total      int                    `json:"total"`
+	Total      *int                   `json:"total,omitempty"`
 	Limit      int                    `json:"limit"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+	HasMore    bool                   `json:"has_more"`
 }
@@ -0,0 +1,89 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// WatcherResponse represents a user watching an item or sprint
+type WatcherResponse struct {
+	User      *UserResponse         `json:"user,omitempty"`
+	Reason    constants.WatchReason `json:"reason"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+// WatchedItemSummary represents a watched backlog item in a user's watch list
+type WatchedItemSummary struct {
+	ID        uuid.UUID            `json:"id"`
+	Key       string               `json:"key"`
+	ProjectID uuid.UUID            `json:"project_id"`
+	Title     string               `json:"title"`
+	Type      constants.ItemType   `json:"type"`
+	Status    constants.ItemStatus `json:"status"`
+}
+
+// WatchedSprintSummary represents a watched sprint in a user's watch list
+type WatchedSprintSummary struct {
+	ID        uuid.UUID              `json:"id"`
+	ProjectID uuid.UUID              `json:"project_id"`
+	Name      string                 `json:"name"`
+	Status    constants.SprintStatus `json:"status"`
+}
+
+// WatchedEntityResponse represents an item or sprint a user watches. Only the
+// summary matching the entity type is set.
+type WatchedEntityResponse struct {
+	EntityType constants.WatchEntityType `json:"entity_type"`
+	EntityID   uuid.UUID                 `json:"entity_id"`
+	Reason     constants.WatchReason     `json:"reason"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	Item       *WatchedItemSummary       `json:"item,omitempty"`
+	Sprint     *WatchedSprintSummary     `json:"sprint,omitempty"`
+}
+
+// ToWatcherResponse converts a Watcher model to WatcherResponse
+func ToWatcherResponse(watcher *models.Watcher) WatcherResponse {
+	resp := WatcherResponse{
+		Reason:    watcher.Reason,
+		CreatedAt: watcher.CreatedAt,
+	}
+	if watcher.User.ID != uuid.Nil {
+		resp.User = ToUserResponse(&watcher.User)
+	}
+	return resp
+}
+
+// ToWatcherListResponse converts a slice of Watcher models to WatcherResponse slice
+func ToWatcherListResponse(watchers []models.Watcher) []WatcherResponse {
+	responses := make([]WatcherResponse, len(watchers))
+	for i := range watchers {
+		responses[i] = ToWatcherResponse(&watchers[i])
+	}
+	return responses
+}
+
+// ToWatchedItemSummary converts a BacklogItem model to WatchedItemSummary
+func ToWatchedItemSummary(item *models.BacklogItem) *WatchedItemSummary {
+	return &WatchedItemSummary{
+		ID:        item.ID,
+		Key:       item.Key(),
+		ProjectID: item.ProjectID,
+		Title:     item.Title,
+		Type:      item.Type,
+		Status:    item.Status,
+	}
+}
+
+// ToWatchedSprintSummary converts a Sprint model to WatchedSprintSummary
+func ToWatchedSprintSummary(sprint *models.Sprint) *WatchedSprintSummary {
+	return &WatchedSprintSummary{
+		ID:        sprint.ID,
+		ProjectID: sprint.ProjectID,
+		Name:      sprint.Name,
+		Status:    sprint.Status,
+	}
+}
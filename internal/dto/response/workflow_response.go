@@ -0,0 +1,35 @@
+package response
+
+import (
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+)
+
+// WorkflowResponse represents a project workflow in API responses
+type WorkflowResponse struct {
+	ProjectID   uuid.UUID                   `json:"project_id"`
+	Statuses    []models.WorkflowStatus     `json:"statuses"`
+	Transitions []models.WorkflowTransition `json:"transitions"`
+	IsDefault   bool                        `json:"is_default"`
+}
+
+// ToWorkflowResponse converts a Workflow model to WorkflowResponse
+func ToWorkflowResponse(workflow *models.Workflow) *WorkflowResponse {
+	if workflow == nil {
+		return nil
+	}
+
+	resp := &WorkflowResponse{
+		ProjectID:   workflow.ProjectID,
+		Statuses:    workflow.Statuses,
+		Transitions: workflow.Transitions,
+		IsDefault:   workflow.ID == uuid.Nil,
+	}
+
+	if resp.Transitions == nil {
+		resp.Transitions = []models.WorkflowTransition{}
+	}
+
+	return resp
+}
@@ -0,0 +1,114 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// WorklogResponse represents a worklog in API responses
+type WorklogResponse struct {
+	ID        uuid.UUID     `json:"id"`
+	ItemID    uuid.UUID     `json:"item_id"`
+	User      *UserResponse `json:"user,omitempty"`
+	Minutes   int           `json:"minutes"`
+	Date      string        `json:"date"`
+	Note      string        `json:"note"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// UserTimeResponse represents the time one user logged
+type UserTimeResponse struct {
+	User    UserResponse `json:"user"`
+	Minutes int          `json:"minutes"`
+}
+
+// ItemWorklogsResponse lists an item's worklogs with the time logged in total and
+// per user. Estimates are in minutes.
+type ItemWorklogsResponse struct {
+	Worklogs          []WorklogResponse  `json:"worklogs"`
+	TotalMinutes      int                `json:"total_minutes"`
+	ByUser            []UserTimeResponse `json:"by_user"`
+	OriginalEstimate  *int               `json:"original_estimate"`
+	RemainingEstimate *int               `json:"remaining_estimate"`
+}
+
+// TimesheetItemResponse represents the time logged on one item in a week
+type TimesheetItemResponse struct {
+	ItemID    uuid.UUID `json:"item_id"`
+	Key       string    `json:"key,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	ProjectID uuid.UUID `json:"project_id,omitempty"`
+	Minutes   int       `json:"minutes"`
+}
+
+// TimesheetWeekResponse represents the time logged in the week starting on WeekStart
+type TimesheetWeekResponse struct {
+	WeekStart    string                  `json:"week_start"`
+	TotalMinutes int                     `json:"total_minutes"`
+	Items        []TimesheetItemResponse `json:"items"`
+}
+
+// TimesheetResponse represents a user's logged time per week across projects
+type TimesheetResponse struct {
+	UserID       uuid.UUID               `json:"user_id"`
+	From         string                  `json:"from"`
+	To           string                  `json:"to"`
+	TotalMinutes int                     `json:"total_minutes"`
+	Weeks        []TimesheetWeekResponse `json:"weeks"`
+}
+
+// ToWorklogResponse converts a Worklog model to WorklogResponse
+func ToWorklogResponse(worklog *models.Worklog) *WorklogResponse {
+	if worklog == nil {
+		return nil
+	}
+
+	resp := &WorklogResponse{
+		ID:        worklog.ID,
+		ItemID:    worklog.ItemID,
+		Minutes:   worklog.Minutes,
+		Date:      worklog.Date.Format(constants.DateLayout),
+		CreatedAt: worklog.CreatedAt,
+		UpdatedAt: worklog.UpdatedAt,
+	}
+	if worklog.Note != nil {
+		resp.Note = *worklog.Note
+	}
+	if worklog.User.ID != uuid.Nil {
+		resp.User = ToUserResponse(&worklog.User)
+	}
+	return resp
+}
+
+// ToItemWorklogsResponse converts an item's worklogs and sums their time in total and
+// per user, in order of first appearance
+func ToItemWorklogsResponse(item *models.BacklogItem, worklogs []models.Worklog) *ItemWorklogsResponse {
+	resp := &ItemWorklogsResponse{
+		Worklogs:          make([]WorklogResponse, len(worklogs)),
+		ByUser:            []UserTimeResponse{},
+		OriginalEstimate:  item.OriginalEstimate,
+		RemainingEstimate: item.RemainingEstimate,
+	}
+	byUser := make(map[uuid.UUID]int)
+	for i := range worklogs {
+		resp.Worklogs[i] = *ToWorklogResponse(&worklogs[i])
+		resp.TotalMinutes += worklogs[i].Minutes
+
+		idx, ok := byUser[worklogs[i].UserID]
+		if !ok {
+			idx = len(resp.ByUser)
+			byUser[worklogs[i].UserID] = idx
+			resp.ByUser = append(resp.ByUser, UserTimeResponse{User: UserResponse{ID: worklogs[i].UserID}})
+			if worklogs[i].User.ID != uuid.Nil {
+				resp.ByUser[idx].User = *ToUserResponse(&worklogs[i].User)
+			}
+		}
+		resp.ByUser[idx].Minutes += worklogs[i].Minutes
+	}
+	return resp
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+// multipartOverhead is the room left for multipart headers and boundaries when
+// limiting the size of upload requests
+const multipartOverhead = 64 << 10
+
+type AttachmentHandler struct {
+	attachmentService service.AttachmentService
+	maxUploadSize     int64
+}
+
+func NewAttachmentHandler(attachmentService service.AttachmentService, maxUploadSize int64) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		maxUploadSize:     maxUploadSize,
+	}
+}
+
+// Upload handles POST /api/backlog/:id/attachments
+// @Summary Attach a file to a backlog item
+// @Description Upload a file as multipart form data. The file type is detected from its content and must be allowed; the same content can only be attached once per item.
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param file formData file true "File to attach"
+// @Success 201 {object} response.AttachmentResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 413 {object} response.ErrorResponse
+// @Failure 415 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/attachments [post]
+func (h *AttachmentHandler) Upload(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.RespondError(c, http.StatusRequestEntityTooLarge, "File is too large", "FILE_TOO_LARGE", service.ErrAttachmentTooLarge.Error())
+			return
+		}
+		utils.RespondBadRequest(c, "Invalid file upload", err.Error())
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid file upload", err.Error())
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.Upload(c.Request.Context(), id, header.Filename, header.Header.Get("Content-Type"), file, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrAttachmentEmpty):
+			utils.RespondBadRequest(c, "Invalid file upload", err.Error())
+		case errors.Is(err, service.ErrAttachmentTooLarge):
+			utils.RespondError(c, http.StatusRequestEntityTooLarge, "File is too large", "FILE_TOO_LARGE", err.Error())
+		case errors.Is(err, service.ErrAttachmentTypeNotAllowed):
+			utils.RespondError(c, http.StatusUnsupportedMediaType, "File type is not allowed", "UNSUPPORTED_FILE_TYPE", err.Error())
+		case errors.Is(err, service.ErrAttachmentExists):
+			utils.RespondError(c, http.StatusConflict, "File is already attached", "ATTACHMENT_EXISTS", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to upload attachment", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusCreated, "File attached successfully", attachment)
+}
+
+// GetByItem handles GET /api/backlog/:id/attachments
+// @Summary Get backlog item attachments
+// @Description Get the files attached to a backlog item, oldest first
+// @Tags attachments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Success 200 {array} response.AttachmentResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/attachments [get]
+func (h *AttachmentHandler) GetByItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	attachments, err := h.attachmentService.GetByItemID(id)
+	if err != nil {
+		if errors.Is(err, service.ErrBacklogItemNotFound) {
+			utils.RespondNotFound(c, "Backlog item not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch attachments", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", attachments)
+}
+
+// Download handles GET /api/backlog/:id/attachments/:attachmentId
+// @Summary Download an attachment
+// @Description Download the content of a file attached to a backlog item
+// @Tags attachments
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/attachments/{attachmentId} [get]
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid attachment ID", "ID must be a valid UUID")
+		return
+	}
+
+	attachment, content, err := h.attachmentService.Open(c.Request.Context(), id, attachmentID)
+	if err != nil {
+		if errors.Is(err, service.ErrAttachmentNotFound) {
+			utils.RespondNotFound(c, "Attachment not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to download attachment", err.Error())
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"ETag":                   strconv.Quote(attachment.Checksum),
+	})
+}
+
+// Delete handles DELETE /api/backlog/:id/attachments/:attachmentId
+// @Summary Remove an attachment
+// @Description Detach a file from a backlog item
+// @Tags attachments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid attachment ID", "ID must be a valid UUID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.attachmentService.Delete(c.Request.Context(), id, attachmentID, userID); err != nil {
+		if errors.Is(err, service.ErrAttachmentNotFound) {
+			utils.RespondNotFound(c, "Attachment not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to remove attachment", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Attachment removed successfully", nil)
+}
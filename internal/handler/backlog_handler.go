@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 
 	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
 	"sprint-backlog/internal/service"
 	"sprint-backlog/internal/utils"
 	"sprint-backlog/pkg/constants"
@@ -25,7 +26,7 @@ func NewBacklogHandler(backlogService service.BacklogService) *BacklogHandler {
 
 // Create handles POST /api/backlog
 // @Summary Create a new backlog item
-// @Description Create a new backlog item
+// @Description Create a new backlog item. With template_id, fields left empty are filled from the template and its standard child items are created too.
 // @Tags backlog
 // @Accept json
 // @Produce json
@@ -58,6 +59,28 @@ func (h *BacklogHandler) Create(c *gin.Context) {
 			utils.RespondBadRequest(c, "Invalid priority", err.Error())
 		case errors.Is(err, service.ErrInvalidStatus):
 			utils.RespondBadRequest(c, "Invalid status", err.Error())
+		case errors.Is(err, service.ErrParentNotFound),
+			errors.Is(err, service.ErrParentNotInProject),
+			errors.Is(err, service.ErrInvalidParentType):
+			utils.RespondBadRequest(c, "Invalid parent item", err.Error())
+		case errors.Is(err, service.ErrTemplateNotFound),
+			errors.Is(err, service.ErrTemplateNotInProject):
+			utils.RespondBadRequest(c, "Invalid template", err.Error())
+		case errors.Is(err, service.ErrSprintNotFound),
+			errors.Is(err, service.ErrSprintNotInProject):
+			utils.RespondBadRequest(c, "Invalid sprint", err.Error())
+		case errors.Is(err, service.ErrInvalidDueDate):
+			utils.RespondBadRequest(c, "Invalid due date", err.Error())
+		case errors.Is(err, service.ErrLabelNotInCatalog):
+			utils.RespondBadRequest(c, "Label not in catalog", err.Error())
+		case errors.Is(err, service.ErrUnknownCustomField),
+			errors.Is(err, service.ErrInvalidCustomFieldValue),
+			errors.Is(err, service.ErrCustomFieldRequired):
+			utils.RespondBadRequest(c, "Invalid custom field value", err.Error())
+		case errors.Is(err, service.ErrInvalidStoryPoints),
+			errors.Is(err, service.ErrInvalidEstimate),
+			errors.Is(err, service.ErrEstimateMismatch):
+			utils.RespondBadRequest(c, "Invalid estimate", err.Error())
 		default:
 			utils.RespondInternalError(c, "Failed to create backlog item", err.Error())
 		}
@@ -73,14 +96,23 @@ func (h *BacklogHandler) Create(c *gin.Context) {
 // @Tags backlog
 // @Produce json
 // @Security BearerAuth
-// @Param search query string false "Search in title and description"
-// @Param type query []string false "Filter by type (Story, Task, Bug, Epic)"
+// @Param search query string false "Full-text search in title, labels, description and comments"
+// @Param type query []string false "Filter by type (Story, Task, Bug, Epic, Subtask)"
 // @Param priority query []string false "Filter by priority (Critical, High, Medium, Low)"
-// @Param status query []string false "Filter by status (New, Ready, In Progress, In Review, Done)"
+// @Param status query []string false "Filter by workflow status (default: New, Ready, In Progress, Done, Archived)"
 // @Param sprint_id query string false "Filter by sprint ID or 'none' for unassigned"
+// @Param parent_id query string false "Filter by parent item ID or 'none' for top-level items"
 // @Param labels query []string false "Filter by labels"
+// @Param assignee query []string false "Filter by assignee user ID, 'me' or 'unassigned'"
+// @Param due_before query string false "Filter by items due before a date (YYYY-MM-DD)"
+// @Param due_after query string false "Filter by items due after a date (YYYY-MM-DD)"
+// @Param overdue query bool false "Filter by items past their due date that are not done"
+// @Param q query string false "Query language filter, e.g. priority in (High, Critical) AND status != Done AND points >= 5 ORDER BY updated DESC. Custom fields are named cf.<key>, e.g. cf.environment = prod"
+// @Param sort query string false "Comma-separated sort fields with optional :asc or :desc, e.g. priority:desc,updated_at. Fields: priority, status, story_points, created_at, updated_at, due_date, title, key, type, rank, relevance"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Cursor from next_cursor of the previous page; takes precedence over page"
+// @Param include_total query bool false "Count all matches; defaults to true for numbered pages and false when paging by cursor"
 // @Success 200 {object} response.BacklogListResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
@@ -93,9 +125,24 @@ func (h *BacklogHandler) GetAll(c *gin.Context) {
 		return
 	}
 
-	result, err := h.backlogService.GetAll(&params)
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	result, err := h.backlogService.GetAll(&params, userID)
 	if err != nil {
-		utils.RespondInternalError(c, "Failed to fetch backlog items", err.Error())
+		switch {
+		case errors.Is(err, service.ErrInvalidQuery):
+			utils.RespondBadRequest(c, "Invalid query", err.Error())
+		case errors.Is(err, service.ErrInvalidSort):
+			utils.RespondBadRequest(c, "Invalid sort", err.Error())
+		case errors.Is(err, service.ErrInvalidCursor):
+			utils.RespondBadRequest(c, "Invalid cursor", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to fetch backlog items", err.Error())
+		}
 		return
 	}
 
@@ -103,25 +150,24 @@ func (h *BacklogHandler) GetAll(c *gin.Context) {
 }
 
 // GetByID handles GET /api/backlog/:id
-// @Summary Get backlog item by ID
-// @Description Get a backlog item by its ID
+// @Summary Get backlog item by ID or key
+// @Description Get a backlog item by its ID or by its key such as PROJ-123
 // @Tags backlog
 // @Produce json
 // @Security BearerAuth
-// @Param id path string true "Backlog Item ID"
+// @Param id path string true "Backlog Item ID or key"
 // @Success 200 {object} response.BacklogItemResponse
-// @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Router /backlog/{id} [get]
 func (h *BacklogHandler) GetByID(c *gin.Context) {
+	var item *response.BacklogItemResponse
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
-		return
+		item, err = h.backlogService.GetByKey(c.Param("id"))
+	} else {
+		item, err = h.backlogService.GetByID(id)
 	}
-
-	item, err := h.backlogService.GetByID(id)
 	if err != nil {
 		if errors.Is(err, service.ErrBacklogItemNotFound) {
 			utils.RespondNotFound(c, "Backlog item not found")
@@ -147,6 +193,7 @@ func (h *BacklogHandler) GetByID(c *gin.Context) {
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /backlog/{id} [put]
 func (h *BacklogHandler) Update(c *gin.Context) {
@@ -179,6 +226,27 @@ func (h *BacklogHandler) Update(c *gin.Context) {
 			utils.RespondBadRequest(c, "Invalid priority", err.Error())
 		case errors.Is(err, service.ErrInvalidStatus):
 			utils.RespondBadRequest(c, "Invalid status", err.Error())
+		case errors.Is(err, service.ErrTransitionNotAllowed):
+			utils.RespondError(c, http.StatusConflict, "Status transition not allowed", "TRANSITION_NOT_ALLOWED", err.Error())
+		case errors.Is(err, service.ErrWIPLimitExceeded):
+			utils.RespondError(c, http.StatusConflict, "Work-in-progress limit reached", "WIP_LIMIT_EXCEEDED", err.Error())
+		case errors.Is(err, service.ErrInvalidParentType):
+			utils.RespondBadRequest(c, "Item type does not fit the hierarchy", err.Error())
+		case errors.Is(err, service.ErrSprintNotFound),
+			errors.Is(err, service.ErrSprintNotInProject):
+			utils.RespondBadRequest(c, "Invalid sprint", err.Error())
+		case errors.Is(err, service.ErrInvalidDueDate):
+			utils.RespondBadRequest(c, "Invalid due date", err.Error())
+		case errors.Is(err, service.ErrLabelNotInCatalog):
+			utils.RespondBadRequest(c, "Label not in catalog", err.Error())
+		case errors.Is(err, service.ErrUnknownCustomField),
+			errors.Is(err, service.ErrInvalidCustomFieldValue),
+			errors.Is(err, service.ErrCustomFieldRequired):
+			utils.RespondBadRequest(c, "Invalid custom field value", err.Error())
+		case errors.Is(err, service.ErrInvalidStoryPoints),
+			errors.Is(err, service.ErrInvalidEstimate),
+			errors.Is(err, service.ErrEstimateMismatch):
+			utils.RespondBadRequest(c, "Invalid estimate", err.Error())
 		default:
 			utils.RespondInternalError(c, "Failed to update backlog item", err.Error())
 		}
@@ -190,7 +258,7 @@ func (h *BacklogHandler) Update(c *gin.Context) {
 
 // Delete handles DELETE /api/backlog/:id
 // @Summary Delete a backlog item
-// @Description Delete a backlog item by its ID
+// @Description Move a backlog item to the trash. It can be restored until the trash is purged.
 // @Tags backlog
 // @Produce json
 // @Security BearerAuth
@@ -233,6 +301,7 @@ func (h *BacklogHandler) Delete(c *gin.Context) {
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /backlog/{id}/status [patch]
 func (h *BacklogHandler) UpdateStatus(c *gin.Context) {
@@ -254,13 +323,17 @@ func (h *BacklogHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	item, err := h.backlogService.UpdateStatus(id, req.Status, userID)
+	item, err := h.backlogService.UpdateStatus(id, &req, userID)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrBacklogItemNotFound):
 			utils.RespondNotFound(c, "Backlog item not found")
 		case errors.Is(err, service.ErrInvalidStatus):
 			utils.RespondBadRequest(c, "Invalid status", err.Error())
+		case errors.Is(err, service.ErrTransitionNotAllowed):
+			utils.RespondError(c, http.StatusConflict, "Status transition not allowed", "TRANSITION_NOT_ALLOWED", err.Error())
+		case errors.Is(err, service.ErrWIPLimitExceeded):
+			utils.RespondError(c, http.StatusConflict, "Work-in-progress limit reached", "WIP_LIMIT_EXCEEDED", err.Error())
 		default:
 			utils.RespondInternalError(c, "Failed to update status", err.Error())
 		}
@@ -356,11 +429,14 @@ func (h *BacklogHandler) AddLabel(c *gin.Context) {
 
 	item, err := h.backlogService.AddLabel(id, req.Label, userID)
 	if err != nil {
-		if errors.Is(err, service.ErrBacklogItemNotFound) {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
 			utils.RespondNotFound(c, "Backlog item not found")
-			return
+		case errors.Is(err, service.ErrLabelNotInCatalog):
+			utils.RespondBadRequest(c, "Label not in catalog", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to add label", err.Error())
 		}
-		utils.RespondInternalError(c, "Failed to add label", err.Error())
 		return
 	}
 
@@ -413,29 +489,77 @@ func (h *BacklogHandler) RemoveLabel(c *gin.Context) {
 	utils.RespondSuccess(c, http.StatusOK, "Label removed successfully", item)
 }
 
-// AddComment handles POST /api/backlog/:id/comments
-// @Summary Add a comment to backlog item
-// @Description Add a comment to a backlog item
+// GetHistory handles GET /api/backlog/:id/history
+// @Summary Get backlog item history
+// @Description Get the history of a backlog item, newest first. Without limit or cursor the whole history is returned; otherwise one page with cursor metadata.
+// @Tags backlog
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param limit query int false "Entries per page (max 100)" default(50)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Success 200 {array} response.ItemHistoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/history [get]
+func (h *BacklogHandler) GetHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	var params request.HistoryQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.RespondBadRequest(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	history, next, err := h.backlogService.GetHistory(id, &params)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrInvalidCursor):
+			utils.RespondBadRequest(c, "Invalid cursor", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to fetch history", err.Error())
+		}
+		return
+	}
+
+	if params.Paginated() {
+		utils.RespondCursorPaginated(c, history, params.Limit, next)
+		return
+	}
+	utils.RespondSuccess(c, http.StatusOK, "", history)
+}
+
+// SetAssignees handles PUT /api/backlog/:id/assignees
+// @Summary Set backlog item assignees
+// @Description Replace the assignees of a backlog item; an empty list clears them
 // @Tags backlog
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Backlog Item ID"
-// @Param request body request.AddCommentRequest true "Add comment request"
-// @Success 201 {object} response.ItemHistoryResponse
+// @Param request body request.SetAssigneesRequest true "Set assignees request"
+// @Success 200 {object} response.BacklogItemResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
-// @Router /backlog/{id}/comments [post]
-func (h *BacklogHandler) AddComment(c *gin.Context) {
+// @Router /backlog/{id}/assignees [put]
+func (h *BacklogHandler) SetAssignees(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
 		return
 	}
 
-	var req request.AddCommentRequest
+	var req request.SetAssigneesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.RespondBadRequest(c, "Invalid request body", err.Error())
 		return
@@ -447,50 +571,406 @@ func (h *BacklogHandler) AddComment(c *gin.Context) {
 		return
 	}
 
-	history, err := h.backlogService.AddComment(id, req.Content, userID)
+	item, err := h.backlogService.SetAssignees(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrAssigneeNotFound):
+			utils.RespondBadRequest(c, "Invalid assignee", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to set assignees", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Assignees updated successfully", item)
+}
+
+// ClearAssignees handles DELETE /api/backlog/:id/assignees
+// @Summary Clear backlog item assignees
+// @Description Remove all assignees from a backlog item
+// @Tags backlog
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Success 200 {object} response.BacklogItemResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/assignees [delete]
+func (h *BacklogHandler) ClearAssignees(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	item, err := h.backlogService.ClearAssignees(id, userID)
 	if err != nil {
 		if errors.Is(err, service.ErrBacklogItemNotFound) {
 			utils.RespondNotFound(c, "Backlog item not found")
 			return
 		}
-		utils.RespondInternalError(c, "Failed to add comment", err.Error())
+		utils.RespondInternalError(c, "Failed to clear assignees", err.Error())
 		return
 	}
 
-	utils.RespondSuccess(c, http.StatusCreated, "Comment added successfully", history)
+	utils.RespondSuccess(c, http.StatusOK, "Assignees cleared successfully", item)
 }
 
-// GetHistory handles GET /api/backlog/:id/history
-// @Summary Get backlog item history
-// @Description Get the history of a backlog item
+// SetParent handles PATCH /api/backlog/:id/parent
+// @Summary Set backlog item parent
+// @Description Place a backlog item under a parent item, or detach it with a null parent_id. Epics hold stories, bugs and tasks; stories, bugs and tasks hold subtasks.
 // @Tags backlog
+// @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Backlog Item ID"
-// @Success 200 {array} response.ItemHistoryResponse
+// @Param request body request.SetParentRequest true "Set parent request"
+// @Success 200 {object} response.BacklogItemResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
-// @Router /backlog/{id}/history [get]
-func (h *BacklogHandler) GetHistory(c *gin.Context) {
+// @Router /backlog/{id}/parent [patch]
+func (h *BacklogHandler) SetParent(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.SetParentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	item, err := h.backlogService.SetParent(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrParentNotFound),
+			errors.Is(err, service.ErrParentNotInProject),
+			errors.Is(err, service.ErrInvalidParentType):
+			utils.RespondBadRequest(c, "Invalid parent item", err.Error())
+		case errors.Is(err, service.ErrParentCycle):
+			utils.RespondError(c, http.StatusConflict, "Parent link would create a cycle", "PARENT_CYCLE", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to set parent", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Parent updated successfully", item)
+}
+
+// SetDueDate handles PATCH /api/backlog/:id/due-date
+// @Summary Set backlog item due date
+// @Description Set the due date of a backlog item as YYYY-MM-DD, or clear it with a null due_date
+// @Tags backlog
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param request body request.SetDueDateRequest true "Set due date request"
+// @Success 200 {object} response.BacklogItemResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/due-date [patch]
+func (h *BacklogHandler) SetDueDate(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
 		return
 	}
 
-	history, err := h.backlogService.GetHistory(id)
+	var req request.SetDueDateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	item, err := h.backlogService.SetDueDate(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrInvalidDueDate):
+			utils.RespondBadRequest(c, "Invalid due date", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to set due date", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Due date updated successfully", item)
+}
+
+// Clone handles POST /api/backlog/:id/clone
+// @Summary Clone a backlog item
+// @Description Copy a backlog item into its own or another project, optionally with its labels, direct children and links. The clone starts in the initial workflow status, outside any sprint and unassigned.
+// @Tags backlog
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param request body request.CloneItemRequest true "Clone request"
+// @Success 201 {object} response.BacklogItemResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/clone [post]
+func (h *BacklogHandler) Clone(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.CloneItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	item, err := h.backlogService.Clone(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrProjectNotFound):
+			utils.RespondNotFound(c, "Project not found")
+		case errors.Is(err, service.ErrLabelNotInCatalog):
+			utils.RespondBadRequest(c, "Label not in catalog", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to clone backlog item", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusCreated, "Backlog item cloned successfully", item)
+}
+
+// Move handles POST /api/backlog/:id/move
+// @Summary Move a backlog item to another project
+// @Description Move a backlog item and its descendants to another project. They get new keys, are ranked last and leave their sprint; the item is detached from its parent. Statuses missing from the target workflow are mapped by category.
+// @Tags backlog
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param request body request.MoveItemRequest true "Move request"
+// @Success 200 {object} response.BacklogItemResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/move [post]
+func (h *BacklogHandler) Move(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.MoveItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	item, err := h.backlogService.Move(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrProjectNotFound):
+			utils.RespondNotFound(c, "Project not found")
+		case errors.Is(err, service.ErrSameProject):
+			utils.RespondBadRequest(c, "Invalid move", err.Error())
+		case errors.Is(err, service.ErrLabelNotInCatalog):
+			utils.RespondBadRequest(c, "Label not in catalog", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to move backlog item", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Backlog item moved successfully", item)
+}
+
+// Rank handles POST /api/backlog/:id/rank
+// @Summary Reorder a backlog item
+// @Description Move a backlog item directly before or after another item of the same project. Only the moved item's rank changes.
+// @Tags backlog
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param request body request.RankItemRequest true "Rank request"
+// @Success 200 {object} response.BacklogItemResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/rank [post]
+func (h *BacklogHandler) Rank(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.RankItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	item, err := h.backlogService.Rank(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrInvalidRankAnchor),
+			errors.Is(err, service.ErrRankAnchorNotFound):
+			utils.RespondBadRequest(c, "Invalid rank anchor", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to rank item", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Item ranked successfully", item)
+}
+
+// Bulk handles POST /api/backlog/bulk
+// @Summary Apply an operation to many backlog items
+// @Description Apply one operation (set_status, set_priority, set_sprint, add_label, remove_label, set_story_points, delete) to up to 100 items. All items are validated first and the changes are applied together; if any item fails, nothing is changed and the per-item results are returned with a 422.
+// @Tags backlog
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.BulkUpdateRequest true "Bulk update request"
+// @Success 200 {object} response.BulkUpdateResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/bulk [post]
+func (h *BacklogHandler) Bulk(c *gin.Context) {
+	var req request.BulkUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	result, err := h.backlogService.Bulk(&req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBulkValidationFailed):
+			utils.RespondErrorWithData(c, http.StatusUnprocessableEntity, err.Error(), "BULK_VALIDATION_FAILED", result)
+		case errors.Is(err, service.ErrInvalidBulkOperation),
+			errors.Is(err, service.ErrInvalidStatus),
+			errors.Is(err, service.ErrInvalidPriority),
+			errors.Is(err, service.ErrEmptyLabel):
+			utils.RespondBadRequest(c, "Invalid bulk operation", err.Error())
+		case errors.Is(err, service.ErrSprintNotFound),
+			errors.Is(err, service.ErrSprintNotInProject):
+			utils.RespondBadRequest(c, "Invalid sprint", err.Error())
+		case errors.Is(err, service.ErrWIPLimitExceeded):
+			utils.RespondError(c, http.StatusConflict, "Work-in-progress limit reached", "WIP_LIMIT_EXCEEDED", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to apply bulk operation", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Bulk operation applied successfully", result)
+}
+
+// GetChildren handles GET /api/backlog/:id/children
+// @Summary Get backlog item children
+// @Description Get the child items of a backlog item, such as the stories of an epic
+// @Tags backlog
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Success 200 {array} response.BacklogItemResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/children [get]
+func (h *BacklogHandler) GetChildren(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	children, err := h.backlogService.GetChildren(id)
 	if err != nil {
 		if errors.Is(err, service.ErrBacklogItemNotFound) {
 			utils.RespondNotFound(c, "Backlog item not found")
 			return
 		}
-		utils.RespondInternalError(c, "Failed to fetch history", err.Error())
+		utils.RespondInternalError(c, "Failed to fetch children", err.Error())
 		return
 	}
 
-	utils.RespondSuccess(c, http.StatusOK, "", history)
+	utils.RespondSuccess(c, http.StatusOK, "", children)
 }
 
 // Helper to convert string to constants.ItemStatus
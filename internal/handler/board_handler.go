@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type BoardHandler struct {
+	boardService service.BoardService
+}
+
+func NewBoardHandler(boardService service.BoardService) *BoardHandler {
+	return &BoardHandler{
+		boardService: boardService,
+	}
+}
+
+// GetBoard handles GET /api/board
+// @Summary Get project board
+// @Description Get a Kanban board for a project or one of its sprints, with one column per status
+// @Tags board
+// @Produce json
+// @Security BearerAuth
+// @Param project_id query string true "Project ID"
+// @Param sprint_id query string false "Sprint ID, 'active' for the active sprint or 'none' for unassigned items"
+// @Param search query string false "Full-text search in title, labels, description and comments"
+// @Param type query []string false "Filter by type (Story, Task, Bug, Epic, Subtask)"
+// @Param priority query []string false "Filter by priority (Critical, High, Medium, Low)"
+// @Param status query []string false "Limit the board to these status columns"
+// @Param labels query []string false "Filter by labels"
+// @Param assignee query []string false "Filter by assignee user ID, 'me' or 'unassigned'"
+// @Param due_before query string false "Filter by items due before a date (YYYY-MM-DD)"
+// @Param due_after query string false "Filter by items due after a date (YYYY-MM-DD)"
+// @Param overdue query bool false "Filter by items past their due date that are not done"
+// @Param q query string false "Query language filter, e.g. priority in (High, Critical) AND label = api"
+// @Success 200 {object} response.BoardResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /board [get]
+func (h *BoardHandler) GetBoard(c *gin.Context) {
+	var params request.BoardQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.RespondBadRequest(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	board, err := h.boardService.GetBoard(&params, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidProjectID):
+			utils.RespondBadRequest(c, "Invalid project ID", err.Error())
+		case errors.Is(err, service.ErrProjectNotFound):
+			utils.RespondNotFound(c, "Project not found")
+		case errors.Is(err, service.ErrSprintNotFound):
+			utils.RespondNotFound(c, "Sprint not found")
+		case errors.Is(err, service.ErrNoActiveSprint):
+			utils.RespondNotFound(c, "Project has no active sprint")
+		case errors.Is(err, service.ErrSprintNotInProject):
+			utils.RespondBadRequest(c, "Sprint does not belong to this project", err.Error())
+		case errors.Is(err, service.ErrInvalidQuery):
+			utils.RespondBadRequest(c, "Invalid query", err.Error())
+		case errors.Is(err, service.ErrInvalidSort):
+			utils.RespondBadRequest(c, "Invalid sort", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to fetch board", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", board)
+}
+
+// MoveItem handles PATCH /api/board/items/:id/move
+// @Summary Move a card on the board
+// @Description Move a backlog item to a status column and position within it
+// @Tags board
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param request body request.MoveBoardItemRequest true "Move item request"
+// @Success 200 {object} response.BoardColumnResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /board/items/{id}/move [patch]
+func (h *BoardHandler) MoveItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.MoveBoardItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	column, err := h.boardService.MoveItem(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrInvalidStatus):
+			utils.RespondBadRequest(c, "Invalid status", err.Error())
+		case errors.Is(err, service.ErrTransitionNotAllowed):
+			utils.RespondError(c, http.StatusConflict, "Status transition not allowed", "TRANSITION_NOT_ALLOWED", err.Error())
+		case errors.Is(err, service.ErrWIPLimitExceeded):
+			utils.RespondError(c, http.StatusConflict, "Work-in-progress limit reached", "WIP_LIMIT_EXCEEDED", err.Error())
+		case errors.Is(err, service.ErrSprintNotFound):
+			utils.RespondNotFound(c, "Sprint not found")
+		case errors.Is(err, service.ErrNoActiveSprint):
+			utils.RespondNotFound(c, "Project has no active sprint")
+		case errors.Is(err, service.ErrItemNotInSprint):
+			utils.RespondBadRequest(c, "Item is not on this board", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to move item", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Item moved successfully", column)
+}
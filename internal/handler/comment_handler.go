@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type CommentHandler struct {
+	commentService service.CommentService
+}
+
+func NewCommentHandler(commentService service.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// Create handles POST /api/backlog/:id/comments
+// @Summary Comment on a backlog item
+// @Description Add a comment to a backlog item, or reply to one of its comments with parent_id. @mentions of a user's email address or its local part are recorded.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param request body request.CreateCommentRequest true "Create comment request"
+// @Success 201 {object} response.CommentResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/comments [post]
+func (h *CommentHandler) Create(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	comment, err := h.commentService.Create(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrEmptyComment),
+			errors.Is(err, service.ErrParentCommentNotFound):
+			utils.RespondBadRequest(c, "Invalid comment", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to add comment", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusCreated, "Comment added successfully", comment)
+}
+
+// GetByItem handles GET /api/backlog/:id/comments
+// @Summary Get backlog item comments
+// @Description Get the comment threads of a backlog item, oldest first. Deleted comments are kept without content while they have replies.
+// @Tags comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Success 200 {array} response.CommentResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/comments [get]
+func (h *CommentHandler) GetByItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	comments, err := h.commentService.GetByItemID(id)
+	if err != nil {
+		if errors.Is(err, service.ErrBacklogItemNotFound) {
+			utils.RespondNotFound(c, "Backlog item not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch comments", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", comments)
+}
+
+// Update handles PUT /api/comments/:id
+// @Summary Edit a comment
+// @Description Edit one of your comments; the previous content is kept in its edit history
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Comment ID"
+// @Param request body request.UpdateCommentRequest true "Update comment request"
+// @Success 200 {object} response.CommentResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /comments/{id} [put]
+func (h *CommentHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid comment ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	comment, err := h.commentService.Update(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCommentNotFound):
+			utils.RespondNotFound(c, "Comment not found")
+		case errors.Is(err, service.ErrCommentForbidden):
+			utils.RespondForbidden(c, err.Error())
+		case errors.Is(err, service.ErrEmptyComment):
+			utils.RespondBadRequest(c, "Invalid comment", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to update comment", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Comment updated successfully", comment)
+}
+
+// Delete handles DELETE /api/comments/:id
+// @Summary Delete a comment
+// @Description Delete one of your comments; replies to it are kept
+// @Tags comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Comment ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /comments/{id} [delete]
+func (h *CommentHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid comment ID", "ID must be a valid UUID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.commentService.Delete(id, userID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrCommentNotFound):
+			utils.RespondNotFound(c, "Comment not found")
+		case errors.Is(err, service.ErrCommentForbidden):
+			utils.RespondForbidden(c, err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to delete comment", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Comment deleted successfully", nil)
+}
+
+// GetEdits handles GET /api/comments/:id/edits
+// @Summary Get comment edit history
+// @Description Get the previous versions of a comment, newest first
+// @Tags comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Comment ID"
+// @Success 200 {array} response.CommentEditResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /comments/{id}/edits [get]
+func (h *CommentHandler) GetEdits(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid comment ID", "ID must be a valid UUID")
+		return
+	}
+
+	edits, err := h.commentService.GetEdits(id)
+	if err != nil {
+		if errors.Is(err, service.ErrCommentNotFound) {
+			utils.RespondNotFound(c, "Comment not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch comment edits", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", edits)
+}
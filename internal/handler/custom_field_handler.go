@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type CustomFieldHandler struct {
+	fieldService service.CustomFieldService
+}
+
+func NewCustomFieldHandler(fieldService service.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		fieldService: fieldService,
+	}
+}
+
+// Create handles POST /api/projects/:id/custom-fields
+// @Summary Create a custom field
+// @Description Define a custom field for a project's items. Types are text, number, date, select, multi_select and user; select types need options. Items are filtered by the field as cf.<key> in the query language.
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body request.CreateCustomFieldRequest true "Create custom field request"
+// @Success 201 {object} response.CustomFieldResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/custom-fields [post]
+func (h *CustomFieldHandler) Create(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.CreateCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	field, err := h.fieldService.Create(projectID, &req)
+	if err != nil {
+		h.respondCustomFieldError(c, err, "Failed to create custom field")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusCreated, "Custom field created successfully", field)
+}
+
+// GetByProject handles GET /api/projects/:id/custom-fields
+// @Summary Get custom fields of a project
+// @Description Get the custom field definitions of a project in the order they were created
+// @Tags custom-fields
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {array} response.CustomFieldResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/custom-fields [get]
+func (h *CustomFieldHandler) GetByProject(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	fields, err := h.fieldService.GetByProjectID(projectID)
+	if err != nil {
+		h.respondCustomFieldError(c, err, "Failed to fetch custom fields")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", fields)
+}
+
+// GetByID handles GET /api/custom-fields/:id
+// @Summary Get a custom field
+// @Description Get a custom field definition by ID
+// @Tags custom-fields
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Custom field ID"
+// @Success 200 {object} response.CustomFieldResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /custom-fields/{id} [get]
+func (h *CustomFieldHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid custom field ID", "ID must be a valid UUID")
+		return
+	}
+
+	field, err := h.fieldService.GetByID(id)
+	if err != nil {
+		h.respondCustomFieldError(c, err, "Failed to fetch custom field")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", field)
+}
+
+// Update handles PUT /api/custom-fields/:id
+// @Summary Update a custom field
+// @Description Update a custom field's name, options or whether it is required. The key and type cannot change. Values already set on items are kept.
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Custom field ID"
+// @Param request body request.UpdateCustomFieldRequest true "Update custom field request"
+// @Success 200 {object} response.CustomFieldResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /custom-fields/{id} [put]
+func (h *CustomFieldHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid custom field ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.UpdateCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	field, err := h.fieldService.Update(id, &req)
+	if err != nil {
+		h.respondCustomFieldError(c, err, "Failed to update custom field")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Custom field updated successfully", field)
+}
+
+// Delete handles DELETE /api/custom-fields/:id
+// @Summary Delete a custom field
+// @Description Delete a custom field and clear its value from every item of the project. Each cleared item gets a history entry.
+// @Tags custom-fields
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Custom field ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /custom-fields/{id} [delete]
+func (h *CustomFieldHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid custom field ID", "ID must be a valid UUID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.fieldService.Delete(id, userID); err != nil {
+		h.respondCustomFieldError(c, err, "Failed to delete custom field")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Custom field deleted successfully", nil)
+}
+
+// respondCustomFieldError maps the errors shared by the custom field endpoints
+func (h *CustomFieldHandler) respondCustomFieldError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrProjectNotFound):
+		utils.RespondNotFound(c, "Project not found")
+	case errors.Is(err, service.ErrCustomFieldNotFound):
+		utils.RespondNotFound(c, "Custom field not found")
+	case errors.Is(err, service.ErrInvalidCustomFieldKey),
+		errors.Is(err, service.ErrReservedCustomFieldKey),
+		errors.Is(err, service.ErrInvalidCustomFieldType),
+		errors.Is(err, service.ErrInvalidCustomFieldOptions):
+		utils.RespondBadRequest(c, "Invalid custom field", err.Error())
+	case errors.Is(err, service.ErrCustomFieldExists):
+		utils.RespondError(c, http.StatusConflict, "Custom field key already in use", "CUSTOM_FIELD_EXISTS", err.Error())
+	default:
+		utils.RespondInternalError(c, fallback, err.Error())
+	}
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type EstimationHandler struct {
+	estimationService service.EstimationService
+}
+
+func NewEstimationHandler(estimationService service.EstimationService) *EstimationHandler {
+	return &EstimationHandler{
+		estimationService: estimationService,
+	}
+}
+
+// Get handles GET /api/projects/:id/estimation-scale
+// @Summary Get a project's estimation scale
+// @Description Get the estimation scale of a project and the values its items may take. Linear projects accept any story points from 0 to 100.
+// @Tags estimation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} response.EstimationScaleResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/estimation-scale [get]
+func (h *EstimationHandler) Get(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	scale, err := h.estimationService.Get(projectID)
+	if err != nil {
+		h.respondEstimationError(c, err, "Failed to fetch estimation scale")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", scale)
+}
+
+// Update handles PUT /api/projects/:id/estimation-scale
+// @Summary Update a project's estimation scale
+// @Description Set the estimation scale of a project: linear, fibonacci, powers_of_two, tshirt or custom. T-shirt and custom scales map labels to story points; T-shirt defaults to XS-XXL when no options are given. Estimates of open items are mapped onto the new scale and each changed item gets a history entry; done items and items of completed sprints keep their estimate, so past velocity does not change.
+// @Tags estimation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body request.UpdateEstimationScaleRequest true "Update estimation scale request"
+// @Success 200 {object} response.EstimationScaleResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/estimation-scale [put]
+func (h *EstimationHandler) Update(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.UpdateEstimationScaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	scale, err := h.estimationService.Update(projectID, &req, userID)
+	if err != nil {
+		h.respondEstimationError(c, err, "Failed to update estimation scale")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Estimation scale updated successfully", scale)
+}
+
+// respondEstimationError maps the errors shared by the estimation scale endpoints
+func (h *EstimationHandler) respondEstimationError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrProjectNotFound):
+		utils.RespondNotFound(c, "Project not found")
+	case errors.Is(err, service.ErrInvalidEstimationScale),
+		errors.Is(err, service.ErrInvalidEstimationOptions):
+		utils.RespondBadRequest(c, "Invalid estimation scale", err.Error())
+	default:
+		utils.RespondInternalError(c, fallback, err.Error())
+	}
+}
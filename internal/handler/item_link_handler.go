@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type ItemLinkHandler struct {
+	linkService service.ItemLinkService
+}
+
+func NewItemLinkHandler(linkService service.ItemLinkService) *ItemLinkHandler {
+	return &ItemLinkHandler{
+		linkService: linkService,
+	}
+}
+
+// Create handles POST /api/backlog/:id/links
+// @Summary Link two backlog items
+// @Description Create a typed link (blocks, is_blocked_by, relates_to, duplicates, is_duplicated_by) from the item to another item
+// @Tags links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param request body request.CreateItemLinkRequest true "Create link request"
+// @Success 201 {object} response.ItemLinkResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/links [post]
+func (h *ItemLinkHandler) Create(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.CreateItemLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	link, err := h.linkService.Create(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrInvalidLinkType),
+			errors.Is(err, service.ErrLinkTargetNotFound),
+			errors.Is(err, service.ErrSelfLink):
+			utils.RespondBadRequest(c, "Invalid link", err.Error())
+		case errors.Is(err, service.ErrLinkExists):
+			utils.RespondError(c, http.StatusConflict, "Items are already linked", "LINK_EXISTS", err.Error())
+		case errors.Is(err, service.ErrLinkCycle):
+			utils.RespondError(c, http.StatusConflict, "Blocking link would create a cycle", "LINK_CYCLE", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to create link", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusCreated, "Link created successfully", link)
+}
+
+// GetByItem handles GET /api/backlog/:id/links
+// @Summary Get backlog item links
+// @Description Get the links of a backlog item, described from the item's point of view
+// @Tags links
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Success 200 {array} response.ItemLinkResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/links [get]
+func (h *ItemLinkHandler) GetByItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	links, err := h.linkService.GetByItemID(id)
+	if err != nil {
+		if errors.Is(err, service.ErrBacklogItemNotFound) {
+			utils.RespondNotFound(c, "Backlog item not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch links", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", links)
+}
+
+// Delete handles DELETE /api/backlog/:id/links/:linkId
+// @Summary Remove a backlog item link
+// @Description Remove a link between two backlog items
+// @Tags links
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param linkId path string true "Link ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/links/{linkId} [delete]
+func (h *ItemLinkHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	linkID, err := uuid.Parse(c.Param("linkId"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid link ID", "ID must be a valid UUID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.linkService.Delete(id, linkID, userID); err != nil {
+		if errors.Is(err, service.ErrLinkNotFound) {
+			utils.RespondNotFound(c, "Link not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to remove link", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Link removed successfully", nil)
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type ItemTemplateHandler struct {
+	templateService service.ItemTemplateService
+}
+
+func NewItemTemplateHandler(templateService service.ItemTemplateService) *ItemTemplateHandler {
+	return &ItemTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// Create handles POST /api/projects/:id/templates
+// @Summary Create an item template
+// @Description Create a template that pre-fills new backlog items of a project and can create standard child items
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body request.CreateItemTemplateRequest true "Create item template request"
+// @Success 201 {object} response.ItemTemplateResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/templates [post]
+func (h *ItemTemplateHandler) Create(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.CreateItemTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	template, err := h.templateService.Create(projectID, &req, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrProjectNotFound) {
+			utils.RespondNotFound(c, "Project not found")
+			return
+		}
+		h.respondTemplateError(c, err, "Failed to create item template")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusCreated, "Item template created successfully", template)
+}
+
+// GetByProject handles GET /api/projects/:id/templates
+// @Summary Get item templates of a project
+// @Description Get all item templates of a project ordered by name
+// @Tags templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {array} response.ItemTemplateResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/templates [get]
+func (h *ItemTemplateHandler) GetByProject(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	templates, err := h.templateService.GetByProjectID(projectID)
+	if err != nil {
+		if errors.Is(err, service.ErrProjectNotFound) {
+			utils.RespondNotFound(c, "Project not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch item templates", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", templates)
+}
+
+// GetByID handles GET /api/templates/:id
+// @Summary Get an item template
+// @Description Get an item template by ID
+// @Tags templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Item Template ID"
+// @Success 200 {object} response.ItemTemplateResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /templates/{id} [get]
+func (h *ItemTemplateHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid template ID", "ID must be a valid UUID")
+		return
+	}
+
+	template, err := h.templateService.GetByID(id)
+	if err != nil {
+		if errors.Is(err, service.ErrTemplateNotFound) {
+			utils.RespondNotFound(c, "Item template not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch item template", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", template)
+}
+
+// Update handles PUT /api/templates/:id
+// @Summary Update an item template
+// @Description Replace all fields of an item template
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Item Template ID"
+// @Param request body request.UpdateItemTemplateRequest true "Update item template request"
+// @Success 200 {object} response.ItemTemplateResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /templates/{id} [put]
+func (h *ItemTemplateHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid template ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.UpdateItemTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	template, err := h.templateService.Update(id, &req)
+	if err != nil {
+		if errors.Is(err, service.ErrTemplateNotFound) {
+			utils.RespondNotFound(c, "Item template not found")
+			return
+		}
+		h.respondTemplateError(c, err, "Failed to update item template")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Item template updated successfully", template)
+}
+
+// Delete handles DELETE /api/templates/:id
+// @Summary Delete an item template
+// @Description Delete an item template. Items created from it are not affected.
+// @Tags templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Item Template ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /templates/{id} [delete]
+func (h *ItemTemplateHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid template ID", "ID must be a valid UUID")
+		return
+	}
+
+	if err := h.templateService.Delete(id); err != nil {
+		if errors.Is(err, service.ErrTemplateNotFound) {
+			utils.RespondNotFound(c, "Item template not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to delete item template", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Item template deleted successfully", nil)
+}
+
+// respondTemplateError maps template validation errors shared by Create and Update.
+func (h *ItemTemplateHandler) respondTemplateError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidItemType):
+		utils.RespondBadRequest(c, "Invalid item type", err.Error())
+	case errors.Is(err, service.ErrInvalidPriority):
+		utils.RespondBadRequest(c, "Invalid priority", err.Error())
+	case errors.Is(err, service.ErrInvalidParentType):
+		utils.RespondBadRequest(c, "Invalid child item type", err.Error())
+	case errors.Is(err, service.ErrTemplateExists):
+		utils.RespondError(c, http.StatusConflict, "Item template name already in use", "TEMPLATE_EXISTS", err.Error())
+	default:
+		utils.RespondInternalError(c, fallback, err.Error())
+	}
+}
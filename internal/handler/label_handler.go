@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type LabelHandler struct {
+	labelService service.LabelService
+}
+
+func NewLabelHandler(labelService service.LabelService) *LabelHandler {
+	return &LabelHandler{
+		labelService: labelService,
+	}
+}
+
+// Create handles POST /api/projects/:id/labels
+// @Summary Create a label
+// @Description Add a label with a color and description to a project's label catalog. Names are unique ignoring case.
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body request.CreateLabelRequest true "Create label request"
+// @Success 201 {object} response.LabelResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/labels [post]
+func (h *LabelHandler) Create(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.CreateLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	label, err := h.labelService.Create(projectID, &req, userID)
+	if err != nil {
+		h.respondLabelError(c, err, "Failed to create label")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusCreated, "Label created successfully", label)
+}
+
+// GetByProject handles GET /api/projects/:id/labels
+// @Summary Get labels of a project
+// @Description Get the project's label catalog together with labels used on items but missing from it, each with its number of items, most used first. q keeps the labels starting with it for autocomplete.
+// @Tags labels
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param q query string false "Name prefix, ignoring case"
+// @Param limit query int false "Maximum number of labels (default 20 with q)"
+// @Success 200 {array} response.LabelResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/labels [get]
+func (h *LabelHandler) GetByProject(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	var params request.LabelQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.RespondBadRequest(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	labels, err := h.labelService.GetByProjectID(projectID, &params)
+	if err != nil {
+		h.respondLabelError(c, err, "Failed to fetch labels")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", labels)
+}
+
+// Merge handles POST /api/projects/:id/labels/merge
+// @Summary Merge labels
+// @Description Replace the source labels with the target catalog label on every item and template of the project in one transaction. Sources in the catalog are removed from it.
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body request.MergeLabelsRequest true "Merge labels request"
+// @Success 200 {object} response.LabelChangeResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/labels/merge [post]
+func (h *LabelHandler) Merge(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.MergeLabelsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	result, err := h.labelService.Merge(projectID, &req, userID)
+	if err != nil {
+		h.respondLabelError(c, err, "Failed to merge labels")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Labels merged successfully", result)
+}
+
+// Update handles PUT /api/labels/:id
+// @Summary Update a label
+// @Description Update a catalog label. A new name renames the label on every item and template of the project in one transaction.
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Label ID"
+// @Param request body request.UpdateLabelRequest true "Update label request"
+// @Success 200 {object} response.LabelChangeResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /labels/{id} [put]
+func (h *LabelHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid label ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.UpdateLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	result, err := h.labelService.Update(id, &req, userID)
+	if err != nil {
+		h.respondLabelError(c, err, "Failed to update label")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Label updated successfully", result)
+}
+
+// Delete handles DELETE /api/labels/:id
+// @Summary Delete a label
+// @Description Remove a label from the catalog. Items keep it as a label outside the catalog.
+// @Tags labels
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Label ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /labels/{id} [delete]
+func (h *LabelHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid label ID", "ID must be a valid UUID")
+		return
+	}
+
+	if err := h.labelService.Delete(id); err != nil {
+		h.respondLabelError(c, err, "Failed to delete label")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Label deleted successfully", nil)
+}
+
+// respondLabelError maps the errors shared by the label endpoints
+func (h *LabelHandler) respondLabelError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrProjectNotFound):
+		utils.RespondNotFound(c, "Project not found")
+	case errors.Is(err, service.ErrLabelNotFound):
+		utils.RespondNotFound(c, "Label not found")
+	case errors.Is(err, service.ErrEmptyLabel):
+		utils.RespondBadRequest(c, "Invalid label", err.Error())
+	case errors.Is(err, service.ErrLabelMergeSelf):
+		utils.RespondBadRequest(c, "Invalid merge", err.Error())
+	case errors.Is(err, service.ErrLabelExists):
+		utils.RespondError(c, http.StatusConflict, "Label name already in use", "LABEL_EXISTS", err.Error())
+	default:
+		utils.RespondInternalError(c, fallback, err.Error())
+	}
+}
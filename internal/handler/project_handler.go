@@ -176,7 +176,7 @@ func (h *ProjectHandler) Update(c *gin.Context) {
 
 // Delete handles DELETE /api/projects/:id
 // @Summary Delete a project
-// @Description Delete a project by its ID
+// @Description Move a project to the trash together with its sprints and items. It can be restored until the trash is purged.
 // @Tags projects
 // @Produce json
 // @Security BearerAuth
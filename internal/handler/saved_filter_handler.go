@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type SavedFilterHandler struct {
+	filterService service.SavedFilterService
+}
+
+func NewSavedFilterHandler(filterService service.SavedFilterService) *SavedFilterHandler {
+	return &SavedFilterHandler{
+		filterService: filterService,
+	}
+}
+
+// Create handles POST /api/projects/:id/filters
+// @Summary Save a backlog query
+// @Description Save a named query-language filter for a project, optionally shared with teammates
+// @Tags filters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body request.CreateSavedFilterRequest true "Create saved filter request"
+// @Success 201 {object} response.SavedFilterResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/filters [post]
+func (h *SavedFilterHandler) Create(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.CreateSavedFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	filter, err := h.filterService.Create(projectID, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrProjectNotFound):
+			utils.RespondNotFound(c, "Project not found")
+		case errors.Is(err, service.ErrInvalidQuery):
+			utils.RespondBadRequest(c, "Invalid query", err.Error())
+		case errors.Is(err, service.ErrSavedFilterExists):
+			utils.RespondError(c, http.StatusConflict, "Saved filter name already in use", "FILTER_EXISTS", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to save filter", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusCreated, "Filter saved successfully", filter)
+}
+
+// GetByProject handles GET /api/projects/:id/filters
+// @Summary Get saved filters of a project
+// @Description Get the user's own saved filters of a project and those shared by teammates
+// @Tags filters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {array} response.SavedFilterResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/filters [get]
+func (h *SavedFilterHandler) GetByProject(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	filters, err := h.filterService.GetByProjectID(projectID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrProjectNotFound) {
+			utils.RespondNotFound(c, "Project not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch saved filters", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", filters)
+}
+
+// GetByID handles GET /api/filters/:id
+// @Summary Get a saved filter
+// @Description Get a saved filter owned by or shared with the user
+// @Tags filters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved Filter ID"
+// @Success 200 {object} response.SavedFilterResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /filters/{id} [get]
+func (h *SavedFilterHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid filter ID", "ID must be a valid UUID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	filter, err := h.filterService.GetByID(id, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrSavedFilterNotFound) {
+			utils.RespondNotFound(c, "Saved filter not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch saved filter", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", filter)
+}
+
+// Update handles PUT /api/filters/:id
+// @Summary Update a saved filter
+// @Description Rename, change the query of, or share a saved filter. Only the owner can update it.
+// @Tags filters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved Filter ID"
+// @Param request body request.UpdateSavedFilterRequest true "Update saved filter request"
+// @Success 200 {object} response.SavedFilterResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /filters/{id} [put]
+func (h *SavedFilterHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid filter ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.UpdateSavedFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	filter, err := h.filterService.Update(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSavedFilterNotFound):
+			utils.RespondNotFound(c, "Saved filter not found")
+		case errors.Is(err, service.ErrSavedFilterForbidden):
+			utils.RespondForbidden(c, err.Error())
+		case errors.Is(err, service.ErrInvalidQuery):
+			utils.RespondBadRequest(c, "Invalid query", err.Error())
+		case errors.Is(err, service.ErrSavedFilterExists):
+			utils.RespondError(c, http.StatusConflict, "Saved filter name already in use", "FILTER_EXISTS", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to update saved filter", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Filter updated successfully", filter)
+}
+
+// Delete handles DELETE /api/filters/:id
+// @Summary Delete a saved filter
+// @Description Delete a saved filter. Only the owner can delete it.
+// @Tags filters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved Filter ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /filters/{id} [delete]
+func (h *SavedFilterHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid filter ID", "ID must be a valid UUID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.filterService.Delete(id, userID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrSavedFilterNotFound):
+			utils.RespondNotFound(c, "Saved filter not found")
+		case errors.Is(err, service.ErrSavedFilterForbidden):
+			utils.RespondForbidden(c, err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to delete saved filter", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Filter deleted successfully", nil)
+}
@@ -69,8 +69,11 @@ func (h *SprintHandler) Create(c *gin.Context) {
 // @Security BearerAuth
 // @Param project_id query string false "Filter by project ID"
 // @Param status query []string false "Filter by status (Planning, Active, Completed, Cancelled)"
+// @Param sort query string false "Comma-separated sort fields with optional :asc or :desc, e.g. status,start_date:desc. Fields: name, status, start_date, end_date, created_at, updated_at"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Cursor from next_cursor of the previous page; takes precedence over page"
+// @Param include_total query bool false "Count all matches; defaults to true for numbered pages and false when paging by cursor"
 // @Success 200 {object} response.SprintListResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
@@ -85,7 +88,14 @@ func (h *SprintHandler) GetAll(c *gin.Context) {
 
 	result, err := h.sprintService.GetAll(&params)
 	if err != nil {
-		utils.RespondInternalError(c, "Failed to fetch sprints", err.Error())
+		switch {
+		case errors.Is(err, service.ErrInvalidSort):
+			utils.RespondBadRequest(c, "Invalid sort", err.Error())
+		case errors.Is(err, service.ErrInvalidCursor):
+			utils.RespondBadRequest(c, "Invalid cursor", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to fetch sprints", err.Error())
+		}
 		return
 	}
 
@@ -176,7 +186,7 @@ func (h *SprintHandler) Update(c *gin.Context) {
 
 // Delete handles DELETE /api/sprints/:id
 // @Summary Delete a sprint
-// @Description Delete a sprint by its ID
+// @Description Move a sprint to the trash. It can be restored until the trash is purged.
 // @Tags sprints
 // @Produce json
 // @Security BearerAuth
@@ -441,11 +451,13 @@ func (h *SprintHandler) RemoveItem(c *gin.Context) {
 
 // GetHistory handles GET /api/sprints/:id/history
 // @Summary Get sprint history
-// @Description Get the history of a sprint
+// @Description Get the history of a sprint, newest first. Without limit or cursor the whole history is returned; otherwise one page with cursor metadata.
 // @Tags sprints
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Sprint ID"
+// @Param limit query int false "Entries per page (max 100)" default(50)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
 // @Success 200 {array} response.SprintHistoryResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
@@ -459,16 +471,29 @@ func (h *SprintHandler) GetHistory(c *gin.Context) {
 		return
 	}
 
-	history, err := h.sprintService.GetHistory(id)
+	var params request.HistoryQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.RespondBadRequest(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	history, next, err := h.sprintService.GetHistory(id, &params)
 	if err != nil {
-		if errors.Is(err, service.ErrSprintNotFound) {
+		switch {
+		case errors.Is(err, service.ErrSprintNotFound):
 			utils.RespondNotFound(c, "Sprint not found")
-			return
+		case errors.Is(err, service.ErrInvalidCursor):
+			utils.RespondBadRequest(c, "Invalid cursor", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to fetch history", err.Error())
 		}
-		utils.RespondInternalError(c, "Failed to fetch history", err.Error())
 		return
 	}
 
+	if params.Paginated() {
+		utils.RespondCursorPaginated(c, history, params.Limit, next)
+		return
+	}
 	utils.RespondSuccess(c, http.StatusOK, "", history)
 }
 
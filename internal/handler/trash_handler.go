@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type TrashHandler struct {
+	trashService service.TrashService
+}
+
+func NewTrashHandler(trashService service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// GetProjectTrash handles GET /api/projects/:id/trash
+// @Summary Get a project's trash
+// @Description Get the deleted sprints and items of a project, with the time they will be purged. Works for deleted projects too.
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} response.ProjectTrashResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/trash [get]
+func (h *TrashHandler) GetProjectTrash(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	trash, err := h.trashService.GetProjectTrash(id)
+	if err != nil {
+		h.respondTrashError(c, err, "Failed to fetch trash")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", trash)
+}
+
+// GetDeletedProjects handles GET /api/trash/projects
+// @Summary Get deleted projects
+// @Description Get the projects in the trash, most recently deleted first
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} response.TrashedProjectResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /trash/projects [get]
+func (h *TrashHandler) GetDeletedProjects(c *gin.Context) {
+	projects, err := h.trashService.GetDeletedProjects()
+	if err != nil {
+		utils.RespondInternalError(c, "Failed to fetch deleted projects", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", projects)
+}
+
+// RestoreItem handles POST /api/backlog/:id/restore
+// @Summary Restore a backlog item
+// @Description Take a deleted backlog item out of the trash. Items of a deleted project are restored with the project.
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Success 200 {object} response.RestoreResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/restore [post]
+func (h *TrashHandler) RestoreItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+	h.restore(c, id, h.trashService.RestoreItem, "Backlog item restored")
+}
+
+// RestoreSprint handles POST /api/sprints/:id/restore
+// @Summary Restore a sprint
+// @Description Take a deleted sprint out of the trash. Sprints of a deleted project are restored with the project.
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sprint ID"
+// @Success 200 {object} response.RestoreResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /sprints/{id}/restore [post]
+func (h *TrashHandler) RestoreSprint(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid sprint ID", "ID must be a valid UUID")
+		return
+	}
+	h.restore(c, id, h.trashService.RestoreSprint, "Sprint restored")
+}
+
+// RestoreProject handles POST /api/projects/:id/restore
+// @Summary Restore a project
+// @Description Take a deleted project out of the trash together with the sprints and items deleted with it
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} response.RestoreResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/restore [post]
+func (h *TrashHandler) RestoreProject(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+	h.restore(c, id, h.trashService.RestoreProject, "Project restored")
+}
+
+func (h *TrashHandler) restore(c *gin.Context, id uuid.UUID, restore func(id, userID uuid.UUID) (*response.RestoreResponse, error), message string) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	restored, err := restore(id, userID)
+	if err != nil {
+		h.respondTrashError(c, err, "Failed to restore")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, message, restored)
+}
+
+// respondTrashError maps the errors shared by the trash endpoints
+func (h *TrashHandler) respondTrashError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrProjectNotFound):
+		utils.RespondNotFound(c, "Project not found")
+	case errors.Is(err, service.ErrSprintNotFound):
+		utils.RespondNotFound(c, "Sprint not found")
+	case errors.Is(err, service.ErrBacklogItemNotFound):
+		utils.RespondNotFound(c, "Backlog item not found")
+	case errors.Is(err, service.ErrNotInTrash):
+		utils.RespondNotFound(c, "Not in the trash")
+	case errors.Is(err, service.ErrProjectInTrash):
+		utils.RespondError(c, http.StatusConflict, "Project is in the trash", "PROJECT_IN_TRASH", err.Error())
+	default:
+		utils.RespondInternalError(c, message, err.Error())
+	}
+}
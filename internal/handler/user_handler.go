@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"sprint-backlog/internal/dto/request"
 	"sprint-backlog/internal/service"
 	"sprint-backlog/internal/utils"
 )
@@ -80,6 +82,8 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path string true "User ID"
 // @Param limit query int false "Maximum number of activities to return" default(50)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Count all activities; defaults to true without a cursor and false with one"
 // @Success 200 {object} utils.SuccessResponse{data=response.UserActivitiesResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
@@ -94,15 +98,27 @@ func (h *UserHandler) GetActivities(c *gin.Context) {
 	}
 
 	// Get limit from query params (default 50)
-	limit := 50
+	params := request.ActivityQueryParams{Limit: 50, Cursor: c.Query("cursor")}
 	if limitStr := c.Query("limit"); limitStr != "" {
 		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
+			params.Limit = parsedLimit
 		}
 	}
 
-	activities, err := h.userService.GetActivities(id, limit)
+	// Count all activities unless paging by cursor (default) or told otherwise
+	params.IncludeTotal = params.Cursor == ""
+	if totalStr := c.Query("include_total"); totalStr != "" {
+		if includeTotal, err := strconv.ParseBool(totalStr); err == nil {
+			params.IncludeTotal = includeTotal
+		}
+	}
+
+	activities, err := h.userService.GetActivities(id, &params)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			utils.RespondBadRequest(c, "Invalid cursor", err.Error())
+			return
+		}
 		utils.RespondInternalError(c, "Failed to get user activities", err.Error())
 		return
 	}
@@ -110,6 +126,47 @@ func (h *UserHandler) GetActivities(c *gin.Context) {
 	utils.RespondSuccess(c, http.StatusOK, "User activities retrieved successfully", activities)
 }
 
+// GetDueItems returns a user's overdue and upcoming items
+// @Summary Get user due items
+// @Description Get the open items assigned to a user across all projects that are overdue or due within the next days
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param days query int false "Number of days ahead to include upcoming items for (1-365)" default(14)
+// @Success 200 {object} utils.SuccessResponse{data=response.DueItemsResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /users/{id}/due-items [get]
+func (h *UserHandler) GetDueItems(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Get days from query params (default 14)
+	days := 14
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsedDays, err := strconv.Atoi(daysStr)
+		if err != nil || parsedDays < 1 || parsedDays > 365 {
+			utils.RespondBadRequest(c, "Invalid days", "days must be a number between 1 and 365")
+			return
+		}
+		days = parsedDays
+	}
+
+	items, err := h.userService.GetDueItems(id, days)
+	if err != nil {
+		utils.RespondInternalError(c, "Failed to get due items", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Due items retrieved successfully", items)
+}
+
 // UpdateProfile updates the current user's profile
 // @Summary Update user profile
 // @Description Update the current user's profile information
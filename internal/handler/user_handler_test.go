@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"sprint-backlog/internal/dto/request"
 	"sprint-backlog/internal/dto/response"
 )
 
@@ -30,14 +31,22 @@ func (m *MockUserService) GetByID(id uuid.UUID) (*response.UserResponse, error)
 	return args.Get(0).(*response.UserResponse), args.Error(1)
 }
 
-func (m *MockUserService) GetActivities(userID uuid.UUID, limit int) (*response.UserActivitiesResponse, error) {
-	args := m.Called(userID, limit)
+func (m *MockUserService) GetActivities(userID uuid.UUID, params *request.ActivityQueryParams) (*response.UserActivitiesResponse, error) {
+	args := m.Called(userID, params)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*response.UserActivitiesResponse), args.Error(1)
 }
 
+func (m *MockUserService) GetDueItems(userID uuid.UUID, days int) (*response.DueItemsResponse, error) {
+	args := m.Called(userID, days)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.DueItemsResponse), args.Error(1)
+}
+
 func TestUserHandler_GetAll(t *testing.T) {
 	t.Run("should return all users", func(t *testing.T) {
 		mockService := new(MockUserService)
@@ -151,10 +160,9 @@ func TestUserHandler_GetActivities(t *testing.T) {
 		userID := uuid.New()
 		activities := &response.UserActivitiesResponse{
 			Activities: []response.UserActivityResponse{},
-			Total:      0,
 			Limit:      50,
 		}
-		mockService.On("GetActivities", userID, 50).Return(activities, nil)
+		mockService.On("GetActivities", userID, &request.ActivityQueryParams{Limit: 50, IncludeTotal: true}).Return(activities, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/"+userID.String()+"/activities", nil)
 		w := httptest.NewRecorder()
@@ -175,10 +183,9 @@ func TestUserHandler_GetActivities(t *testing.T) {
 		userID := uuid.New()
 		activities := &response.UserActivitiesResponse{
 			Activities: []response.UserActivityResponse{},
-			Total:      0,
 			Limit:      100,
 		}
-		mockService.On("GetActivities", userID, 100).Return(activities, nil)
+		mockService.On("GetActivities", userID, &request.ActivityQueryParams{Limit: 100, IncludeTotal: true}).Return(activities, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/"+userID.String()+"/activities?limit=100", nil)
 		w := httptest.NewRecorder()
@@ -189,6 +196,29 @@ func TestUserHandler_GetActivities(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("should skip the total when paging by cursor", func(t *testing.T) {
+		mockService := new(MockUserService)
+		handler := NewUserHandler(mockService)
+
+		router := setupTestRouter()
+		router.GET("/users/:id/activities", handler.GetActivities)
+
+		userID := uuid.New()
+		activities := &response.UserActivitiesResponse{
+			Activities: []response.UserActivityResponse{},
+			Limit:      20,
+		}
+		mockService.On("GetActivities", userID, &request.ActivityQueryParams{Cursor: "abc", Limit: 20}).Return(activities, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/"+userID.String()+"/activities?limit=20&cursor=abc", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("should return 400 for invalid UUID", func(t *testing.T) {
 		mockService := new(MockUserService)
 		handler := NewUserHandler(mockService)
@@ -204,3 +234,45 @@ func TestUserHandler_GetActivities(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
+
+func TestUserHandler_GetDueItems(t *testing.T) {
+	t.Run("should default to two weeks ahead", func(t *testing.T) {
+		mockService := new(MockUserService)
+		handler := NewUserHandler(mockService)
+
+		router := setupTestRouter()
+		router.GET("/users/:id/due-items", handler.GetDueItems)
+
+		userID := uuid.New()
+		items := &response.DueItemsResponse{
+			Overdue:  []response.DueItemResponse{},
+			Upcoming: []response.DueItemResponse{},
+			Days:     14,
+		}
+		mockService.On("GetDueItems", userID, 14).Return(items, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/"+userID.String()+"/due-items", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should reject days out of range", func(t *testing.T) {
+		mockService := new(MockUserService)
+		handler := NewUserHandler(mockService)
+
+		router := setupTestRouter()
+		router.GET("/users/:id/due-items", handler.GetDueItems)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/"+uuid.New().String()+"/due-items?days=0", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GetDueItems", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+	"sprint-backlog/pkg/constants"
+)
+
+type WatcherHandler struct {
+	watcherService service.WatcherService
+}
+
+func NewWatcherHandler(watcherService service.WatcherService) *WatcherHandler {
+	return &WatcherHandler{
+		watcherService: watcherService,
+	}
+}
+
+// WatchItem handles POST /api/backlog/:id/watch
+// @Summary Watch a backlog item
+// @Description Subscribe the current user to the changes of a backlog item. Watching an item twice keeps the original subscription.
+// @Tags watchers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Success 200 {object} response.WatcherResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/watch [post]
+func (h *WatcherHandler) WatchItem(c *gin.Context) {
+	h.watch(c, constants.WatchEntityItem)
+}
+
+// UnwatchItem handles DELETE /api/backlog/:id/watch
+// @Summary Stop watching a backlog item
+// @Description Remove the current user's subscription to a backlog item, including automatic ones
+// @Tags watchers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/watch [delete]
+func (h *WatcherHandler) UnwatchItem(c *gin.Context) {
+	h.unwatch(c, constants.WatchEntityItem)
+}
+
+// GetItemWatchers handles GET /api/backlog/:id/watchers
+// @Summary Get backlog item watchers
+// @Description Get the users watching a backlog item and why they watch it
+// @Tags watchers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Success 200 {array} response.WatcherResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/watchers [get]
+func (h *WatcherHandler) GetItemWatchers(c *gin.Context) {
+	h.getWatchers(c, constants.WatchEntityItem)
+}
+
+// WatchSprint handles POST /api/sprints/:id/watch
+// @Summary Watch a sprint
+// @Description Subscribe the current user to the changes of a sprint. Watching a sprint twice keeps the original subscription.
+// @Tags watchers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sprint ID"
+// @Success 200 {object} response.WatcherResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /sprints/{id}/watch [post]
+func (h *WatcherHandler) WatchSprint(c *gin.Context) {
+	h.watch(c, constants.WatchEntitySprint)
+}
+
+// UnwatchSprint handles DELETE /api/sprints/:id/watch
+// @Summary Stop watching a sprint
+// @Description Remove the current user's subscription to a sprint, including automatic ones
+// @Tags watchers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sprint ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /sprints/{id}/watch [delete]
+func (h *WatcherHandler) UnwatchSprint(c *gin.Context) {
+	h.unwatch(c, constants.WatchEntitySprint)
+}
+
+// GetSprintWatchers handles GET /api/sprints/:id/watchers
+// @Summary Get sprint watchers
+// @Description Get the users watching a sprint and why they watch it
+// @Tags watchers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sprint ID"
+// @Success 200 {array} response.WatcherResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /sprints/{id}/watchers [get]
+func (h *WatcherHandler) GetSprintWatchers(c *gin.Context) {
+	h.getWatchers(c, constants.WatchEntitySprint)
+}
+
+// GetWatching handles GET /api/users/:id/watching
+// @Summary Get the entities a user watches
+// @Description Get the backlog items and sprints a user watches, most recently watched first
+// @Tags watchers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param type query string false "Only list watched entities of this type (item, sprint)"
+// @Success 200 {array} response.WatchedEntityResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /users/{id}/watching [get]
+func (h *WatcherHandler) GetWatching(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid user ID", "ID must be a valid UUID")
+		return
+	}
+
+	var params request.WatchingQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.RespondBadRequest(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	watching, err := h.watcherService.GetWatching(id, &params)
+	if err != nil {
+		utils.RespondInternalError(c, "Failed to fetch watched entities", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", watching)
+}
+
+func (h *WatcherHandler) watch(c *gin.Context, entityType constants.WatchEntityType) {
+	id, ok := parseWatchedID(c, entityType)
+	if !ok {
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	watcher, err := h.watcherService.Watch(entityType, id, userID)
+	if err != nil {
+		h.respondWatchError(c, err, "Failed to watch")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Watching", watcher)
+}
+
+func (h *WatcherHandler) unwatch(c *gin.Context, entityType constants.WatchEntityType) {
+	id, ok := parseWatchedID(c, entityType)
+	if !ok {
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.watcherService.Unwatch(entityType, id, userID); err != nil {
+		h.respondWatchError(c, err, "Failed to stop watching")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Stopped watching", nil)
+}
+
+func (h *WatcherHandler) getWatchers(c *gin.Context, entityType constants.WatchEntityType) {
+	id, ok := parseWatchedID(c, entityType)
+	if !ok {
+		return
+	}
+
+	watchers, err := h.watcherService.GetWatchers(entityType, id)
+	if err != nil {
+		h.respondWatchError(c, err, "Failed to fetch watchers")
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", watchers)
+}
+
+// parseWatchedID parses the ID of the watched entity, responding with a bad request
+// when it is invalid
+func parseWatchedID(c *gin.Context, entityType constants.WatchEntityType) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		if entityType == constants.WatchEntitySprint {
+			utils.RespondBadRequest(c, "Invalid sprint ID", "ID must be a valid UUID")
+		} else {
+			utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		}
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondWatchError maps the errors shared by the watch endpoints
+func (h *WatcherHandler) respondWatchError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrBacklogItemNotFound):
+		utils.RespondNotFound(c, "Backlog item not found")
+	case errors.Is(err, service.ErrSprintNotFound):
+		utils.RespondNotFound(c, "Sprint not found")
+	case errors.Is(err, service.ErrNotWatching):
+		utils.RespondNotFound(c, "Not watching")
+	default:
+		utils.RespondInternalError(c, message, err.Error())
+	}
+}
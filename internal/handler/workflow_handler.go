@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type WorkflowHandler struct {
+	workflowService service.WorkflowService
+}
+
+func NewWorkflowHandler(workflowService service.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+	}
+}
+
+// GetByProject handles GET /api/projects/:id/workflow
+// @Summary Get project workflow
+// @Description Get the statuses, categories and allowed transitions of a project
+// @Tags workflows
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} response.WorkflowResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/workflow [get]
+func (h *WorkflowHandler) GetByProject(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	workflow, err := h.workflowService.GetByProjectID(projectID)
+	if err != nil {
+		if errors.Is(err, service.ErrProjectNotFound) {
+			utils.RespondNotFound(c, "Project not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch workflow", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", workflow)
+}
+
+// Update handles PUT /api/projects/:id/workflow
+// @Summary Update project workflow
+// @Description Replace the statuses and allowed transitions of a project
+// @Tags workflows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body request.UpdateWorkflowRequest true "Update workflow request"
+// @Success 200 {object} response.WorkflowResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/workflow [put]
+func (h *WorkflowHandler) Update(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.UpdateWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	workflow, err := h.workflowService.Update(projectID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrProjectNotFound):
+			utils.RespondNotFound(c, "Project not found")
+		case errors.Is(err, service.ErrInvalidStatus),
+			errors.Is(err, service.ErrInvalidStatusCategory),
+			errors.Is(err, service.ErrDuplicateWorkflowStatus),
+			errors.Is(err, service.ErrUnknownTransitionStatus),
+			errors.Is(err, service.ErrInvalidWIPLimit):
+			utils.RespondBadRequest(c, "Invalid workflow", err.Error())
+		case errors.Is(err, service.ErrWorkflowStatusInUse):
+			utils.RespondError(c, http.StatusConflict, "Items still use a removed status", "WORKFLOW_STATUS_IN_USE", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to update workflow", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Workflow updated successfully", workflow)
+}
+
+// UpdateWIPLimits handles PUT /api/projects/:id/wip-limits
+// @Summary Update work-in-progress limits
+// @Description Set or clear the work-in-progress limit of the project's status columns
+// @Tags workflows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body request.UpdateWIPLimitsRequest true "Update WIP limits request"
+// @Success 200 {object} response.WorkflowResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /projects/{id}/wip-limits [put]
+func (h *WorkflowHandler) UpdateWIPLimits(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid project ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.UpdateWIPLimitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	workflow, err := h.workflowService.UpdateWIPLimits(projectID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrProjectNotFound):
+			utils.RespondNotFound(c, "Project not found")
+		case errors.Is(err, service.ErrInvalidStatus):
+			utils.RespondBadRequest(c, "Invalid status", err.Error())
+		case errors.Is(err, service.ErrInvalidWIPLimit):
+			utils.RespondBadRequest(c, "Invalid WIP limit", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to update WIP limits", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "WIP limits updated successfully", workflow)
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/service"
+	"sprint-backlog/internal/utils"
+)
+
+type WorklogHandler struct {
+	worklogService service.WorklogService
+}
+
+func NewWorklogHandler(worklogService service.WorklogService) *WorklogHandler {
+	return &WorklogHandler{
+		worklogService: worklogService,
+	}
+}
+
+// Create handles POST /api/backlog/:id/worklogs
+// @Summary Log work on a backlog item
+// @Description Log minutes worked on a backlog item. They are added to the item's time spent and taken off its remaining estimate.
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Param request body request.CreateWorklogRequest true "Create worklog request"
+// @Success 201 {object} response.WorklogResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/worklogs [post]
+func (h *WorklogHandler) Create(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.CreateWorklogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	worklog, err := h.worklogService.Create(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBacklogItemNotFound):
+			utils.RespondNotFound(c, "Backlog item not found")
+		case errors.Is(err, service.ErrInvalidWorklogDate):
+			utils.RespondBadRequest(c, "Invalid work date", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to log work", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusCreated, "Work logged successfully", worklog)
+}
+
+// GetByItem handles GET /api/backlog/:id/worklogs
+// @Summary Get backlog item worklogs
+// @Description Get the worklogs of a backlog item, most recent work first, with the time logged in total and per user
+// @Tags worklogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backlog Item ID"
+// @Success 200 {object} response.ItemWorklogsResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /backlog/{id}/worklogs [get]
+func (h *WorklogHandler) GetByItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid backlog item ID", "ID must be a valid UUID")
+		return
+	}
+
+	worklogs, err := h.worklogService.GetByItemID(id)
+	if err != nil {
+		if errors.Is(err, service.ErrBacklogItemNotFound) {
+			utils.RespondNotFound(c, "Backlog item not found")
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch worklogs", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", worklogs)
+}
+
+// Update handles PUT /api/worklogs/:id
+// @Summary Edit a worklog
+// @Description Change the minutes, day or note of a worklog. Only its author can edit it.
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Worklog ID"
+// @Param request body request.UpdateWorklogRequest true "Update worklog request"
+// @Success 200 {object} response.WorklogResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /worklogs/{id} [put]
+func (h *WorklogHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid worklog ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req request.UpdateWorklogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	worklog, err := h.worklogService.Update(id, &req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrWorklogNotFound):
+			utils.RespondNotFound(c, "Worklog not found")
+		case errors.Is(err, service.ErrWorklogForbidden):
+			utils.RespondForbidden(c, err.Error())
+		case errors.Is(err, service.ErrInvalidWorklogDate):
+			utils.RespondBadRequest(c, "Invalid work date", err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to update worklog", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Worklog updated successfully", worklog)
+}
+
+// Delete handles DELETE /api/worklogs/:id
+// @Summary Delete a worklog
+// @Description Delete a worklog and give its minutes back to the item's remaining estimate. Only its author can delete it.
+// @Tags worklogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Worklog ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /worklogs/{id} [delete]
+func (h *WorklogHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid worklog ID", "ID must be a valid UUID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.RespondUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.worklogService.Delete(id, userID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrWorklogNotFound):
+			utils.RespondNotFound(c, "Worklog not found")
+		case errors.Is(err, service.ErrWorklogForbidden):
+			utils.RespondForbidden(c, err.Error())
+		default:
+			utils.RespondInternalError(c, "Failed to delete worklog", err.Error())
+		}
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "Worklog deleted successfully", nil)
+}
+
+// GetTimesheet handles GET /api/users/:id/timesheet
+// @Summary Get a user's timesheet
+// @Description Get the time a user logged per week (starting Monday) and item across projects. Defaults to the current week and the three before it.
+// @Tags worklogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param from query string false "First day, inclusive (YYYY-MM-DD)"
+// @Param to query string false "Last day, inclusive (YYYY-MM-DD); defaults to today"
+// @Success 200 {object} response.TimesheetResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /users/{id}/timesheet [get]
+func (h *WorklogHandler) GetTimesheet(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondBadRequest(c, "Invalid user ID", "ID must be a valid UUID")
+		return
+	}
+
+	var params request.TimesheetQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.RespondBadRequest(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	timesheet, err := h.worklogService.GetTimesheet(id, &params)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTimesheetRange) {
+			utils.RespondBadRequest(c, "Invalid timesheet range", err.Error())
+			return
+		}
+		utils.RespondInternalError(c, "Failed to fetch timesheet", err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, http.StatusOK, "", timesheet)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Attachment is a file attached to a backlog item. Files are stored once per
+// checksum, so attachments with the same content share their stored object.
+type Attachment struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	ItemID       uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_attachments_item_checksum" json:"item_id"`
+	UploadedByID uuid.UUID `gorm:"type:uuid;not null" json:"uploaded_by_id"`
+	FileName     string    `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType  string    `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size         int64     `gorm:"not null" json:"size"`
+	Checksum     string    `gorm:"type:char(64);not null;index;uniqueIndex:idx_attachments_item_checksum" json:"checksum"`
+	StorageKey   string    `gorm:"type:varchar(255);not null" json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Relations
+	UploadedBy User `gorm:"foreignKey:UploadedByID" json:"uploaded_by,omitempty"`
+}
+
+func (a *Attachment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Attachment model
+func (Attachment) TableName() string {
+	return "attachments"
+}
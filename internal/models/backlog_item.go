@@ -1,10 +1,12 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"sprint-backlog/pkg/constants"
@@ -14,24 +16,48 @@ type BacklogItem struct {
 	ID          uuid.UUID              `gorm:"type:uuid;primary_key" json:"id"`
 	ProjectID   uuid.UUID              `gorm:"type:uuid;not null;index" json:"project_id"`
 	SprintID    *uuid.UUID             `gorm:"type:uuid;index" json:"sprint_id"`
+	ParentID    *uuid.UUID             `gorm:"type:uuid;index" json:"parent_id"`
 	CreatedByID uuid.UUID              `gorm:"type:uuid;not null" json:"created_by_id"`
+	Number      int                    `gorm:"not null;default:0" json:"number"`
 	Title       string                 `gorm:"not null" json:"title"`
 	Description *string                `json:"description"`
 	Type        constants.ItemType     `gorm:"type:varchar(20);not null;default:'Task'" json:"type"`
 	Priority    constants.Priority     `gorm:"type:varchar(20);not null;default:'Medium'" json:"priority"`
-	Status      constants.ItemStatus   `gorm:"type:varchar(20);not null;default:'New'" json:"status"`
+	Status      constants.ItemStatus   `gorm:"type:varchar(50);not null;default:'New'" json:"status"`
 	StoryPoints *int                   `json:"story_points"`
+	Estimate    *string                `gorm:"type:varchar(20)" json:"estimate"`
+	DueDate     *time.Time             `gorm:"type:date;index" json:"due_date"`
 	Labels      pq.StringArray         `gorm:"type:text[]" json:"labels"`
 	Position    int                    `gorm:"not null;default:0" json:"position"`
+	Rank        string                 `gorm:"type:varchar(255);not null;default:''" json:"rank"`
 	CreatedAt   time.Time              `json:"created_at"`
 	UpdatedAt   time.Time              `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt         `gorm:"index" json:"-"`
 
+	// Estimates and time spent are in minutes; TimeSpent sums the item's worklogs
+	OriginalEstimate  *int `json:"original_estimate"`
+	RemainingEstimate *int `json:"remaining_estimate"`
+	TimeSpent         int  `gorm:"not null;default:0" json:"time_spent"`
+
+	// CustomFields holds the values of the project's custom fields by field key
+	CustomFields datatypes.JSONMap `gorm:"type:jsonb;not null;default:'{}'" json:"custom_fields"`
+
 	// Relations
-	Project   Project       `gorm:"foreignKey:ProjectID" json:"project,omitempty"`
-	Sprint    *Sprint       `gorm:"foreignKey:SprintID" json:"sprint,omitempty"`
-	CreatedBy User          `gorm:"foreignKey:CreatedByID" json:"created_by,omitempty"`
-	History   []ItemHistory `gorm:"foreignKey:ItemID" json:"history,omitempty"`
+	Project   Project        `gorm:"foreignKey:ProjectID" json:"project,omitempty"`
+	Sprint    *Sprint        `gorm:"foreignKey:SprintID" json:"sprint,omitempty"`
+	Parent    *BacklogItem   `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
+	CreatedBy User           `gorm:"foreignKey:CreatedByID" json:"created_by,omitempty"`
+	History   []ItemHistory  `gorm:"foreignKey:ItemID" json:"history,omitempty"`
+	Assignees []ItemAssignee `gorm:"foreignKey:ItemID" json:"assignees,omitempty"`
+}
+
+// Key returns the human-readable item key such as PROJ-123. It is empty when the
+// project is not loaded or the item has no number yet.
+func (b *BacklogItem) Key() string {
+	if b.Project.Key == "" || b.Number == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", b.Project.Key, b.Number)
 }
 
 func (b *BacklogItem) BeforeCreate(tx *gorm.DB) error {
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Comment is a comment on a backlog item. Replies point at the top-level comment
+// of their thread, so threads are one level deep.
+type Comment struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	ItemID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"item_id"`
+	ParentID  *uuid.UUID     `gorm:"type:uuid;index" json:"parent_id"`
+	AuthorID  uuid.UUID      `gorm:"type:uuid;not null;index" json:"author_id"`
+	Content   string         `gorm:"type:text;not null" json:"content"`
+	EditedAt  *time.Time     `json:"edited_at"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Author   User             `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Mentions []CommentMention `gorm:"foreignKey:CommentID" json:"mentions,omitempty"`
+}
+
+func (c *Comment) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Comment model
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// CommentEdit keeps the content a comment had before one of its edits
+type CommentEdit struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	CommentID uuid.UUID `gorm:"type:uuid;not null;index" json:"comment_id"`
+	EditorID  uuid.UUID `gorm:"type:uuid;not null" json:"editor_id"`
+	Content   string    `gorm:"type:text;not null" json:"content"`
+	EditedAt  time.Time `gorm:"not null" json:"edited_at"`
+
+	// Relations
+	Editor User `gorm:"foreignKey:EditorID" json:"editor,omitempty"`
+}
+
+func (e *CommentEdit) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.EditedAt.IsZero() {
+		e.EditedAt = time.Now()
+	}
+	return nil
+}
+
+// TableName specifies the table name for CommentEdit model
+func (CommentEdit) TableName() string {
+	return "comment_edits"
+}
+
+// CommentMention records a user mentioned in the current content of a comment
+type CommentMention struct {
+	CommentID uuid.UUID `gorm:"type:uuid;primaryKey" json:"comment_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for CommentMention model
+func (CommentMention) TableName() string {
+	return "comment_mentions"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"sprint-backlog/pkg/constants"
+)
+
+// CustomField defines an extra field of a project's backlog items. Items keep their
+// values in BacklogItem.CustomFields under the field's key.
+type CustomField struct {
+	ID        uuid.UUID                 `gorm:"type:uuid;primary_key" json:"id"`
+	ProjectID uuid.UUID                 `gorm:"type:uuid;not null;uniqueIndex:idx_custom_fields_key" json:"project_id"`
+	Key       string                    `gorm:"type:varchar(50);not null;uniqueIndex:idx_custom_fields_key" json:"key"`
+	Name      string                    `gorm:"type:varchar(100);not null" json:"name"`
+	Type      constants.CustomFieldType `gorm:"type:varchar(20);not null" json:"type"`
+	Options   pq.StringArray            `gorm:"type:text[]" json:"options"`
+	Required  bool                      `gorm:"not null;default:false" json:"required"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+}
+
+func (f *CustomField) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for CustomField model
+func (CustomField) TableName() string {
+	return "custom_fields"
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ItemAssignee links a backlog item to one of the users working on it
+type ItemAssignee struct {
+	ItemID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"item_id"`
+	UserID       uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	AssignedByID uuid.UUID `gorm:"type:uuid;not null" json:"assigned_by_id"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for ItemAssignee model
+func (ItemAssignee) TableName() string {
+	return "item_assignees"
+}
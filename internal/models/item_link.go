@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sprint-backlog/pkg/constants"
+)
+
+// ItemLink is a typed relation between two backlog items. Directed types are
+// stored in their forward form, e.g. "A is blocked by B" is stored as "B blocks A".
+type ItemLink struct {
+	ID          uuid.UUID          `gorm:"type:uuid;primary_key" json:"id"`
+	SourceID    uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_item_links_pair" json:"source_id"`
+	TargetID    uuid.UUID          `gorm:"type:uuid;not null;index;uniqueIndex:idx_item_links_pair" json:"target_id"`
+	Type        constants.LinkType `gorm:"type:varchar(20);not null;uniqueIndex:idx_item_links_pair" json:"type"`
+	CreatedByID uuid.UUID          `gorm:"type:uuid;not null" json:"created_by_id"`
+	CreatedAt   time.Time          `json:"created_at"`
+
+	// Relations
+	Source BacklogItem `gorm:"foreignKey:SourceID" json:"source,omitempty"`
+	Target BacklogItem `gorm:"foreignKey:TargetID" json:"target,omitempty"`
+}
+
+func (l *ItemLink) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ItemLink model
+func (ItemLink) TableName() string {
+	return "item_links"
+}
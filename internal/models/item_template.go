@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"sprint-backlog/pkg/constants"
+)
+
+// TemplateChild is a standard child item created together with items from a template.
+// An empty priority inherits the priority of the new parent item.
+type TemplateChild struct {
+	Title       string             `json:"title"`
+	Type        constants.ItemType `json:"type"`
+	Priority    constants.Priority `json:"priority,omitempty"`
+	Description string             `json:"description,omitempty"`
+	StoryPoints *int               `json:"story_points,omitempty"`
+	Labels      []string           `json:"labels,omitempty"`
+}
+
+// ItemTemplate pre-fills the fields of new backlog items of a project. An empty
+// priority leaves the priority to the create request.
+type ItemTemplate struct {
+	ID          uuid.UUID                          `gorm:"type:uuid;primary_key" json:"id"`
+	ProjectID   uuid.UUID                          `gorm:"type:uuid;not null;uniqueIndex:idx_item_templates_name" json:"project_id"`
+	Name        string                             `gorm:"type:varchar(100);not null;uniqueIndex:idx_item_templates_name" json:"name"`
+	Type        constants.ItemType                 `gorm:"type:varchar(20);not null" json:"type"`
+	Priority    constants.Priority                 `gorm:"type:varchar(20);not null;default:''" json:"priority"`
+	Description *string                            `json:"description"`
+	StoryPoints *int                               `json:"story_points"`
+	Labels      pq.StringArray                     `gorm:"type:text[]" json:"labels"`
+	Children    datatypes.JSONSlice[TemplateChild] `gorm:"type:jsonb" json:"children"`
+	CreatedByID uuid.UUID                          `gorm:"type:uuid;not null" json:"created_by_id"`
+	CreatedAt   time.Time                          `json:"created_at"`
+	UpdatedAt   time.Time                          `json:"updated_at"`
+}
+
+func (t *ItemTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ItemTemplate model
+func (ItemTemplate) TableName() string {
+	return "item_templates"
+}
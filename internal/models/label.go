@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Label is an entry of a project's label catalog. Items keep their labels as plain
+// names; the catalog gives them a color and description, and in projects with
+// strict labels it is the list of labels items may use.
+type Label struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	ProjectID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_labels_name" json:"project_id"`
+	Name        string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_labels_name" json:"name"`
+	Color       string    `gorm:"type:varchar(7);not null" json:"color"`
+	Description *string   `json:"description"`
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null" json:"created_by_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (l *Label) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Label model
+func (Label) TableName() string {
+	return "labels"
+}
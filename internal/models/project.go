@@ -4,7 +4,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
+
+	"sprint-backlog/pkg/constants"
 )
 
 type Project struct {
@@ -17,12 +20,30 @@ type Project struct {
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// ItemSequence is the last number handed out to an item of the project
+	ItemSequence int `gorm:"not null;default:0" json:"-"`
+
+	// StrictLabels only lets items use labels from the project's label catalog
+	StrictLabels bool `gorm:"not null;default:false" json:"strict_labels"`
+
+	// EstimationScale sets the story points items may take. EstimationOptions maps
+	// the labels of T-shirt and custom scales to points.
+	EstimationScale   constants.EstimationScale           `gorm:"type:varchar(20);not null;default:'linear'" json:"estimation_scale"`
+	EstimationOptions datatypes.JSONSlice[EstimateOption] `gorm:"type:jsonb" json:"estimation_options"`
+
 	// Relations
 	CreatedBy    User          `gorm:"foreignKey:CreatedByID" json:"created_by,omitempty"`
 	Sprints      []Sprint      `gorm:"foreignKey:ProjectID" json:"sprints,omitempty"`
 	BacklogItems []BacklogItem `gorm:"foreignKey:ProjectID" json:"backlog_items,omitempty"`
 }
 
+// EstimateOption is a value of an estimation scale: a label, such as a T-shirt
+// size, and the story points it counts for in velocity and reports
+type EstimateOption struct {
+	Label  string `json:"label"`
+	Points int    `json:"points"`
+}
+
 func (p *Project) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == uuid.Nil {
 		p.ID = uuid.New()
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedFilter is a named backlog query of a project. Filters are private to their
+// owner unless shared with the project's other users.
+type SavedFilter struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_saved_filters_name" json:"project_id"`
+	OwnerID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_saved_filters_name" json:"owner_id"`
+	Name      string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_saved_filters_name" json:"name"`
+	Query     string    `gorm:"type:text;not null" json:"query"`
+	Shared    bool      `gorm:"not null;default:false" json:"shared"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Owner User `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+}
+
+func (f *SavedFilter) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for SavedFilter model
+func (SavedFilter) TableName() string {
+	return "saved_filters"
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/pkg/constants"
+)
+
+// Watcher subscribes a user to the changes of a backlog item or sprint
+type Watcher struct {
+	EntityType constants.WatchEntityType `gorm:"type:varchar(20);primaryKey" json:"entity_type"`
+	EntityID   uuid.UUID                 `gorm:"type:uuid;primaryKey" json:"entity_id"`
+	UserID     uuid.UUID                 `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	Reason     constants.WatchReason     `gorm:"type:varchar(20);not null" json:"reason"`
+	CreatedAt  time.Time                 `json:"created_at"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for Watcher model
+func (Watcher) TableName() string {
+	return "watchers"
+}
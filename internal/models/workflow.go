@@ -0,0 +1,118 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"sprint-backlog/pkg/constants"
+)
+
+// WorkflowStatus is a named status of a project workflow.
+// WIPLimit caps the number of items in the status column; nil means unlimited.
+type WorkflowStatus struct {
+	Name     constants.ItemStatus     `json:"name"`
+	Category constants.StatusCategory `json:"category"`
+	WIPLimit *int                     `json:"wip_limit,omitempty"`
+}
+
+// WorkflowTransition allows items to move from one status to another
+type WorkflowTransition struct {
+	From constants.ItemStatus `json:"from"`
+	To   constants.ItemStatus `json:"to"`
+}
+
+// Workflow defines the statuses and allowed transitions of a project's items.
+// An empty transition list allows moving between any two statuses.
+type Workflow struct {
+	ID          uuid.UUID                               `gorm:"type:uuid;primary_key" json:"id"`
+	ProjectID   uuid.UUID                               `gorm:"type:uuid;not null;uniqueIndex" json:"project_id"`
+	Statuses    datatypes.JSONSlice[WorkflowStatus]     `gorm:"type:jsonb;not null" json:"statuses"`
+	Transitions datatypes.JSONSlice[WorkflowTransition] `gorm:"type:jsonb" json:"transitions"`
+	CreatedAt   time.Time                               `json:"created_at"`
+	UpdatedAt   time.Time                               `json:"updated_at"`
+
+	// Relations
+	Project Project `gorm:"foreignKey:ProjectID" json:"project,omitempty"`
+}
+
+func (w *Workflow) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Workflow model
+func (Workflow) TableName() string {
+	return "workflows"
+}
+
+// HasStatus reports whether the status is part of the workflow
+func (w *Workflow) HasStatus(status constants.ItemStatus) bool {
+	_, ok := w.Category(status)
+	return ok
+}
+
+// Category returns the category of a workflow status
+func (w *Workflow) Category(status constants.ItemStatus) (constants.StatusCategory, bool) {
+	for _, s := range w.Statuses {
+		if s.Name == status {
+			return s.Category, true
+		}
+	}
+	return "", false
+}
+
+// WIPLimit returns the work-in-progress limit of a status, or nil when unlimited
+func (w *Workflow) WIPLimit(status constants.ItemStatus) *int {
+	for _, s := range w.Statuses {
+		if s.Name == status {
+			return s.WIPLimit
+		}
+	}
+	return nil
+}
+
+// InitialStatus returns the status new items start in
+func (w *Workflow) InitialStatus() constants.ItemStatus {
+	if len(w.Statuses) == 0 {
+		return ""
+	}
+	return w.Statuses[0].Name
+}
+
+// StatusNames returns the workflow statuses in board order
+func (w *Workflow) StatusNames() []constants.ItemStatus {
+	names := make([]constants.ItemStatus, len(w.Statuses))
+	for i, s := range w.Statuses {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// StatusesIn returns the statuses belonging to a category
+func (w *Workflow) StatusesIn(category constants.StatusCategory) []constants.ItemStatus {
+	var names []constants.ItemStatus
+	for _, s := range w.Statuses {
+		if s.Category == category {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// CanTransition reports whether an item may move between the two statuses
+func (w *Workflow) CanTransition(from, to constants.ItemStatus) bool {
+	if from == to || len(w.Transitions) == 0 {
+		return true
+	}
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to {
+			return true
+		}
+	}
+	return false
+}
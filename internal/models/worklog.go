@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Worklog records the minutes a user worked on a backlog item on a given day
+type Worklog struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	ItemID    uuid.UUID `gorm:"type:uuid;not null;index" json:"item_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index:idx_worklogs_user_date" json:"user_id"`
+	Minutes   int       `gorm:"not null" json:"minutes"`
+	Date      time.Time `gorm:"type:date;not null;index:idx_worklogs_user_date" json:"date"`
+	Note      *string   `gorm:"type:text" json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (w *Worklog) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Worklog model
+func (Worklog) TableName() string {
+	return "worklogs"
+}
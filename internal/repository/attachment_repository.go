@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sprint-backlog/internal/models"
+)
+
+type AttachmentRepository interface {
+	Create(attachment *models.Attachment, history models.ItemHistory, store func() error) error
+	GetByID(id uuid.UUID) (*models.Attachment, error)
+	GetByItemID(itemID uuid.UUID) ([]models.Attachment, error)
+	FindByChecksum(itemID uuid.UUID, checksum string) (*models.Attachment, error)
+	ReleaseStorageKey(key string, remove func() error) error
+	Delete(id uuid.UUID, history models.ItemHistory) error
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+// Create stores the attachment together with its history entry in a single
+// transaction. store writes the file and runs under the storage key's lock, so the
+// file cannot be released while the attachment is being added.
+func (r *attachmentRepository) Create(attachment *models.Attachment, history models.ItemHistory, store func() error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.lockStorageKey(tx, attachment.StorageKey); err != nil {
+			return err
+		}
+		if err := tx.Omit("UploadedBy").Create(attachment).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+		return store()
+	})
+}
+
+func (r *attachmentRepository) GetByID(id uuid.UUID) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.db.Preload("UploadedBy").Where("id = ?", id).First(&attachment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetByItemID returns the item's attachments, oldest first
+func (r *attachmentRepository) GetByItemID(itemID uuid.UUID) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := r.db.Preload("UploadedBy").
+		Where("item_id = ?", itemID).
+		Order("created_at ASC, id ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// FindByChecksum returns the item's attachment with the given content, if any
+func (r *attachmentRepository) FindByChecksum(itemID uuid.UUID, checksum string) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.db.Where("item_id = ? AND checksum = ?", itemID, checksum).First(&attachment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// ReleaseStorageKey calls remove when no attachment of any item uses the stored
+// object anymore. The count is taken under the storage key's lock, so no upload can
+// start sharing the object while it is removed.
+func (r *attachmentRepository) ReleaseStorageKey(key string, remove func() error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.lockStorageKey(tx, key); err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&models.Attachment{}).Where("storage_key = ?", key).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		return remove()
+	})
+}
+
+// lockStorageKey takes a transaction-scoped advisory lock on the storage key,
+// serialising uploads and removals of the same stored object
+func (r *attachmentRepository) lockStorageKey(tx *gorm.DB, key string) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error
+}
+
+// Delete removes the attachment and writes its history entry in a single transaction
+func (r *attachmentRepository) Delete(id uuid.UUID, history models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Attachment{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Create(&history).Error
+	})
+}
@@ -0,0 +1,594 @@
+package repository
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/query"
+)
+
+// BacklogQuery is a query-language expression compiled into SQL on backlog_items.
+// Field names map to fixed SQL expressions and values are always bound as arguments.
+type BacklogQuery struct {
+	Where string
+	Args  []interface{}
+	// OrderBy holds the validated ORDER BY terms; the list's default order breaks ties
+	OrderBy []query.OrderTerm
+}
+
+type queryFieldKind int
+
+const (
+	fieldEnum queryFieldKind = iota
+	fieldText
+	fieldPriority
+	fieldNumber
+	fieldDate
+	fieldLabel
+	fieldRef
+	fieldAssignee
+	fieldFullText
+	fieldCustom
+)
+
+type queryField struct {
+	column string
+	kind   queryFieldKind
+	// values lists the accepted values of enum fields; matching ignores case
+	values []string
+}
+
+// priorityOrder ranks priorities so that they can be compared and sorted
+const priorityOrder = "CASE backlog_items.priority WHEN 'Low' THEN 1 WHEN 'Medium' THEN 2 WHEN 'High' THEN 3 WHEN 'Critical' THEN 4 END"
+
+// statusOrder ranks statuses by their position in the project's workflow, or in the
+// default workflow for projects without one. Statuses outside both sort last.
+const statusOrder = `COALESCE(
+	(SELECT s.ord FROM workflows w CROSS JOIN LATERAL jsonb_array_elements(w.statuses) WITH ORDINALITY AS s(status, ord)
+		WHERE w.project_id = backlog_items.project_id AND s.status->>'name' = backlog_items.status),
+	CASE backlog_items.status WHEN 'New' THEN 1 WHEN 'Ready' THEN 2 WHEN 'In Progress' THEN 3 WHEN 'Done' THEN 4 WHEN 'Archived' THEN 5 END)`
+
+// itemDone matches items whose status is in a done category of the project's
+// workflow, or of the default workflow for projects without one
+const itemDone = `COALESCE(
+	(SELECT s.status->>'category' = 'done' FROM workflows w CROSS JOIN LATERAL jsonb_array_elements(w.statuses) AS s(status)
+		WHERE w.project_id = backlog_items.project_id AND s.status->>'name' = backlog_items.status),
+	backlog_items.status = 'Done')`
+
+// itemClosed matches items that need no more work, i.e. done or archived, like
+// StatusCategory.IsClosed
+const itemClosed = `COALESCE(
+	(SELECT s.status->>'category' IN ('done', 'archived') FROM workflows w CROSS JOIN LATERAL jsonb_array_elements(w.statuses) AS s(status)
+		WHERE w.project_id = backlog_items.project_id AND s.status->>'name' = backlog_items.status),
+	backlog_items.status IN ('Done', 'Archived'))`
+
+// relevanceField sorts full-text search matches best first; it is ignored without a search
+const relevanceField = "relevance"
+
+var backlogQueryFields = map[string]queryField{
+	"type":     {column: "backlog_items.type", kind: fieldEnum, values: itemTypes()},
+	"status":   {column: "backlog_items.status", kind: fieldEnum},
+	"priority": {column: priorityOrder, kind: fieldPriority},
+	"points":   {column: "backlog_items.story_points", kind: fieldNumber},
+	"label":    {column: "backlog_items.labels", kind: fieldLabel},
+	"assignee": {kind: fieldAssignee},
+	"sprint":   {column: "backlog_items.sprint_id", kind: fieldRef},
+	"parent":   {column: "backlog_items.parent_id", kind: fieldRef},
+	"created":  {column: "backlog_items.created_at", kind: fieldDate},
+	"updated":  {column: "backlog_items.updated_at", kind: fieldDate},
+	"due":      {column: "backlog_items.due_date", kind: fieldDate},
+	"title":    {column: "backlog_items.title", kind: fieldText},
+	"text":     {kind: fieldFullText},
+}
+
+// CustomFieldPrefix starts the query names of custom fields, such as cf.customer
+const CustomFieldPrefix = "cf."
+
+// customFieldKey matches valid custom field keys
+var customFieldKey = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// IsCustomFieldKey reports whether key is a valid custom field key
+func IsCustomFieldKey(key string) bool {
+	return customFieldKey.MatchString(key)
+}
+
+// backlogQueryAliases maps alternative field names to their canonical name
+var backlogQueryAliases = map[string]string{
+	"story_points": "points",
+	"labels":       "label",
+	"sprint_id":    "sprint",
+	"parent_id":    "parent",
+	"created_at":   "created",
+	"updated_at":   "updated",
+	"due_date":     "due",
+}
+
+// backlogQueryOrders maps the fields that results can be ordered by to their sort key.
+// Priorities and statuses sort by their meaning rather than alphabetically.
+var backlogQueryOrders = map[string]sortKey{
+	"priority": {expr: priorityOrder, castType: "integer"},
+	"points":   {expr: "backlog_items.story_points", castType: "integer", nullable: true},
+	"created":  {expr: "backlog_items.created_at", castType: "timestamptz"},
+	"updated":  {expr: "backlog_items.updated_at", castType: "timestamptz"},
+	"due":      {expr: "backlog_items.due_date", castType: "date", nullable: true},
+	"status":   {expr: statusOrder, castType: "bigint", nullable: true},
+	"type":     {expr: "backlog_items.type", castType: "text"},
+	"title":    {expr: "backlog_items.title", castType: "text"},
+	"key":      {expr: "backlog_items.number", castType: "integer"},
+	"rank":     {expr: `backlog_items.rank COLLATE "C"`, castType: "text"},
+}
+
+func itemTypes() []string {
+	return []string{
+		string(constants.ItemTypeStory), string(constants.ItemTypeBug), string(constants.ItemTypeTask),
+		string(constants.ItemTypeEpic), string(constants.ItemTypeSubtask),
+	}
+}
+
+func priorities() []string {
+	return []string{
+		string(constants.PriorityLow), string(constants.PriorityMedium),
+		string(constants.PriorityHigh), string(constants.PriorityCritical),
+	}
+}
+
+var priorityRanks = map[constants.Priority]int{
+	constants.PriorityLow:      1,
+	constants.PriorityMedium:   2,
+	constants.PriorityHigh:     3,
+	constants.PriorityCritical: 4,
+}
+
+// CompileBacklogQuery checks a parsed query against the backlog fields and compiles
+// it to SQL. Custom fields are named cf.<key>. userID resolves "me" in assignee and
+// custom field conditions. Unknown fields, operators that do not apply to a field
+// and malformed values are reported as query.SyntaxError.
+func CompileBacklogQuery(q *query.Query, userID uuid.UUID) (*BacklogQuery, error) {
+	c := &queryCompiler{userID: userID, now: time.Now().UTC()}
+	compiled := &BacklogQuery{}
+
+	if q.Where != nil {
+		where, err := c.compile(q.Where)
+		if err != nil {
+			return nil, err
+		}
+		compiled.Where = where
+		compiled.Args = c.args
+	}
+
+	if err := ValidateBacklogSort(q.OrderBy); err != nil {
+		return nil, err
+	}
+	compiled.OrderBy = q.OrderBy
+
+	return compiled, nil
+}
+
+// ValidateBacklogSort checks that items can be sorted by every field of the terms.
+// Besides the query-language order fields, "relevance" sorts search matches best first.
+func ValidateBacklogSort(terms []query.OrderTerm) error {
+	for _, term := range terms {
+		field := canonicalField(term.Field)
+		if _, ok := backlogQueryOrders[field]; !ok && field != relevanceField {
+			return query.Errorf(term.Pos, "cannot sort by %q; use one of %s or %s", term.Field, fieldList(backlogQueryOrders), relevanceField)
+		}
+	}
+	return nil
+}
+
+type queryCompiler struct {
+	userID uuid.UUID
+	now    time.Time
+	args   []interface{}
+}
+
+func canonicalField(name string) string {
+	if canonical, ok := backlogQueryAliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+func fieldList[V any](fields map[string]V) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func (c *queryCompiler) compile(expr query.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *query.Binary:
+		left, err := c.compile(e.Left)
+		if err != nil {
+			return "", err
+		}
+		right, err := c.compile(e.Right)
+		if err != nil {
+			return "", err
+		}
+		return "(" + left + " " + string(e.Op) + " " + right + ")", nil
+	case *query.Not:
+		inner, err := c.compile(e.Expr)
+		if err != nil {
+			return "", err
+		}
+		return negate(inner), nil
+	case *query.Comparison:
+		return c.compileComparison(e)
+	}
+	return "", fmt.Errorf("unsupported query expression %T", expr)
+}
+
+// negate inverts a condition, treating NULL as false so that items without a
+// value match negative conditions such as points != 5
+func negate(condition string) string {
+	return "NOT COALESCE(" + condition + ", FALSE)"
+}
+
+func (c *queryCompiler) bind(values ...interface{}) {
+	c.args = append(c.args, values...)
+}
+
+func (c *queryCompiler) compileComparison(cmp *query.Comparison) (string, error) {
+	name := canonicalField(cmp.Field)
+	field, ok := backlogQueryFields[name]
+	if key, custom := strings.CutPrefix(name, CustomFieldPrefix); custom {
+		if !IsCustomFieldKey(key) {
+			return "", query.Errorf(cmp.Pos, "invalid custom field %q", cmp.Field)
+		}
+		field, ok = queryField{column: key, kind: fieldCustom}, true
+	}
+	if !ok {
+		return "", query.Errorf(cmp.Pos, "unknown field %q; use one of %s or %s<key>", cmp.Field, fieldList(backlogQueryFields), CustomFieldPrefix)
+	}
+
+	// Negative operators compile as the negated positive condition
+	switch cmp.Op {
+	case query.NotEqual, query.NotIn, query.NotContains, query.IsNotEmpty:
+		positive := *cmp
+		positive.Op = map[query.Operator]query.Operator{
+			query.NotEqual:    query.Equal,
+			query.NotIn:       query.In,
+			query.NotContains: query.Contains,
+			query.IsNotEmpty:  query.IsEmpty,
+		}[cmp.Op]
+		condition, err := c.compileField(name, field, &positive, cmp.Op)
+		if err != nil {
+			return "", err
+		}
+		return negate(condition), nil
+	}
+	return c.compileField(name, field, cmp, cmp.Op)
+}
+
+// compileField compiles a positive comparison. written is the operator as written
+// in the query, used in error messages.
+func (c *queryCompiler) compileField(name string, field queryField, cmp *query.Comparison, written query.Operator) (string, error) {
+	unsupported := func() error {
+		return query.Errorf(cmp.Pos, "operator %s cannot be used with %q", written, cmp.Field)
+	}
+
+	switch field.kind {
+	case fieldEnum:
+		values, err := enumValues(field, cmp.Values)
+		if err != nil {
+			return "", err
+		}
+		switch cmp.Op {
+		case query.Equal:
+			c.bind(values[0])
+			return "LOWER(" + field.column + ") = LOWER(?)", nil
+		case query.In:
+			lower := make([]string, len(values))
+			for i, v := range values {
+				lower[i] = strings.ToLower(v)
+			}
+			c.bind(lower)
+			return "LOWER(" + field.column + ") IN ?", nil
+		}
+		return "", unsupported()
+
+	case fieldText:
+		switch cmp.Op {
+		case query.Equal:
+			c.bind(cmp.Values[0].Text)
+			return "LOWER(" + field.column + ") = LOWER(?)", nil
+		case query.Contains:
+			c.bind("%" + escapeLike(cmp.Values[0].Text) + "%")
+			return field.column + " ILIKE ?", nil
+		}
+		return "", unsupported()
+
+	case fieldPriority:
+		ranks := make([]int, len(cmp.Values))
+		for i, v := range cmp.Values {
+			rank, ok := priorityRanks[constants.Priority(matchValue(v.Text, priorities()))]
+			if !ok {
+				return "", query.Errorf(v.Pos, "invalid priority %q; use Low, Medium, High or Critical", v.Text)
+			}
+			ranks[i] = rank
+		}
+		return c.compileOrdered(field.column, cmp, ranks, unsupported)
+
+	case fieldNumber:
+		if cmp.Op == query.IsEmpty {
+			return field.column + " IS NULL", nil
+		}
+		numbers := make([]int, len(cmp.Values))
+		for i, v := range cmp.Values {
+			n, err := strconv.Atoi(v.Text)
+			if err != nil {
+				return "", query.Errorf(v.Pos, "%q is not a whole number", v.Text)
+			}
+			numbers[i] = n
+		}
+		return c.compileOrdered(field.column, cmp, numbers, unsupported)
+
+	case fieldDate:
+		if cmp.Op == query.In || cmp.Op == query.Contains || cmp.Op == query.IsEmpty {
+			return "", unsupported()
+		}
+		start, err := c.parseDay(cmp.Values[0])
+		if err != nil {
+			return "", err
+		}
+		end := start.AddDate(0, 0, 1)
+		switch cmp.Op {
+		case query.Equal:
+			c.bind(start, end)
+			return "(" + field.column + " >= ? AND " + field.column + " < ?)", nil
+		case query.Less:
+			c.bind(start)
+			return field.column + " < ?", nil
+		case query.LessOrEqual:
+			c.bind(end)
+			return field.column + " < ?", nil
+		case query.Greater:
+			c.bind(end)
+			return field.column + " >= ?", nil
+		case query.GreaterOrEqual:
+			c.bind(start)
+			return field.column + " >= ?", nil
+		}
+		return "", unsupported()
+
+	case fieldLabel:
+		switch cmp.Op {
+		case query.Equal:
+			c.bind(cmp.Values[0].Text)
+			return "? = ANY(" + field.column + ")", nil
+		case query.In:
+			labels := make([]string, len(cmp.Values))
+			for i, v := range cmp.Values {
+				labels[i] = v.Text
+			}
+			c.bind(pq.Array(labels))
+			return field.column + " && ?", nil
+		case query.IsEmpty:
+			return "COALESCE(cardinality(" + field.column + "), 0) = 0", nil
+		}
+		return "", unsupported()
+
+	case fieldRef:
+		if cmp.Op == query.IsEmpty {
+			return field.column + " IS NULL", nil
+		}
+		ids, err := c.parseIDs(cmp.Values, false)
+		if err != nil {
+			return "", err
+		}
+		switch cmp.Op {
+		case query.Equal:
+			c.bind(ids[0])
+			return field.column + " = ?", nil
+		case query.In:
+			c.bind(ids)
+			return field.column + " IN ?", nil
+		}
+		return "", unsupported()
+
+	case fieldAssignee:
+		const assigned = "EXISTS (SELECT 1 FROM item_assignees WHERE item_assignees.item_id = backlog_items.id"
+		if cmp.Op == query.IsEmpty {
+			return "NOT " + assigned + ")", nil
+		}
+		ids, err := c.parseIDs(cmp.Values, true)
+		if err != nil {
+			return "", err
+		}
+		switch cmp.Op {
+		case query.Equal:
+			c.bind(ids[0])
+			return assigned + " AND item_assignees.user_id = ?)", nil
+		case query.In:
+			c.bind(ids)
+			return assigned + " AND item_assignees.user_id IN ?)", nil
+		}
+		return "", unsupported()
+
+	case fieldFullText:
+		if cmp.Op != query.Contains {
+			return "", unsupported()
+		}
+		c.bind(cmp.Values[0].Text)
+		return "backlog_items.search_vector @@ websearch_to_tsquery(backlog_search_config(), ?)", nil
+
+	case fieldCustom:
+		return c.compileCustomField(field.column, cmp, unsupported)
+	}
+
+	return "", query.Errorf(cmp.Pos, "unsupported field %q", name)
+}
+
+// compileCustomField compiles a comparison on a custom field value. The list spans
+// projects whose fields may differ, so values are matched by their JSON shape:
+// equality and contains match any element of a multi-select as text ignoring case,
+// and ordering compares numbers with numbers and YYYY-MM-DD dates with dates. Dates
+// are compared as text, which orders them like dates without a cast that would fail
+// on text that only looks like one, such as 2024-13-45.
+// Unquoted "me" stands for the current user's ID, for user fields. The key is bound
+// as an argument wherever the value is read.
+func (c *queryCompiler) compileCustomField(key string, cmp *query.Comparison, unsupported func() error) (string, error) {
+	const value = "backlog_items.custom_fields->?::text"
+	const text = "(" + value + " #>> '{}')"
+	const elements = "EXISTS (SELECT 1 FROM jsonb_array_elements_text(CASE jsonb_typeof(" + value + ") WHEN 'array' THEN " + value + " ELSE jsonb_build_array(" + value + ") END) AS e(value) WHERE "
+
+	texts := make([]string, len(cmp.Values))
+	for i, v := range cmp.Values {
+		texts[i] = v.Text
+		if !v.Quoted && strings.EqualFold(v.Text, "me") {
+			texts[i] = c.userID.String()
+		}
+	}
+
+	switch cmp.Op {
+	case query.IsEmpty:
+		c.bind(key)
+		return "COALESCE(" + value + ", 'null') IN ('null', '[]', '\"\"')", nil
+	case query.Equal:
+		c.bind(key, key, key, texts[0])
+		return elements + "LOWER(e.value) = LOWER(?))", nil
+	case query.In:
+		lower := make([]string, len(texts))
+		for i, t := range texts {
+			lower[i] = strings.ToLower(t)
+		}
+		c.bind(key, key, key, lower)
+		return elements + "LOWER(e.value) IN ?)", nil
+	case query.Contains:
+		c.bind(key, key, key, "%"+escapeLike(texts[0])+"%")
+		return elements + "e.value ILIKE ?)", nil
+	}
+
+	operators := map[query.Operator]string{
+		query.Less:           "<",
+		query.LessOrEqual:    "<=",
+		query.Greater:        ">",
+		query.GreaterOrEqual: ">=",
+	}
+	op, ok := operators[cmp.Op]
+	if !ok {
+		return "", unsupported()
+	}
+	if n, err := strconv.ParseFloat(texts[0], 64); err == nil && !math.IsInf(n, 0) && !math.IsNaN(n) {
+		c.bind(key, key, n)
+		return "CASE WHEN jsonb_typeof(" + value + ") = 'number' THEN (" + value + ")::numeric END " + op + " ?", nil
+	}
+	day, err := c.parseDay(cmp.Values[0])
+	if err != nil {
+		return "", query.Errorf(cmp.Values[0].Pos, "%q is not a number or date; use a number, YYYY-MM-DD, today, -Nd or -Nw", cmp.Values[0].Text)
+	}
+	c.bind(key, key, day.Format(constants.DateLayout))
+	return "CASE WHEN " + text + " ~ '^[0-9]{4}-[0-9]{2}-[0-9]{2}$' THEN " + text + " END COLLATE \"C\" " + op + " ?", nil
+}
+
+// compileOrdered compiles a comparison on a numeric SQL expression
+func (c *queryCompiler) compileOrdered(column string, cmp *query.Comparison, values []int, unsupported func() error) (string, error) {
+	operators := map[query.Operator]string{
+		query.Equal:          "=",
+		query.Less:           "<",
+		query.LessOrEqual:    "<=",
+		query.Greater:        ">",
+		query.GreaterOrEqual: ">=",
+	}
+	if op, ok := operators[cmp.Op]; ok {
+		c.bind(values[0])
+		return column + " " + op + " ?", nil
+	}
+	if cmp.Op == query.In {
+		c.bind(values)
+		return column + " IN ?", nil
+	}
+	return "", unsupported()
+}
+
+// enumValues matches the values against the field's accepted values, returning
+// them with canonical casing. Fields without a fixed list accept any value.
+func enumValues(field queryField, values []query.Value) ([]string, error) {
+	result := make([]string, len(values))
+	for i, v := range values {
+		if field.values == nil {
+			result[i] = v.Text
+			continue
+		}
+		matched := matchValue(v.Text, field.values)
+		if matched == "" {
+			return nil, query.Errorf(v.Pos, "invalid value %q; use one of %s", v.Text, strings.Join(field.values, ", "))
+		}
+		result[i] = matched
+	}
+	return result, nil
+}
+
+// matchValue returns the accepted value equal to text ignoring case, or ""
+func matchValue(text string, accepted []string) string {
+	for _, value := range accepted {
+		if strings.EqualFold(text, value) {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseIDs parses UUID values; with allowMe, "me" stands for the current user
+func (c *queryCompiler) parseIDs(values []query.Value, allowMe bool) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(values))
+	for i, v := range values {
+		if allowMe && !v.Quoted && strings.EqualFold(v.Text, "me") {
+			ids[i] = c.userID
+			continue
+		}
+		id, err := uuid.Parse(v.Text)
+		if err != nil {
+			if allowMe {
+				return nil, query.Errorf(v.Pos, "%q is not a user ID or \"me\"", v.Text)
+			}
+			return nil, query.Errorf(v.Pos, "%q is not a valid ID", v.Text)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// parseDay resolves a date value to the start of that day in UTC. It accepts
+// YYYY-MM-DD, "today" and relative days or weeks in the past such as -7d or -2w.
+func (c *queryCompiler) parseDay(v query.Value) (time.Time, error) {
+	today := time.Date(c.now.Year(), c.now.Month(), c.now.Day(), 0, 0, 0, 0, time.UTC)
+	text := strings.ToLower(v.Text)
+
+	if text == "today" {
+		return today, nil
+	}
+	if strings.HasPrefix(text, "-") && len(text) > 2 {
+		n, err := strconv.Atoi(text[1 : len(text)-1])
+		if err == nil && n >= 0 {
+			switch text[len(text)-1] {
+			case 'd':
+				return today.AddDate(0, 0, -n), nil
+			case 'w':
+				return today.AddDate(0, 0, -7*n), nil
+			}
+		}
+	}
+	if day, err := time.Parse("2006-01-02", text); err == nil {
+		return day, nil
+	}
+	return time.Time{}, query.Errorf(v.Pos, "invalid date %q; use YYYY-MM-DD, today, -Nd or -Nw", v.Text)
+}
+
+// escapeLike escapes the LIKE wildcards in s
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
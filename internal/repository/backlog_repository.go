@@ -2,28 +2,69 @@ package repository
 
 import (
 	"errors"
+	"html"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"sprint-backlog/internal/models"
 	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/query"
+	"sprint-backlog/pkg/rank"
 )
 
+// rankOrder sorts items by rank. Ranks are compared bytewise, and items that have
+// not been ranked yet fall back to their legacy position.
+const rankOrder = `backlog_items.rank COLLATE "C" ASC, backlog_items.position ASC, backlog_items.created_at DESC`
+
+// rankKeys is rankOrder as a keyset, with the item ID breaking the remaining ties
+var rankKeys = []sortKey{
+	{expr: `backlog_items.rank COLLATE "C"`, castType: "text"},
+	{expr: "backlog_items.position", castType: "integer"},
+	{expr: "backlog_items.created_at", castType: "timestamptz", desc: true},
+	{expr: "backlog_items.id", castType: "uuid"},
+}
+
+// newestKeys orders items newest first
+var newestKeys = []sortKey{
+	{expr: "backlog_items.created_at", castType: "timestamptz", desc: true},
+	{expr: "backlog_items.id", castType: "uuid", desc: true},
+}
+
 type BacklogRepository interface {
 	Create(item *models.BacklogItem) error
+	CreateWithChildren(item *models.BacklogItem, children []models.BacklogItem) error
+	CreateClone(item *models.BacklogItem, children []models.BacklogItem, links []models.ItemLink, histories func(item *models.BacklogItem, children []models.BacklogItem) []models.ItemHistory) error
+	MoveToProject(items []models.BacklogItem, histories func(placed []models.BacklogItem) []models.ItemHistory) error
 	GetByID(id uuid.UUID) (*models.BacklogItem, error)
+	GetByKey(projectKey string, number int) (*models.BacklogItem, error)
+	GetByIDs(ids []uuid.UUID) ([]models.BacklogItem, error)
 	GetByProjectID(projectID uuid.UUID, filters BacklogFilters) ([]models.BacklogItem, int64, error)
 	GetBySprintID(sprintID uuid.UUID) ([]models.BacklogItem, error)
-	GetAll(filters BacklogFilters) ([]models.BacklogItem, int64, error)
-	Update(item *models.BacklogItem) error
+	GetAll(filters BacklogFilters) ([]models.BacklogItem, PageInfo, error)
+	Update(item *models.BacklogItem, columns []string, guards ...WIPGuard) error
 	Delete(id uuid.UUID) error
-	UpdateStatus(id uuid.UUID, status constants.ItemStatus) error
+	UpdateStatus(id uuid.UUID, status constants.ItemStatus, guards ...WIPGuard) error
 	UpdatePriority(id uuid.UUID, priority constants.Priority) error
 	AddLabel(id uuid.UUID, label string) error
 	RemoveLabel(id uuid.UUID, label string) error
 	GetMaxPosition(projectID uuid.UUID) (int, error)
+	MoveToColumn(id uuid.UUID, column BoardColumn, index int, histories []models.ItemHistory, guards ...WIPGuard) error
+	CountOutsideStatuses(projectID uuid.UUID, statuses []constants.ItemStatus) (int64, error)
+	CountByStatus(projectID uuid.UUID, sprintID *uuid.UUID) (map[constants.ItemStatus]int64, error)
+	UpdateParent(id uuid.UUID, parentID *uuid.UUID) error
+	UpdateDueDate(id uuid.UUID, dueDate *time.Time) error
+	GetDueForAssignee(userID uuid.UUID, until time.Time) ([]models.BacklogItem, error)
+	GetChildren(parentID uuid.UUID) ([]models.BacklogItem, error)
+	GetAncestorIDs(id uuid.UUID) ([]uuid.UUID, error)
+	GetChildStats(parentIDs []uuid.UUID) ([]ChildStats, error)
+	MoveRank(id, anchorID uuid.UUID, after bool) (string, error)
+	ApplyBulk(ids []uuid.UUID, change BulkChanger) error
+	GetSearchHighlights(ids []uuid.UUID, search string) ([]SearchHighlight, error)
 }
 
 type BacklogFilters struct {
@@ -32,9 +73,83 @@ type BacklogFilters struct {
 	Priority  []constants.Priority
 	Status    []constants.ItemStatus
 	SprintID  *uuid.UUID
+	ParentID  *uuid.UUID
 	Labels    []string
+	// AssigneeIDs matches items assigned to any of the users; Unassigned also
+	// matches items without assignees.
+	AssigneeIDs []uuid.UUID
+	Unassigned  bool
+	// DueBefore and DueAfter match items due strictly before or after a day
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	// OverdueOn matches items due before the day that are not done
+	OverdueOn *time.Time
+	// Sort orders the items by validated terms, see ValidateBacklogSort. It takes
+	// precedence over the order of Query.
+	Sort []query.OrderTerm
+	// Query is a compiled query-language expression
+	Query *BacklogQuery
 	Page      int
 	Limit     int
+	// Cursor continues the list after the last item of a previous page and takes
+	// precedence over Page
+	Cursor string
+	// CountTotal also counts all matching items
+	CountTotal bool
+}
+
+// WIPGuard re-checks a work-in-progress limit in the transaction that changes item
+// statuses. The column is counted after the change while the project is locked, so
+// concurrent moves into the same column cannot both pass; an error from Check
+// rolls the change back.
+type WIPGuard struct {
+	ProjectID uuid.UUID
+	// SprintID narrows the column like it narrows CountByStatus
+	SprintID *uuid.UUID
+	Status   constants.ItemStatus
+	Check    func(load int64) error
+}
+
+// BulkUpdate is the columns a bulk operation changes on one item
+type BulkUpdate struct {
+	ID      uuid.UUID
+	Columns map[string]interface{}
+}
+
+// BulkChanges are the writes of a bulk operation
+type BulkChanges struct {
+	Updates    []BulkUpdate
+	DeletedIDs []uuid.UUID
+	Histories  []models.ItemHistory
+	Guards     []WIPGuard
+}
+
+// BulkChanger validates a bulk operation against the items locked by ApplyBulk and
+// returns its writes; an error rolls the operation back
+type BulkChanger func(items []models.BacklogItem) (*BulkChanges, error)
+
+// SearchHighlight holds the highlighted snippets of an item matching a full-text search
+type SearchHighlight struct {
+	ItemID      uuid.UUID
+	Title       string
+	Description string
+	Comment     *string
+}
+
+// ChildStats aggregates the children of a parent item that share a status
+type ChildStats struct {
+	ParentID    uuid.UUID
+	Status      constants.ItemStatus
+	Count       int64
+	StoryPoints int64
+}
+
+// BoardColumn identifies the cards of one status column on a project or sprint board.
+// A SprintID of uuid.Nil scopes the column to items without a sprint.
+type BoardColumn struct {
+	ProjectID uuid.UUID
+	SprintID  *uuid.UUID
+	Status    constants.ItemStatus
 }
 
 type backlogRepository struct {
@@ -45,13 +160,149 @@ func NewBacklogRepository(db *gorm.DB) BacklogRepository {
 	return &backlogRepository{db: db}
 }
 
+// Create inserts the item with the next number of its project and ranks it last.
+// Incrementing the project sequence locks the project row, so concurrent creates
+// are serialised and never receive the same number or rank.
 func (r *backlogRepository) Create(item *models.BacklogItem) error {
-	return r.db.Create(item).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return r.insert(tx, item)
+	})
+}
+
+// CreateWithChildren inserts the item and then its children under it, each numbered
+// and ranked like Create, in a single transaction
+func (r *backlogRepository) CreateWithChildren(item *models.BacklogItem, children []models.BacklogItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.insert(tx, item); err != nil {
+			return err
+		}
+		for i := range children {
+			children[i].ParentID = &item.ID
+			if err := r.insert(tx, &children[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insert numbers, ranks and inserts an item within a transaction. The creator
+// watches the new item.
+func (r *backlogRepository) insert(tx *gorm.DB, item *models.BacklogItem) error {
+	if err := r.place(tx, item); err != nil {
+		return err
+	}
+	if err := tx.Create(item).Error; err != nil {
+		return err
+	}
+	return watch(tx, models.Watcher{
+		EntityType: constants.WatchEntityItem,
+		EntityID:   item.ID,
+		UserID:     item.CreatedByID,
+		Reason:     constants.WatchReasonCreator,
+	})
+}
+
+// place gives the item the next number of its project and ranks it last there
+func (r *backlogRepository) place(tx *gorm.DB, item *models.BacklogItem) error {
+	var number int
+	if err := tx.Raw(
+		"UPDATE projects SET item_sequence = item_sequence + 1 WHERE id = ? RETURNING item_sequence",
+		item.ProjectID,
+	).Scan(&number).Error; err != nil {
+		return err
+	}
+	if number == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	item.Number = number
+
+	last, err := r.lastRank(tx, item.ProjectID)
+	if err != nil {
+		return err
+	}
+	item.Rank, err = rank.After(last)
+	if err != nil || len(item.Rank) > rank.MaxLength {
+		if err := r.rebalance(tx, item.ProjectID); err != nil {
+			return err
+		}
+		if last, err = r.lastRank(tx, item.ProjectID); err != nil {
+			return err
+		}
+		if item.Rank, err = rank.After(last); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateClone inserts a cloned item, its cloned children and copied links together
+// with the history entries referring to them in a single transaction. histories is
+// called with the items once they are numbered.
+func (r *backlogRepository) CreateClone(item *models.BacklogItem, children []models.BacklogItem, links []models.ItemLink, histories func(item *models.BacklogItem, children []models.BacklogItem) []models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.insert(tx, item); err != nil {
+			return err
+		}
+		for i := range children {
+			children[i].ParentID = &item.ID
+			if err := r.insert(tx, &children[i]); err != nil {
+				return err
+			}
+		}
+		if len(links) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&links).Error; err != nil {
+				return err
+			}
+		}
+		if entries := histories(item, children); len(entries) > 0 {
+			if err := tx.Create(&entries).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MoveToProject moves the items to the project they are set to, in order. Each gets
+// the next number of the project and is ranked last there, like a new item. The
+// history entries are built from the placed items, which carry their new numbers,
+// and written in the same transaction.
+func (r *backlogRepository) MoveToProject(items []models.BacklogItem, histories func(placed []models.BacklogItem) []models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range items {
+			if err := r.place(tx, &items[i]); err != nil {
+				return err
+			}
+			if err := tx.Model(&models.BacklogItem{}).Where("id = ?", items[i].ID).Updates(map[string]interface{}{
+				"project_id":    items[i].ProjectID,
+				"sprint_id":     items[i].SprintID,
+				"parent_id":     items[i].ParentID,
+				"status":        items[i].Status,
+				"number":        items[i].Number,
+				"rank":          items[i].Rank,
+				"position":      items[i].Position,
+				"labels":        items[i].Labels,
+				"story_points":  items[i].StoryPoints,
+				"estimate":      items[i].Estimate,
+				"custom_fields": items[i].CustomFields,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		if entries := histories(items); len(entries) > 0 {
+			if err := tx.Create(&entries).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (r *backlogRepository) GetByID(id uuid.UUID) (*models.BacklogItem, error) {
 	var item models.BacklogItem
-	err := r.db.Preload("CreatedBy").Preload("Sprint").Preload("Project").
+	err := r.db.Preload("CreatedBy").Preload("Sprint").Preload("Project").Preload("Parent").Preload("Assignees.User").
 		Where("id = ?", id).First(&item).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -62,6 +313,32 @@ func (r *backlogRepository) GetByID(id uuid.UUID) (*models.BacklogItem, error) {
 	return &item, nil
 }
 
+func (r *backlogRepository) GetByKey(projectKey string, number int) (*models.BacklogItem, error) {
+	var item models.BacklogItem
+	err := r.db.Preload("CreatedBy").Preload("Sprint").Preload("Project").Preload("Parent").Preload("Assignees.User").
+		Joins("JOIN projects ON projects.id = backlog_items.project_id AND projects.deleted_at IS NULL").
+		Where("projects.key = ? AND backlog_items.number = ?", projectKey, number).
+		First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *backlogRepository) GetByIDs(ids []uuid.UUID) ([]models.BacklogItem, error) {
+	var items []models.BacklogItem
+	if len(ids) == 0 {
+		return items, nil
+	}
+	err := r.db.Preload("Project").Preload("Parent").
+		Where("id IN ?", ids).
+		Find(&items).Error
+	return items, err
+}
+
 func (r *backlogRepository) GetByProjectID(projectID uuid.UUID, filters BacklogFilters) ([]models.BacklogItem, int64, error) {
 	var items []models.BacklogItem
 	var total int64
@@ -80,8 +357,8 @@ func (r *backlogRepository) GetByProjectID(projectID uuid.UUID, filters BacklogF
 		query = query.Offset(offset).Limit(filters.Limit)
 	}
 
-	err := query.Preload("CreatedBy").Preload("Sprint").
-		Order("position ASC, created_at DESC").
+	err := query.Preload("CreatedBy").Preload("Sprint").Preload("Project").Preload("Assignees.User").
+		Order(backlogKeyset(filters, rankKeys).orderBy()).
 		Find(&items).Error
 
 	return items, total, err
@@ -89,48 +366,129 @@ func (r *backlogRepository) GetByProjectID(projectID uuid.UUID, filters BacklogF
 
 func (r *backlogRepository) GetBySprintID(sprintID uuid.UUID) ([]models.BacklogItem, error) {
 	var items []models.BacklogItem
-	err := r.db.Preload("CreatedBy").
+	err := r.db.Preload("CreatedBy").Preload("Project").Preload("Assignees.User").
 		Where("sprint_id = ?", sprintID).
-		Order("position ASC").
+		Order(rankOrder).
 		Find(&items).Error
 	return items, err
 }
 
-func (r *backlogRepository) GetAll(filters BacklogFilters) ([]models.BacklogItem, int64, error) {
+// GetAll returns a page of the matching items. Pages continue from a cursor when
+// one is given, so that items added or removed meanwhile do not shift later pages.
+func (r *backlogRepository) GetAll(filters BacklogFilters) ([]models.BacklogItem, PageInfo, error) {
 	var items []models.BacklogItem
-	var total int64
+	var info PageInfo
 
 	query := r.db.Model(&models.BacklogItem{})
 	query = r.applyFilters(query, filters)
 
-	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if filters.CountTotal {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return nil, info, err
+		}
+		info.Total = &total
 	}
 
-	// Apply pagination and get results
-	if filters.Page > 0 && filters.Limit > 0 {
-		offset := (filters.Page - 1) * filters.Limit
-		query = query.Offset(offset).Limit(filters.Limit)
+	keys := backlogKeyset(filters, newestKeys)
+	query, err := keys.paginate(query, filters.Cursor, filters.Page, filters.Limit)
+	if err != nil {
+		return nil, info, err
 	}
 
-	err := query.Preload("CreatedBy").Preload("Sprint").Preload("Project").
-		Order("created_at DESC").
+	err = query.Preload("CreatedBy").Preload("Sprint").Preload("Project").Preload("Assignees.User").
 		Find(&items).Error
+	if err != nil {
+		return nil, info, err
+	}
 
-	return items, total, err
+	if filters.Limit > 0 && len(items) > filters.Limit {
+		items = items[:filters.Limit]
+		if info.NextCursor, err = keys.cursorFor(r.db, "backlog_items", items[len(items)-1].ID); err != nil {
+			return nil, info, err
+		}
+	}
+	return items, info, nil
 }
 
-func (r *backlogRepository) Update(item *models.BacklogItem) error {
-	return r.db.Save(item).Error
+// Update writes the given columns of the item. Other columns, such as the rank, key
+// and worklog totals, have their own writes and are left as stored, so changes made
+// since the item was loaded are kept.
+func (r *backlogRepository) Update(item *models.BacklogItem, columns []string, guards ...WIPGuard) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.lockGuarded(tx, guards); err != nil {
+			return err
+		}
+		if len(columns) > 0 {
+			if err := tx.Model(item).Select(columns).Omit(clause.Associations).Updates(item).Error; err != nil {
+				return err
+			}
+		}
+		return r.checkGuards(tx, guards)
+	})
 }
 
 func (r *backlogRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.BacklogItem{}, "id = ?", id).Error
 }
 
-func (r *backlogRepository) UpdateStatus(id uuid.UUID, status constants.ItemStatus) error {
-	return r.db.Model(&models.BacklogItem{}).Where("id = ?", id).Update("status", status).Error
+// ApplyBulk loads and locks the items with the given IDs, passes them to change and
+// writes the returned changes in a single transaction. Only the returned columns are
+// written, so concurrent changes to other columns are kept. The items' projects are
+// locked first, in the order other writers lock them.
+func (r *backlogRepository) ApplyBulk(ids []uuid.UUID, change BulkChanger) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(
+			"SELECT 1 FROM projects WHERE id IN (SELECT project_id FROM backlog_items WHERE id IN ?) ORDER BY id FOR UPDATE",
+			ids,
+		).Error; err != nil {
+			return err
+		}
+
+		var items []models.BacklogItem
+		if err := tx.Preload("Project").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", ids).
+			Order("id").
+			Find(&items).Error; err != nil {
+			return err
+		}
+
+		changes, err := change(items)
+		if err != nil {
+			return err
+		}
+
+		for _, update := range changes.Updates {
+			if err := tx.Model(&models.BacklogItem{}).Where("id = ?", update.ID).Updates(update.Columns).Error; err != nil {
+				return err
+			}
+		}
+		if len(changes.DeletedIDs) > 0 {
+			if err := tx.Delete(&models.BacklogItem{}, "id IN ?", changes.DeletedIDs).Error; err != nil {
+				return err
+			}
+		}
+		if len(changes.Histories) > 0 {
+			if err := tx.Create(&changes.Histories).Error; err != nil {
+				return err
+			}
+		}
+		// The guarded columns belong to the projects locked above
+		return r.checkGuards(tx, changes.Guards)
+	})
+}
+
+func (r *backlogRepository) UpdateStatus(id uuid.UUID, status constants.ItemStatus, guards ...WIPGuard) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.lockGuarded(tx, guards); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.BacklogItem{}).Where("id = ?", id).Update("status", status).Error; err != nil {
+			return err
+		}
+		return r.checkGuards(tx, guards)
+	})
 }
 
 func (r *backlogRepository) UpdatePriority(id uuid.UUID, priority constants.Priority) error {
@@ -151,6 +509,67 @@ func (r *backlogRepository) RemoveLabel(id uuid.UUID, label string) error {
 	).Error
 }
 
+func (r *backlogRepository) UpdateParent(id uuid.UUID, parentID *uuid.UUID) error {
+	return r.db.Model(&models.BacklogItem{}).Where("id = ?", id).Update("parent_id", parentID).Error
+}
+
+func (r *backlogRepository) UpdateDueDate(id uuid.UUID, dueDate *time.Time) error {
+	return r.db.Model(&models.BacklogItem{}).Where("id = ?", id).Update("due_date", dueDate).Error
+}
+
+// GetDueForAssignee returns the open items assigned to the user that are due on or
+// before the given day, across all projects, soonest first
+func (r *backlogRepository) GetDueForAssignee(userID uuid.UUID, until time.Time) ([]models.BacklogItem, error) {
+	var items []models.BacklogItem
+	err := r.db.Preload("Sprint").Preload("Project").Preload("Assignees.User").
+		Where("EXISTS (SELECT 1 FROM item_assignees WHERE item_assignees.item_id = backlog_items.id AND item_assignees.user_id = ?)", userID).
+		Where("backlog_items.due_date <= ?", until).
+		Where("NOT " + itemClosed).
+		Order("backlog_items.due_date ASC, " + rankOrder).
+		Find(&items).Error
+	return items, err
+}
+
+func (r *backlogRepository) GetChildren(parentID uuid.UUID) ([]models.BacklogItem, error) {
+	var items []models.BacklogItem
+	err := r.db.Preload("CreatedBy").Preload("Sprint").Preload("Project").Preload("Assignees.User").
+		Where("parent_id = ?", parentID).
+		Order(rankOrder).
+		Find(&items).Error
+	return items, err
+}
+
+// GetAncestorIDs walks up the parent chain of the item and returns the IDs of its
+// ancestors, nearest first. The item itself is not included.
+func (r *backlogRepository) GetAncestorIDs(id uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id, 1 AS depth FROM backlog_items WHERE id = ? AND deleted_at IS NULL
+			UNION
+			SELECT b.parent_id, a.depth + 1 FROM backlog_items b
+			JOIN ancestors a ON b.id = a.parent_id
+			WHERE b.deleted_at IS NULL AND a.depth < 100
+		)
+		SELECT parent_id FROM ancestors WHERE parent_id IS NOT NULL ORDER BY depth`, id).
+		Scan(&ids).Error
+	return ids, err
+}
+
+// GetChildStats counts the children and sums their story points per parent and status
+func (r *backlogRepository) GetChildStats(parentIDs []uuid.UUID) ([]ChildStats, error) {
+	var stats []ChildStats
+	if len(parentIDs) == 0 {
+		return stats, nil
+	}
+	err := r.db.Model(&models.BacklogItem{}).
+		Select("parent_id, status, COUNT(*) AS count, COALESCE(SUM(story_points), 0) AS story_points").
+		Where("parent_id IN ?", parentIDs).
+		Group("parent_id, status").
+		Scan(&stats).Error
+	return stats, err
+}
+
 func (r *backlogRepository) GetMaxPosition(projectID uuid.UUID) (int, error) {
 	var maxPosition int
 	err := r.db.Model(&models.BacklogItem{}).
@@ -160,11 +579,346 @@ func (r *backlogRepository) GetMaxPosition(projectID uuid.UUID) (int, error) {
 	return maxPosition, err
 }
 
+// CountOutsideStatuses counts the project's items whose status is not in the given list
+func (r *backlogRepository) CountOutsideStatuses(projectID uuid.UUID, statuses []constants.ItemStatus) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.BacklogItem{}).Where("project_id = ?", projectID)
+	if len(statuses) > 0 {
+		query = query.Where("status NOT IN ?", statuses)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// CountByStatus counts the project's items per status. A non-nil sprintID narrows the
+// count to that sprint, or to items without a sprint when it is uuid.Nil.
+func (r *backlogRepository) CountByStatus(projectID uuid.UUID, sprintID *uuid.UUID) (map[constants.ItemStatus]int64, error) {
+	return r.countByStatus(r.db, projectID, sprintID)
+}
+
+func (r *backlogRepository) countByStatus(tx *gorm.DB, projectID uuid.UUID, sprintID *uuid.UUID) (map[constants.ItemStatus]int64, error) {
+	var rows []struct {
+		Status constants.ItemStatus
+		Count  int64
+	}
+
+	query := tx.Model(&models.BacklogItem{}).Where("project_id = ?", projectID)
+	if sprintID != nil {
+		if *sprintID == uuid.Nil {
+			query = query.Where("sprint_id IS NULL")
+		} else {
+			query = query.Where("sprint_id = ?", *sprintID)
+		}
+	}
+
+	if err := query.Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[constants.ItemStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// MoveToColumn sets the item's status and places it at index within the target column.
+// Only the moved item is re-ranked: it gets a rank next to its new column neighbours,
+// so ordering relative to other columns is preserved.
+func (r *backlogRepository) MoveToColumn(id uuid.UUID, column BoardColumn, index int, histories []models.ItemHistory, guards ...WIPGuard) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.lockProject(tx, column.ProjectID); err != nil {
+			return err
+		}
+
+		query := tx.Where("project_id = ? AND status = ? AND id <> ?", column.ProjectID, column.Status, id)
+		if column.SprintID != nil {
+			if *column.SprintID == uuid.Nil {
+				query = query.Where("sprint_id IS NULL")
+			} else {
+				query = query.Where("sprint_id = ?", *column.SprintID)
+			}
+		}
+
+		var cards []models.BacklogItem
+		if err := query.Order(rankOrder).Find(&cards).Error; err != nil {
+			return err
+		}
+
+		if index < 0 {
+			index = 0
+		}
+		if index > len(cards) {
+			index = len(cards)
+		}
+
+		// Rank the item before the card it displaces, or after the last card.
+		// An empty column leaves the rank unchanged.
+		switch {
+		case index < len(cards):
+			if _, err := r.placeRank(tx, id, cards[index], false); err != nil {
+				return err
+			}
+		case len(cards) > 0:
+			if _, err := r.placeRank(tx, id, cards[len(cards)-1], true); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.BacklogItem{}).Where("id = ?", id).
+			Update("status", column.Status).Error; err != nil {
+			return err
+		}
+
+		if len(histories) > 0 {
+			if err := tx.Create(&histories).Error; err != nil {
+				return err
+			}
+		}
+
+		return r.checkGuards(tx, guards)
+	})
+}
+
+// MoveRank places the item directly before or after the anchor item in the project
+// backlog and returns its new rank. Only the moved item is updated unless its new
+// rank would exceed rank.MaxLength, in which case the project is rebalanced first.
+func (r *backlogRepository) MoveRank(id, anchorID uuid.UUID, after bool) (string, error) {
+	var newRank string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var anchor models.BacklogItem
+		if err := tx.Where("id = ?", anchorID).First(&anchor).Error; err != nil {
+			return err
+		}
+		if err := r.lockProject(tx, anchor.ProjectID); err != nil {
+			return err
+		}
+
+		var err error
+		newRank, err = r.placeRank(tx, id, anchor, after)
+		return err
+	})
+	return newRank, err
+}
+
+// lockProject locks the project row, serialising rank changes within the project
+func (r *backlogRepository) lockProject(tx *gorm.DB, projectID uuid.UUID) error {
+	return tx.Exec("SELECT 1 FROM projects WHERE id = ? FOR UPDATE", projectID).Error
+}
+
+// lockGuarded locks the projects of the guarded columns before their items change
+func (r *backlogRepository) lockGuarded(tx *gorm.DB, guards []WIPGuard) error {
+	locked := make(map[uuid.UUID]bool, len(guards))
+	for _, guard := range guards {
+		if locked[guard.ProjectID] {
+			continue
+		}
+		locked[guard.ProjectID] = true
+		if err := r.lockProject(tx, guard.ProjectID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkGuards counts the guarded columns after their items changed. The caller must
+// hold the locks taken by lockGuarded.
+func (r *backlogRepository) checkGuards(tx *gorm.DB, guards []WIPGuard) error {
+	for _, guard := range guards {
+		counts, err := r.countByStatus(tx, guard.ProjectID, guard.SprintID)
+		if err != nil {
+			return err
+		}
+		if err := guard.Check(counts[guard.Status]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lastRank returns the highest rank in the project, or an empty string when the
+// project has no ranked items
+func (r *backlogRepository) lastRank(tx *gorm.DB, projectID uuid.UUID) (string, error) {
+	var ranks []string
+	err := tx.Unscoped().Model(&models.BacklogItem{}).
+		Where("project_id = ?", projectID).
+		Order(`backlog_items.rank COLLATE "C" DESC`).
+		Limit(1).
+		Pluck("rank", &ranks).Error
+	if err != nil || len(ranks) == 0 {
+		return "", err
+	}
+	return ranks[0], nil
+}
+
+// neighbourRank returns the rank of the item next to the anchor, skipping the item
+// being moved. It is empty when the anchor is first or last in the project.
+func (r *backlogRepository) neighbourRank(tx *gorm.DB, id uuid.UUID, anchor models.BacklogItem, after bool) (string, error) {
+	query := tx.Unscoped().Model(&models.BacklogItem{}).
+		Where("project_id = ? AND id <> ?", anchor.ProjectID, id)
+	if after {
+		query = query.Where(`backlog_items.rank COLLATE "C" > ?`, anchor.Rank).
+			Order(`backlog_items.rank COLLATE "C" ASC`)
+	} else {
+		query = query.Where(`backlog_items.rank COLLATE "C" < ?`, anchor.Rank).
+			Order(`backlog_items.rank COLLATE "C" DESC`)
+	}
+
+	var ranks []string
+	if err := query.Limit(1).Pluck("rank", &ranks).Error; err != nil || len(ranks) == 0 {
+		return "", err
+	}
+	return ranks[0], nil
+}
+
+// placeRank gives the item a rank directly before or after the anchor. The project
+// is rebalanced when the anchor and its neighbour leave no usable key between them.
+// The caller must hold the project lock.
+func (r *backlogRepository) placeRank(tx *gorm.DB, id uuid.UUID, anchor models.BacklogItem, after bool) (string, error) {
+	key, err := r.rankNextTo(tx, id, anchor, after)
+	if err != nil || len(key) > rank.MaxLength {
+		if err := r.rebalance(tx, anchor.ProjectID); err != nil {
+			return "", err
+		}
+		if err := tx.Model(&models.BacklogItem{}).Where("id = ?", anchor.ID).
+			Select("rank").Scan(&anchor.Rank).Error; err != nil {
+			return "", err
+		}
+		if key, err = r.rankNextTo(tx, id, anchor, after); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Model(&models.BacklogItem{}).Where("id = ?", id).Update("rank", key).Error; err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (r *backlogRepository) rankNextTo(tx *gorm.DB, id uuid.UUID, anchor models.BacklogItem, after bool) (string, error) {
+	neighbour, err := r.neighbourRank(tx, id, anchor, after)
+	if err != nil {
+		return "", err
+	}
+	if after {
+		return rank.Between(anchor.Rank, neighbour)
+	}
+	return rank.Between(neighbour, anchor.Rank)
+}
+
+// rebalance assigns evenly spaced ranks to all the project's items, including
+// deleted ones so that they keep a valid rank, in a single statement. The caller
+// must hold the project lock.
+func (r *backlogRepository) rebalance(tx *gorm.DB, projectID uuid.UUID) error {
+	var ids []string
+	if err := tx.Unscoped().Model(&models.BacklogItem{}).
+		Where("project_id = ?", projectID).
+		Order(rankOrder).
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return tx.Exec(`
+		UPDATE backlog_items SET rank = ranked.rank
+		FROM unnest(?::uuid[], ?::text[]) AS ranked(id, rank)
+		WHERE backlog_items.id = ranked.id`,
+		pq.Array(ids), pq.Array(rank.Spread(len(ids))),
+	).Error
+}
+
+// Highlighted terms are marked with private-use characters, which are removed from
+// the text beforehand, and only turned into <mark> tags once the text is escaped
+const (
+	highlightStart   = "\uE000"
+	highlightStop    = "\uE001"
+	highlightOptions = `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `"`
+)
+
+var highlightTags = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// GetSearchHighlights returns HTML snippets of the items' title, description and
+// best matching comment: the text is escaped and the search terms are wrapped in
+// <mark> tags
+func (r *backlogRepository) GetSearchHighlights(ids []uuid.UUID, search string) ([]SearchHighlight, error) {
+	var highlights []SearchHighlight
+	if len(ids) == 0 || search == "" {
+		return highlights, nil
+	}
+	text := func(column string) string {
+		return "translate(" + column + ", '" + highlightStart + highlightStop + "', '')"
+	}
+	err := r.db.Raw(`
+		SELECT b.id AS item_id,
+			ts_headline(backlog_search_config(), `+text("b.title")+`, q, '`+highlightOptions+`, HighlightAll=true') AS title,
+			CASE WHEN to_tsvector(backlog_search_config(), COALESCE(b.description, '')) @@ q
+				THEN ts_headline(backlog_search_config(), `+text("b.description")+`, q, '`+highlightOptions+`, MaxFragments=2, MaxWords=20, MinWords=5')
+				ELSE '' END AS description,
+			(SELECT ts_headline(backlog_search_config(), `+text("c.content")+`, q, '`+highlightOptions+`, MaxFragments=1, MaxWords=20, MinWords=5')
+				FROM comments c
+				WHERE c.item_id = b.id AND c.deleted_at IS NULL AND to_tsvector(backlog_search_config(), c.content) @@ q
+				ORDER BY ts_rank(to_tsvector(backlog_search_config(), c.content), q) DESC
+				LIMIT 1) AS comment
+		FROM backlog_items b, websearch_to_tsquery(backlog_search_config(), ?) q
+		WHERE b.id IN ?`,
+		search, ids).
+		Scan(&highlights).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range highlights {
+		highlights[i].Title = highlightHTML(highlights[i].Title)
+		highlights[i].Description = highlightHTML(highlights[i].Description)
+		if highlights[i].Comment != nil {
+			comment := highlightHTML(*highlights[i].Comment)
+			highlights[i].Comment = &comment
+		}
+	}
+	return highlights, nil
+}
+
+// highlightHTML escapes a headline and turns its markers into <mark> tags
+func highlightHTML(headline string) string {
+	return highlightTags.Replace(html.EscapeString(headline))
+}
+
+// backlogKeyset returns the ordering for a filtered item list: the requested sort or
+// else the query's order, then the given fallback order
+func backlogKeyset(filters BacklogFilters, fallback []sortKey) keyset {
+	terms := filters.Sort
+	if len(terms) == 0 && filters.Query != nil {
+		terms = filters.Query.OrderBy
+	}
+
+	var keys []sortKey
+	for _, term := range terms {
+		field := canonicalField(term.Field)
+		if field == relevanceField {
+			if filters.Search != "" {
+				keys = append(keys, sortKey{
+					expr:     "ts_rank_cd(backlog_items.search_vector, websearch_to_tsquery(backlog_search_config(), ?))",
+					vars:     []interface{}{filters.Search},
+					castType: "real",
+					desc:     true,
+				})
+			}
+			continue
+		}
+		key := backlogQueryOrders[field]
+		key.desc = term.Desc
+		keys = append(keys, key)
+	}
+	keys = append(keys, fallback...)
+	return keyset{name: expressionName("items", keys), keys: keys}
+}
+
 func (r *backlogRepository) applyFilters(query *gorm.DB, filters BacklogFilters) *gorm.DB {
-	// Search filter
+	// Full-text search over title, labels, description and comments
 	if filters.Search != "" {
-		searchPattern := "%" + filters.Search + "%"
-		query = query.Where("title ILIKE ? OR description ILIKE ?", searchPattern, searchPattern)
+		query = query.Where("backlog_items.search_vector @@ websearch_to_tsquery(backlog_search_config(), ?)", filters.Search)
 	}
 
 	// Type filter
@@ -191,10 +945,47 @@ func (r *backlogRepository) applyFilters(query *gorm.DB, filters BacklogFilters)
 		}
 	}
 
+	// Parent filter
+	if filters.ParentID != nil {
+		if *filters.ParentID == uuid.Nil {
+			query = query.Where("parent_id IS NULL")
+		} else {
+			query = query.Where("parent_id = ?", *filters.ParentID)
+		}
+	}
+
 	// Labels filter
 	if len(filters.Labels) > 0 {
 		query = query.Where("labels && ?", pq.Array(filters.Labels))
 	}
 
+	// Due date filters
+	if filters.DueBefore != nil {
+		query = query.Where("backlog_items.due_date < ?", *filters.DueBefore)
+	}
+	if filters.DueAfter != nil {
+		query = query.Where("backlog_items.due_date > ?", *filters.DueAfter)
+	}
+	if filters.OverdueOn != nil {
+		query = query.Where("backlog_items.due_date < ? AND NOT "+itemClosed, *filters.OverdueOn)
+	}
+
+	// Query language filter
+	if filters.Query != nil && filters.Query.Where != "" {
+		query = query.Where(filters.Query.Where, filters.Query.Args...)
+	}
+
+	// Assignee filter
+	assigned := "EXISTS (SELECT 1 FROM item_assignees WHERE item_assignees.item_id = backlog_items.id AND item_assignees.user_id IN ?)"
+	unassigned := "NOT EXISTS (SELECT 1 FROM item_assignees WHERE item_assignees.item_id = backlog_items.id)"
+	switch {
+	case len(filters.AssigneeIDs) > 0 && filters.Unassigned:
+		query = query.Where(assigned+" OR "+unassigned, filters.AssigneeIDs)
+	case len(filters.AssigneeIDs) > 0:
+		query = query.Where(assigned, filters.AssigneeIDs)
+	case filters.Unassigned:
+		query = query.Where(unassigned)
+	}
+
 	return query
 }
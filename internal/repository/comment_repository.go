@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+type CommentRepository interface {
+	Create(comment *models.Comment, history models.ItemHistory) error
+	GetByID(id uuid.UUID) (*models.Comment, error)
+	GetByItemID(itemID uuid.UUID) ([]models.Comment, error)
+	Update(comment *models.Comment, edit *models.CommentEdit, history models.ItemHistory) error
+	Delete(id uuid.UUID, history models.ItemHistory) error
+	GetEdits(commentID uuid.UUID) ([]models.CommentEdit, error)
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// Create stores the comment with its mentions and history entry in a single transaction
+// and subscribes the author to the item
+func (r *commentRepository) Create(comment *models.Comment, history models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(comment).Error; err != nil {
+			return err
+		}
+		if err := r.createMentions(tx, comment); err != nil {
+			return err
+		}
+		if err := watch(tx, models.Watcher{
+			EntityType: constants.WatchEntityItem,
+			EntityID:   comment.ItemID,
+			UserID:     comment.AuthorID,
+			Reason:     constants.WatchReasonCommenter,
+		}); err != nil {
+			return err
+		}
+		return tx.Create(&history).Error
+	})
+}
+
+func (r *commentRepository) GetByID(id uuid.UUID) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.db.Preload("Author").Preload("Mentions.User").
+		Where("id = ?", id).First(&comment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetByItemID returns the item's comments, oldest first. Deleted comments are only
+// included while their thread still has replies, so that the thread stays intact.
+func (r *commentRepository) GetByItemID(itemID uuid.UUID) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := r.db.Unscoped().Preload("Author").Preload("Mentions.User").
+		Where("comments.item_id = ?", itemID).
+		Where(`comments.deleted_at IS NULL OR EXISTS (
+			SELECT 1 FROM comments replies WHERE replies.parent_id = comments.id AND replies.deleted_at IS NULL)`).
+		Order("comments.created_at ASC, comments.id ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
+// Update saves the comment's new content and replaces its mentions, keeping the
+// previous content as an edit, in a single transaction
+func (r *commentRepository) Update(comment *models.Comment, edit *models.CommentEdit, history models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(edit).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(comment).Omit(clause.Associations).
+			Select("content", "edited_at").Updates(comment).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&models.CommentMention{}).Error; err != nil {
+			return err
+		}
+		if err := r.createMentions(tx, comment); err != nil {
+			return err
+		}
+		return tx.Create(&history).Error
+	})
+}
+
+// Delete soft-deletes the comment and writes its history entry in a single transaction
+func (r *commentRepository) Delete(id uuid.UUID, history models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Comment{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Create(&history).Error
+	})
+}
+
+// GetEdits returns the previous versions of a comment, newest first
+func (r *commentRepository) GetEdits(commentID uuid.UUID) ([]models.CommentEdit, error) {
+	var edits []models.CommentEdit
+	err := r.db.Preload("Editor").
+		Where("comment_id = ?", commentID).
+		Order("edited_at DESC").
+		Find(&edits).Error
+	return edits, err
+}
+
+func (r *commentRepository) createMentions(tx *gorm.DB, comment *models.Comment) error {
+	if len(comment.Mentions) == 0 {
+		return nil
+	}
+	for i := range comment.Mentions {
+		comment.Mentions[i].CommentID = comment.ID
+	}
+	return tx.Omit(clause.Associations).Create(&comment.Mentions).Error
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+type CustomFieldRepository interface {
+	Create(field *models.CustomField) error
+	GetByID(id uuid.UUID) (*models.CustomField, error)
+	GetByProjectID(projectID uuid.UUID) ([]models.CustomField, error)
+	FindByKey(projectID uuid.UUID, key string) (*models.CustomField, error)
+	Update(field *models.CustomField) error
+	Delete(field *models.CustomField, userID uuid.UUID) (int, error)
+}
+
+type customFieldRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldRepository(db *gorm.DB) CustomFieldRepository {
+	return &customFieldRepository{db: db}
+}
+
+func (r *customFieldRepository) Create(field *models.CustomField) error {
+	return r.db.Create(field).Error
+}
+
+func (r *customFieldRepository) GetByID(id uuid.UUID) (*models.CustomField, error) {
+	var field models.CustomField
+	err := r.db.Where("id = ?", id).First(&field).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &field, nil
+}
+
+func (r *customFieldRepository) GetByProjectID(projectID uuid.UUID) ([]models.CustomField, error) {
+	var fields []models.CustomField
+	err := r.db.Where("project_id = ?", projectID).
+		Order("created_at ASC").
+		Find(&fields).Error
+	return fields, err
+}
+
+func (r *customFieldRepository) FindByKey(projectID uuid.UUID, key string) (*models.CustomField, error) {
+	var field models.CustomField
+	err := r.db.Where("project_id = ? AND key = ?", projectID, key).First(&field).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &field, nil
+}
+
+func (r *customFieldRepository) Update(field *models.CustomField) error {
+	return r.db.Save(field).Error
+}
+
+// Delete removes the field and clears its value from every item of the project,
+// deleted ones included, in a single transaction. Each cleared item gets a history
+// entry. It returns the number of items changed.
+func (r *customFieldRepository) Delete(field *models.CustomField, userID uuid.UUID) (int, error) {
+	var changed int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var items []models.BacklogItem
+		if err := tx.Unscoped().Select("id", "custom_fields").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("project_id = ? AND custom_fields->?::text IS NOT NULL", field.ProjectID, field.Key).
+			Find(&items).Error; err != nil {
+			return err
+		}
+
+		key := field.Key
+		now := time.Now()
+		histories := make([]models.ItemHistory, 0, len(items))
+		for _, item := range items {
+			oldVal, _ := json.Marshal(item.CustomFields[key])
+			histories = append(histories, models.ItemHistory{
+				ItemID:       item.ID,
+				UserID:       userID,
+				Action:       constants.ItemActionUpdated,
+				FieldChanged: &key,
+				OldValue:     datatypes.JSON(oldVal),
+				NewValue:     datatypes.JSON("null"),
+				Timestamp:    now,
+			})
+		}
+		if len(items) > 0 {
+			if err := tx.Unscoped().Model(&models.BacklogItem{}).
+				Where("project_id = ? AND custom_fields->?::text IS NOT NULL", field.ProjectID, key).
+				UpdateColumn("custom_fields", gorm.Expr("custom_fields - ?::text", key)).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&histories).Error; err != nil {
+				return err
+			}
+		}
+
+		changed = len(items)
+		return tx.Delete(&models.CustomField{}, "id = ?", field.ID).Error
+	})
+	return changed, err
+}
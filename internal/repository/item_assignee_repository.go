@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+type ItemAssigneeRepository interface {
+	GetByItemID(itemID uuid.UUID) ([]models.ItemAssignee, error)
+	Replace(itemID uuid.UUID, assignees []models.ItemAssignee, histories []models.ItemHistory) error
+}
+
+type itemAssigneeRepository struct {
+	db *gorm.DB
+}
+
+func NewItemAssigneeRepository(db *gorm.DB) ItemAssigneeRepository {
+	return &itemAssigneeRepository{db: db}
+}
+
+func (r *itemAssigneeRepository) GetByItemID(itemID uuid.UUID) ([]models.ItemAssignee, error) {
+	var assignees []models.ItemAssignee
+	err := r.db.Preload("User").
+		Where("item_id = ?", itemID).
+		Order("created_at ASC").
+		Find(&assignees).Error
+	return assignees, err
+}
+
+// Replace swaps the item's assignees for the given list and writes the matching
+// history entries in a single transaction. Assignees kept from the previous list
+// retain their original assignment record; newly assigned users watch the item.
+func (r *itemAssigneeRepository) Replace(itemID uuid.UUID, assignees []models.ItemAssignee, histories []models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		keep := make([]uuid.UUID, 0, len(assignees))
+		for _, a := range assignees {
+			keep = append(keep, a.UserID)
+		}
+
+		query := tx.Where("item_id = ?", itemID)
+		if len(keep) > 0 {
+			query = query.Where("user_id NOT IN ?", keep)
+		}
+		if err := query.Delete(&models.ItemAssignee{}).Error; err != nil {
+			return err
+		}
+
+		var watchers []models.Watcher
+		for i := range assignees {
+			assignees[i].ItemID = itemID
+			result := tx.Where("item_id = ? AND user_id = ?", itemID, assignees[i].UserID).
+				FirstOrCreate(&assignees[i])
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				watchers = append(watchers, models.Watcher{
+					EntityType: constants.WatchEntityItem,
+					EntityID:   itemID,
+					UserID:     assignees[i].UserID,
+					Reason:     constants.WatchReasonAssignee,
+				})
+			}
+		}
+		if err := watch(tx, watchers...); err != nil {
+			return err
+		}
+
+		if len(histories) > 0 {
+			if err := tx.Create(&histories).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -5,14 +5,22 @@ import (
 	"gorm.io/gorm"
 
 	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
 )
 
+// History lists are ordered newest first. They take a cursor from HistoryCursor to
+// continue after a previous page and a limit of 0 returns all remaining entries.
 type ItemHistoryRepository interface {
 	Create(history *models.ItemHistory) error
-	GetByItemID(itemID uuid.UUID) ([]models.ItemHistory, error)
-	GetByUserID(userID uuid.UUID, limit int) ([]models.ItemHistory, error)
+	GetByItemID(itemID uuid.UUID, cursor string, limit int) ([]models.ItemHistory, error)
+	GetByUserID(userID uuid.UUID, cursor string, limit int) ([]models.ItemHistory, error)
+	GetAssignmentsForUser(userID uuid.UUID, cursor string, limit int) ([]models.ItemHistory, error)
+	CountByUserID(userID uuid.UUID) (int64, error)
+	CountAssignmentsForUser(userID uuid.UUID) (int64, error)
 }
 
+var itemHistoryKeys = historyKeyset("item_histories")
+
 type itemHistoryRepository struct {
 	db *gorm.DB
 }
@@ -25,25 +33,52 @@ func (r *itemHistoryRepository) Create(history *models.ItemHistory) error {
 	return r.db.Create(history).Error
 }
 
-func (r *itemHistoryRepository) GetByItemID(itemID uuid.UUID) ([]models.ItemHistory, error) {
-	var histories []models.ItemHistory
-	err := r.db.Preload("User").
-		Where("item_id = ?", itemID).
-		Order("timestamp DESC").
-		Find(&histories).Error
-	return histories, err
+func (r *itemHistoryRepository) GetByItemID(itemID uuid.UUID, cursor string, limit int) ([]models.ItemHistory, error) {
+	return r.find(r.db.Preload("User").Where("item_id = ?", itemID), cursor, limit)
 }
 
-func (r *itemHistoryRepository) GetByUserID(userID uuid.UUID, limit int) ([]models.ItemHistory, error) {
-	var histories []models.ItemHistory
-	query := r.db.Preload("User").Preload("Item").
-		Where("user_id = ?", userID).
-		Order("timestamp DESC")
+func (r *itemHistoryRepository) GetByUserID(userID uuid.UUID, cursor string, limit int) ([]models.ItemHistory, error) {
+	return r.find(r.byUser(userID).Preload("User").Preload("Item"), cursor, limit)
+}
+
+// GetAssignmentsForUser returns the assignment changes made by other users that
+// assigned the user to, or removed the user from, an item
+func (r *itemHistoryRepository) GetAssignmentsForUser(userID uuid.UUID, cursor string, limit int) ([]models.ItemHistory, error) {
+	return r.find(r.assignmentsFor(userID).Preload("User").Preload("Item"), cursor, limit)
+}
+
+func (r *itemHistoryRepository) CountByUserID(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.byUser(userID).Count(&count).Error
+	return count, err
+}
 
+func (r *itemHistoryRepository) CountAssignmentsForUser(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.assignmentsFor(userID).Count(&count).Error
+	return count, err
+}
+
+func (r *itemHistoryRepository) byUser(userID uuid.UUID) *gorm.DB {
+	return r.db.Model(&models.ItemHistory{}).Where("user_id = ?", userID)
+}
+
+func (r *itemHistoryRepository) assignmentsFor(userID uuid.UUID) *gorm.DB {
+	return r.db.Model(&models.ItemHistory{}).
+		Where("user_id <> ?", userID).
+		Where("(action = ? AND new_value = to_jsonb(?::text)) OR (action = ? AND old_value = to_jsonb(?::text))",
+			constants.ItemActionAssigned, userID.String(), constants.ItemActionUnassigned, userID.String())
+}
+
+func (r *itemHistoryRepository) find(query *gorm.DB, cursor string, limit int) ([]models.ItemHistory, error) {
+	var histories []models.ItemHistory
+	query, err := itemHistoryKeys.seek(query, cursor)
+	if err != nil {
+		return nil, err
+	}
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
-
-	err := query.Find(&histories).Error
+	err = query.Find(&histories).Error
 	return histories, err
 }
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+type ItemLinkRepository interface {
+	Create(link *models.ItemLink, histories []models.ItemHistory) error
+	GetByID(id uuid.UUID) (*models.ItemLink, error)
+	GetByItemID(itemID uuid.UUID) ([]models.ItemLink, error)
+	Delete(id uuid.UUID, histories []models.ItemHistory) error
+	FindBetween(itemID, otherID uuid.UUID, linkType constants.LinkType) (*models.ItemLink, error)
+	BlocksTransitively(fromID, toID uuid.UUID) (bool, error)
+	GetBlockers(itemIDs []uuid.UUID) ([]models.ItemLink, error)
+}
+
+type itemLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewItemLinkRepository(db *gorm.DB) ItemLinkRepository {
+	return &itemLinkRepository{db: db}
+}
+
+// Create stores the link together with its history entries in a single transaction
+func (r *itemLinkRepository) Create(link *models.ItemLink, histories []models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(link).Error; err != nil {
+			return err
+		}
+		if len(histories) > 0 {
+			if err := tx.Create(&histories).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *itemLinkRepository) GetByID(id uuid.UUID) (*models.ItemLink, error) {
+	var link models.ItemLink
+	err := r.db.Where("id = ?", id).First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetByItemID returns the links on either side of the item, skipping links
+// whose other item has been deleted
+func (r *itemLinkRepository) GetByItemID(itemID uuid.UUID) ([]models.ItemLink, error) {
+	var links []models.ItemLink
+	err := r.db.Preload("Source").Preload("Target").
+		Joins("JOIN backlog_items source ON source.id = item_links.source_id AND source.deleted_at IS NULL").
+		Joins("JOIN backlog_items target ON target.id = item_links.target_id AND target.deleted_at IS NULL").
+		Where("item_links.source_id = ? OR item_links.target_id = ?", itemID, itemID).
+		Order("item_links.created_at ASC").
+		Find(&links).Error
+	return links, err
+}
+
+// Delete removes the link and writes its history entries in a single transaction
+func (r *itemLinkRepository) Delete(id uuid.UUID, histories []models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.ItemLink{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if len(histories) > 0 {
+			if err := tx.Create(&histories).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindBetween returns a link of the given stored type between the two items in either direction
+func (r *itemLinkRepository) FindBetween(itemID, otherID uuid.UUID, linkType constants.LinkType) (*models.ItemLink, error) {
+	var link models.ItemLink
+	err := r.db.Where("type = ?", linkType).
+		Where("(source_id = ? AND target_id = ?) OR (source_id = ? AND target_id = ?)", itemID, otherID, otherID, itemID).
+		First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+// BlocksTransitively reports whether fromID blocks toID through a chain of "blocks" links
+func (r *itemLinkRepository) BlocksTransitively(fromID, toID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.Raw(`
+		WITH RECURSIVE blocked AS (
+			SELECT target_id FROM item_links WHERE source_id = ? AND type = ?
+			UNION
+			SELECT l.target_id FROM item_links l
+			JOIN blocked b ON l.source_id = b.target_id
+			WHERE l.type = ?
+		)
+		SELECT EXISTS (SELECT 1 FROM blocked WHERE target_id = ?)`,
+		fromID, constants.LinkTypeBlocks, constants.LinkTypeBlocks, toID).
+		Scan(&exists).Error
+	return exists, err
+}
+
+// GetBlockers returns the "blocks" links pointing at any of the items, with the blocking item loaded
+func (r *itemLinkRepository) GetBlockers(itemIDs []uuid.UUID) ([]models.ItemLink, error) {
+	var links []models.ItemLink
+	if len(itemIDs) == 0 {
+		return links, nil
+	}
+	err := r.db.Preload("Source").
+		Where("target_id IN ? AND type = ?", itemIDs, constants.LinkTypeBlocks).
+		Find(&links).Error
+	return links, err
+}
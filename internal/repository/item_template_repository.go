@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sprint-backlog/internal/models"
+)
+
+type ItemTemplateRepository interface {
+	Create(template *models.ItemTemplate) error
+	GetByID(id uuid.UUID) (*models.ItemTemplate, error)
+	GetByProjectID(projectID uuid.UUID) ([]models.ItemTemplate, error)
+	FindByName(projectID uuid.UUID, name string) (*models.ItemTemplate, error)
+	Update(template *models.ItemTemplate) error
+	Delete(id uuid.UUID) error
+}
+
+type itemTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewItemTemplateRepository(db *gorm.DB) ItemTemplateRepository {
+	return &itemTemplateRepository{db: db}
+}
+
+func (r *itemTemplateRepository) Create(template *models.ItemTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *itemTemplateRepository) GetByID(id uuid.UUID) (*models.ItemTemplate, error) {
+	var template models.ItemTemplate
+	err := r.db.Where("id = ?", id).First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *itemTemplateRepository) GetByProjectID(projectID uuid.UUID) ([]models.ItemTemplate, error) {
+	var templates []models.ItemTemplate
+	err := r.db.Where("project_id = ?", projectID).
+		Order("name ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+func (r *itemTemplateRepository) FindByName(projectID uuid.UUID, name string) (*models.ItemTemplate, error) {
+	var template models.ItemTemplate
+	err := r.db.Where("project_id = ? AND name = ?", projectID, name).First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *itemTemplateRepository) Update(template *models.ItemTemplate) error {
+	return r.db.Save(template).Error
+}
+
+func (r *itemTemplateRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.ItemTemplate{}, "id = ?", id).Error
+}
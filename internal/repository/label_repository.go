@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// LabelUsage counts the live items of a project that carry a label
+type LabelUsage struct {
+	Name  string
+	Count int64
+}
+
+type LabelRepository interface {
+	Create(label *models.Label) error
+	GetByID(id uuid.UUID) (*models.Label, error)
+	GetByProjectID(projectID uuid.UUID) ([]models.Label, error)
+	FindByName(projectID uuid.UUID, name string) (*models.Label, error)
+	GetUsage(projectID uuid.UUID) ([]LabelUsage, error)
+	Update(label *models.Label) error
+	Rename(label *models.Label, oldName string, userID uuid.UUID) (int, error)
+	Merge(target *models.Label, sources []string, userID uuid.UUID) (int, error)
+	Delete(id uuid.UUID) error
+}
+
+type labelRepository struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) LabelRepository {
+	return &labelRepository{db: db}
+}
+
+func (r *labelRepository) Create(label *models.Label) error {
+	return r.db.Create(label).Error
+}
+
+func (r *labelRepository) GetByID(id uuid.UUID) (*models.Label, error) {
+	var label models.Label
+	err := r.db.Where("id = ?", id).First(&label).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (r *labelRepository) GetByProjectID(projectID uuid.UUID) ([]models.Label, error) {
+	var labels []models.Label
+	err := r.db.Where("project_id = ?", projectID).
+		Order("name ASC").
+		Find(&labels).Error
+	return labels, err
+}
+
+// FindByName looks up a catalog label ignoring case
+func (r *labelRepository) FindByName(projectID uuid.UUID, name string) (*models.Label, error) {
+	var label models.Label
+	err := r.db.Where("project_id = ? AND LOWER(name) = LOWER(?)", projectID, name).First(&label).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &label, nil
+}
+
+// GetUsage counts every label, catalogued or not, on the project's live items
+func (r *labelRepository) GetUsage(projectID uuid.UUID) ([]LabelUsage, error) {
+	var usage []LabelUsage
+	err := r.db.Raw(
+		`SELECT label AS name, COUNT(*) AS count
+		FROM backlog_items CROSS JOIN LATERAL unnest(labels) AS label
+		WHERE project_id = ? AND deleted_at IS NULL
+		GROUP BY label`,
+		projectID,
+	).Scan(&usage).Error
+	return usage, err
+}
+
+func (r *labelRepository) Update(label *models.Label) error {
+	return r.db.Save(label).Error
+}
+
+// Rename saves the renamed label and rewrites the old name on every item and item
+// template of the project in a single transaction. It returns the number of items
+// changed.
+func (r *labelRepository) Rename(label *models.Label, oldName string, userID uuid.UUID) (int, error) {
+	var changed int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(label).Error; err != nil {
+			return err
+		}
+		var err error
+		changed, err = rewriteLabels(tx, label.ProjectID, []string{oldName}, label.Name, userID)
+		return err
+	})
+	return changed, err
+}
+
+// Merge replaces the source labels with the target on every item and item template
+// of the project and removes the sources from the catalog, in a single transaction.
+// It returns the number of items changed.
+func (r *labelRepository) Merge(target *models.Label, sources []string, userID uuid.UUID) (int, error) {
+	var changed int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ? AND name IN ? AND id <> ?", target.ProjectID, sources, target.ID).
+			Delete(&models.Label{}).Error; err != nil {
+			return err
+		}
+		var err error
+		changed, err = rewriteLabels(tx, target.ProjectID, sources, target.Name, userID)
+		return err
+	})
+	return changed, err
+}
+
+func (r *labelRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.Label{}, "id = ?", id).Error
+}
+
+// rewriteLabels replaces the given labels with one label on the project's items,
+// deleted ones included so a restore brings back current names, and on its
+// templates. Each changed item gets a history entry.
+func rewriteLabels(tx *gorm.DB, projectID uuid.UUID, from []string, to string, userID uuid.UUID) (int, error) {
+	var items []models.BacklogItem
+	if err := tx.Unscoped().Select("id", "labels").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("project_id = ? AND labels && ?", projectID, pq.Array(from)).
+		Find(&items).Error; err != nil {
+		return 0, err
+	}
+
+	field := "labels"
+	now := time.Now()
+	histories := make([]models.ItemHistory, 0, len(items))
+	for _, item := range items {
+		labels := replaceLabels(item.Labels, from, to)
+		if err := tx.Unscoped().Model(&models.BacklogItem{}).
+			Where("id = ?", item.ID).
+			UpdateColumn("labels", labels).Error; err != nil {
+			return 0, err
+		}
+		oldVal, _ := json.Marshal(item.Labels)
+		newVal, _ := json.Marshal(labels)
+		histories = append(histories, models.ItemHistory{
+			ItemID:       item.ID,
+			UserID:       userID,
+			Action:       constants.ItemActionUpdated,
+			FieldChanged: &field,
+			OldValue:     datatypes.JSON(oldVal),
+			NewValue:     datatypes.JSON(newVal),
+			Timestamp:    now,
+		})
+	}
+	if len(histories) > 0 {
+		if err := tx.Create(&histories).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	var templates []models.ItemTemplate
+	if err := tx.Where("project_id = ?", projectID).Find(&templates).Error; err != nil {
+		return 0, err
+	}
+	for _, template := range templates {
+		labels := replaceLabels(template.Labels, from, to)
+		children := make([]models.TemplateChild, len(template.Children))
+		changed := !equalLabels(labels, template.Labels)
+		for i, child := range template.Children {
+			children[i] = child
+			children[i].Labels = replaceLabels(child.Labels, from, to)
+			changed = changed || !equalLabels(children[i].Labels, child.Labels)
+		}
+		if !changed {
+			continue
+		}
+		if err := tx.Model(&models.ItemTemplate{}).
+			Where("id = ?", template.ID).
+			Updates(map[string]interface{}{
+				"labels":   labels,
+				"children": datatypes.JSONSlice[models.TemplateChild](children),
+			}).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	return len(items), nil
+}
+
+// replaceLabels swaps any of from for to, keeping the label order and writing to
+// only once
+func replaceLabels(labels []string, from []string, to string) pq.StringArray {
+	if labels == nil {
+		return nil
+	}
+	replace := make(map[string]bool, len(from))
+	for _, label := range from {
+		replace[label] = true
+	}
+	result := make(pq.StringArray, 0, len(labels))
+	added := false
+	for _, label := range labels {
+		if replace[label] || label == to {
+			if added {
+				continue
+			}
+			label = to
+			added = true
+		}
+		result = append(result, label)
+	}
+	return result
+}
+
+func equalLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
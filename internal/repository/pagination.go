@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sprint-backlog/pkg/cursor"
+)
+
+// PageInfo describes the page returned by a paginated list query
+type PageInfo struct {
+	// Total counts all matching rows; nil when counting was not requested
+	Total *int64
+	// NextCursor continues the list after the last returned row; empty on the last page
+	NextCursor string
+}
+
+// sortKey is one term of a keyset ordering. Cursor values are stored as text and
+// cast back to castType when compared. Nullable keys sort their NULLs last.
+type sortKey struct {
+	expr     string
+	vars     []interface{}
+	castType string
+	desc     bool
+	nullable bool
+}
+
+// keyset is an ordering that ends in a unique key, so that every row has a distinct
+// position and a page can continue strictly after the last row of the previous one.
+// The name is recorded in cursors so that they are only accepted by the same ordering.
+type keyset struct {
+	name string
+	keys []sortKey
+}
+
+// historyKeyset orders history entries newest first. Item and sprint histories
+// share the name so that merged activity lists can use a single cursor.
+func historyKeyset(table string) keyset {
+	return keyset{name: "history", keys: []sortKey{
+		{expr: table + ".timestamp", castType: "timestamptz", desc: true},
+		{expr: table + ".id", castType: "uuid", desc: true},
+	}}
+}
+
+// HistoryCursor returns the cursor that continues a history or activity list after
+// the entry with the given timestamp and ID
+func HistoryCursor(timestamp time.Time, id uuid.UUID) string {
+	return timeCursor("history", timestamp, id)
+}
+
+func timeCursor(name string, t time.Time, id uuid.UUID) string {
+	ts, key := t.Format(time.RFC3339Nano), id.String()
+	return cursor.Encode(name, []*string{&ts, &key})
+}
+
+// orderBy returns the ORDER BY clause of the keyset
+func (k keyset) orderBy() clause.OrderBy {
+	terms := make([]string, len(k.keys))
+	var vars []interface{}
+	for i, key := range k.keys {
+		dir := " ASC"
+		if key.desc {
+			dir = " DESC"
+		}
+		if key.nullable {
+			dir += " NULLS LAST"
+		}
+		terms[i] = key.expr + dir
+		vars = append(vars, key.vars...)
+	}
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                strings.Join(terms, ", "),
+		Vars:               vars,
+		WithoutParentheses: true,
+	}}
+}
+
+// after decodes a cursor and returns the condition selecting the rows that sort
+// after its position: the first key sorts after, or it ties and the second key
+// sorts after, and so on
+func (k keyset) after(token string) (clause.Expr, error) {
+	values, err := cursor.Decode(token, k.name, len(k.keys))
+	if err != nil {
+		return clause.Expr{}, err
+	}
+
+	var terms []string
+	var vars []interface{}
+	var tied []string
+	var tiedVars []interface{}
+	for i, key := range k.keys {
+		expr := "(" + key.expr + ")"
+		value := values[i]
+
+		// Nothing sorts after NULL since NULLs come last
+		if value != nil {
+			op := " > "
+			if key.desc {
+				op = " < "
+			}
+			greater := expr + op + "CAST(? AS " + key.castType + ")"
+			if key.nullable {
+				greater = "(" + greater + " OR " + expr + " IS NULL)"
+			}
+			terms = append(terms, "("+strings.Join(append(append([]string{}, tied...), greater), " AND ")+")")
+			vars = append(vars, tiedVars...)
+			vars = append(vars, key.vars...)
+			vars = append(vars, *value)
+			if key.nullable {
+				vars = append(vars, key.vars...)
+			}
+		}
+
+		if value == nil {
+			tied = append(tied, expr+" IS NULL")
+			tiedVars = append(tiedVars, key.vars...)
+		} else {
+			tied = append(tied, expr+" = CAST(? AS "+key.castType+")")
+			tiedVars = append(tiedVars, key.vars...)
+			tiedVars = append(tiedVars, *value)
+		}
+	}
+
+	if len(terms) == 0 {
+		return clause.Expr{SQL: "FALSE"}, nil
+	}
+	return clause.Expr{SQL: "(" + strings.Join(terms, " OR ") + ")", Vars: vars}, nil
+}
+
+// cursorFor reads the sort key values of the row with the given ID and returns the
+// cursor that continues the list after it
+func (k keyset) cursorFor(db *gorm.DB, table string, id uuid.UUID) (string, error) {
+	columns := make([]string, len(k.keys))
+	var vars []interface{}
+	for i, key := range k.keys {
+		columns[i] = "(" + key.expr + ")::text"
+		vars = append(vars, key.vars...)
+	}
+	vars = append(vars, id)
+
+	rows, err := db.Raw(
+		fmt.Sprintf("SELECT %s FROM %s WHERE %s.id = ?", strings.Join(columns, ", "), table, table),
+		vars...,
+	).Rows()
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return "", rows.Err()
+	}
+	scanned := make([]sql.NullString, len(k.keys))
+	dest := make([]interface{}, len(scanned))
+	for i := range scanned {
+		dest[i] = &scanned[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return "", err
+	}
+
+	values := make([]*string, len(scanned))
+	for i := range scanned {
+		if scanned[i].Valid {
+			values[i] = &scanned[i].String
+		}
+	}
+	return cursor.Encode(k.name, values), nil
+}
+
+// seek orders the query by the keyset and, given a cursor, selects the rows after it
+func (k keyset) seek(query *gorm.DB, token string) (*gorm.DB, error) {
+	query = query.Order(k.orderBy())
+	if token == "" {
+		return query, nil
+	}
+	after, err := k.after(token)
+	if err != nil {
+		return nil, err
+	}
+	return query.Where(after), nil
+}
+
+// paginate selects the page after the cursor or, without one, the numbered page.
+// One extra row is selected so that callers can tell whether another page follows.
+func (k keyset) paginate(query *gorm.DB, token string, page, limit int) (*gorm.DB, error) {
+	query, err := k.seek(query, token)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" && page > 1 && limit > 0 {
+		query = query.Offset((page - 1) * limit)
+	}
+	if limit > 0 {
+		query = query.Limit(limit + 1)
+	}
+	return query, nil
+}
+
+// expressionName identifies an ordering built from arbitrary expressions, so that a
+// cursor issued for one sort is rejected by another
+func expressionName(prefix string, keys []sortKey) string {
+	h := fnv.New64a()
+	for _, key := range keys {
+		fmt.Fprintf(h, "%s|%v|%t;", key.expr, key.vars, key.desc)
+	}
+	return fmt.Sprintf("%s:%x", prefix, h.Sum64())
+}
@@ -1,14 +1,24 @@
 package repository
 
 import (
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/optional"
 )
 
+// EstimateMapper returns the story points and estimate label an item with the
+// given ones takes on a project's estimation scale
+type EstimateMapper func(points *int, estimate *string) (*int, *string)
+
 type ProjectRepository interface {
 	Create(project *models.Project) error
 	GetByID(id uuid.UUID) (*models.Project, error)
@@ -16,6 +26,7 @@ type ProjectRepository interface {
 	GetAll() ([]models.Project, error)
 	GetAllWithPagination(page, limit int) ([]models.Project, int64, error)
 	Update(project *models.Project) error
+	UpdateEstimationScale(project *models.Project, mapEstimate EstimateMapper, userID uuid.UUID) (int, error)
 	Delete(id uuid.UUID) error
 }
 
@@ -81,10 +92,99 @@ func (r *projectRepository) GetAllWithPagination(page, limit int) ([]models.Proj
 	return projects, total, err
 }
 
+// Update writes the fields a project edit changes. The item sequence and the
+// estimation scale are maintained by their own writes and left as stored.
 func (r *projectRepository) Update(project *models.Project) error {
-	return r.db.Save(project).Error
+	return r.db.Model(project).Select("name", "description", "strict_labels").Updates(project).Error
+}
+
+// UpdateEstimationScale saves the project's estimation scale and maps the estimates
+// of its open items, deleted ones included, onto it in a single transaction. Done
+// items and items of completed sprints keep the estimate they were finished with,
+// so velocity and reports of past work do not change. Each changed item gets a
+// history entry per changed field. It returns the number of items changed.
+func (r *projectRepository) UpdateEstimationScale(project *models.Project, mapEstimate EstimateMapper, userID uuid.UUID) (int, error) {
+	var changed int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Project{}).Where("id = ?", project.ID).Updates(map[string]interface{}{
+			"estimation_scale":   project.EstimationScale,
+			"estimation_options": project.EstimationOptions,
+		}).Error; err != nil {
+			return err
+		}
+
+		var items []models.BacklogItem
+		if err := tx.Unscoped().Select("id", "story_points", "estimate").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("project_id = ? AND (story_points IS NOT NULL OR estimate IS NOT NULL)", project.ID).
+			Where("NOT "+itemDone).
+			Where("sprint_id IS NULL OR sprint_id NOT IN (SELECT id FROM sprints WHERE status = ?)", constants.SprintStatusCompleted).
+			Find(&items).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var histories []models.ItemHistory
+		record := func(itemID uuid.UUID, field string, oldValue, newValue interface{}) {
+			oldVal, _ := json.Marshal(oldValue)
+			newVal, _ := json.Marshal(newValue)
+			histories = append(histories, models.ItemHistory{
+				ItemID:       itemID,
+				UserID:       userID,
+				Action:       constants.ItemActionUpdated,
+				FieldChanged: &field,
+				OldValue:     datatypes.JSON(oldVal),
+				NewValue:     datatypes.JSON(newVal),
+				Timestamp:    now,
+			})
+		}
+
+		for _, item := range items {
+			points, estimate := mapEstimate(item.StoryPoints, item.Estimate)
+			pointsChanged := !optional.Equal(points, item.StoryPoints)
+			estimateChanged := !optional.Equal(estimate, item.Estimate)
+			if !pointsChanged && !estimateChanged {
+				continue
+			}
+			if err := tx.Unscoped().Model(&models.BacklogItem{}).
+				Where("id = ?", item.ID).
+				UpdateColumns(map[string]interface{}{"story_points": points, "estimate": estimate}).Error; err != nil {
+				return err
+			}
+			if pointsChanged {
+				record(item.ID, "story_points", item.StoryPoints, points)
+			}
+			if estimateChanged {
+				record(item.ID, "estimate", item.Estimate, estimate)
+			}
+			changed++
+		}
+
+		if len(histories) > 0 {
+			return tx.Create(&histories).Error
+		}
+		return nil
+	})
+	return changed, err
 }
 
+// Delete moves the project to the trash together with its sprints and items. They
+// all get the same deletion time, which is how a restore finds them again.
 func (r *projectRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Project{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&models.BacklogItem{}).
+			Where("project_id = ?", id).
+			Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Sprint{}).
+			Where("project_id = ?", id).
+			Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Project{}).
+			Where("id = ?", id).
+			Update("deleted_at", now).Error
+	})
 }
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sprint-backlog/internal/models"
+)
+
+type SavedFilterRepository interface {
+	Create(filter *models.SavedFilter) error
+	GetByID(id uuid.UUID) (*models.SavedFilter, error)
+	GetVisible(projectID, userID uuid.UUID) ([]models.SavedFilter, error)
+	FindByName(projectID, ownerID uuid.UUID, name string) (*models.SavedFilter, error)
+	Update(filter *models.SavedFilter) error
+	Delete(id uuid.UUID) error
+}
+
+type savedFilterRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedFilterRepository(db *gorm.DB) SavedFilterRepository {
+	return &savedFilterRepository{db: db}
+}
+
+func (r *savedFilterRepository) Create(filter *models.SavedFilter) error {
+	return r.db.Create(filter).Error
+}
+
+func (r *savedFilterRepository) GetByID(id uuid.UUID) (*models.SavedFilter, error) {
+	var filter models.SavedFilter
+	err := r.db.Preload("Owner").Where("id = ?", id).First(&filter).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &filter, nil
+}
+
+// GetVisible returns the project's filters owned by the user or shared with them
+func (r *savedFilterRepository) GetVisible(projectID, userID uuid.UUID) ([]models.SavedFilter, error) {
+	var filters []models.SavedFilter
+	err := r.db.Preload("Owner").
+		Where("project_id = ? AND (owner_id = ? OR shared)", projectID, userID).
+		Order("name ASC").
+		Find(&filters).Error
+	return filters, err
+}
+
+func (r *savedFilterRepository) FindByName(projectID, ownerID uuid.UUID, name string) (*models.SavedFilter, error) {
+	var filter models.SavedFilter
+	err := r.db.Where("project_id = ? AND owner_id = ? AND name = ?", projectID, ownerID, name).First(&filter).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &filter, nil
+}
+
+func (r *savedFilterRepository) Update(filter *models.SavedFilter) error {
+	return r.db.Omit("Owner").Save(filter).Error
+}
+
+func (r *savedFilterRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.SavedFilter{}, "id = ?", id).Error
+}
@@ -7,13 +7,18 @@ import (
 	"sprint-backlog/internal/models"
 )
 
+// History lists are ordered newest first. They take a cursor from HistoryCursor to
+// continue after a previous page and a limit of 0 returns all remaining entries.
 type SprintHistoryRepository interface {
 	Create(history *models.SprintHistory) error
-	GetBySprintID(sprintID uuid.UUID) ([]models.SprintHistory, error)
-	GetByUserID(userID uuid.UUID, limit int) ([]models.SprintHistory, error)
+	GetBySprintID(sprintID uuid.UUID, cursor string, limit int) ([]models.SprintHistory, error)
+	GetByUserID(userID uuid.UUID, cursor string, limit int) ([]models.SprintHistory, error)
+	CountByUserID(userID uuid.UUID) (int64, error)
 	GetAll(limit int) ([]models.SprintHistory, error)
 }
 
+var sprintHistoryKeys = historyKeyset("sprint_histories")
+
 type sprintHistoryRepository struct {
 	db *gorm.DB
 }
@@ -26,38 +31,33 @@ func (r *sprintHistoryRepository) Create(history *models.SprintHistory) error {
 	return r.db.Create(history).Error
 }
 
-func (r *sprintHistoryRepository) GetBySprintID(sprintID uuid.UUID) ([]models.SprintHistory, error) {
-	var histories []models.SprintHistory
-	err := r.db.Preload("User").Preload("Item").
-		Where("sprint_id = ?", sprintID).
-		Order("timestamp DESC").
-		Find(&histories).Error
-	return histories, err
+func (r *sprintHistoryRepository) GetBySprintID(sprintID uuid.UUID, cursor string, limit int) ([]models.SprintHistory, error) {
+	return r.find(r.db.Preload("User").Preload("Item").Where("sprint_id = ?", sprintID), cursor, limit)
 }
 
-func (r *sprintHistoryRepository) GetByUserID(userID uuid.UUID, limit int) ([]models.SprintHistory, error) {
-	var histories []models.SprintHistory
-	query := r.db.Preload("User").Preload("Sprint").Preload("Item").
-		Where("user_id = ?", userID).
-		Order("timestamp DESC")
-
-	if limit > 0 {
-		query = query.Limit(limit)
-	}
+func (r *sprintHistoryRepository) GetByUserID(userID uuid.UUID, cursor string, limit int) ([]models.SprintHistory, error) {
+	return r.find(r.db.Preload("User").Preload("Sprint").Preload("Item").Where("user_id = ?", userID), cursor, limit)
+}
 
-	err := query.Find(&histories).Error
-	return histories, err
+func (r *sprintHistoryRepository) CountByUserID(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.SprintHistory{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
 }
 
 func (r *sprintHistoryRepository) GetAll(limit int) ([]models.SprintHistory, error) {
-	var histories []models.SprintHistory
-	query := r.db.Preload("User").Preload("Sprint").Preload("Item").
-		Order("timestamp DESC")
+	return r.find(r.db.Preload("User").Preload("Sprint").Preload("Item"), "", limit)
+}
 
+func (r *sprintHistoryRepository) find(query *gorm.DB, cursor string, limit int) ([]models.SprintHistory, error) {
+	var histories []models.SprintHistory
+	query, err := sprintHistoryKeys.seek(query, cursor)
+	if err != nil {
+		return nil, err
+	}
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
-
-	err := query.Find(&histories).Error
+	err = query.Find(&histories).Error
 	return histories, err
 }
@@ -8,19 +8,21 @@ import (
 
 	"sprint-backlog/internal/models"
 	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/query"
 )
 
 type SprintRepository interface {
 	Create(sprint *models.Sprint) error
 	GetByID(id uuid.UUID) (*models.Sprint, error)
+	GetByIDs(ids []uuid.UUID) ([]models.Sprint, error)
 	GetByProjectID(projectID uuid.UUID, filters SprintFilters) ([]models.Sprint, int64, error)
-	GetAll(filters SprintFilters) ([]models.Sprint, int64, error)
+	GetAll(filters SprintFilters) ([]models.Sprint, PageInfo, error)
 	GetActive(projectID uuid.UUID) (*models.Sprint, error)
 	Update(sprint *models.Sprint) error
 	Delete(id uuid.UUID) error
 	UpdateStatus(id uuid.UUID, status constants.SprintStatus) error
 	GetItemsBySprintID(sprintID uuid.UUID) ([]models.BacklogItem, error)
-	CalculateVelocity(sprintID uuid.UUID) (int, error)
+	CalculateVelocity(sprintID uuid.UUID, doneStatuses []constants.ItemStatus) (int, error)
 }
 
 type SprintFilters struct {
@@ -28,6 +30,55 @@ type SprintFilters struct {
 	Status    []constants.SprintStatus
 	Page      int
 	Limit     int
+	// Sort orders the sprints by validated terms, see ValidateSprintSort
+	Sort []query.OrderTerm
+	// Cursor continues the list after the last sprint of a previous page and takes
+	// precedence over Page
+	Cursor     string
+	CountTotal bool
+}
+
+// sprintKeys orders sprints by start date, latest first
+var sprintKeys = keyset{name: "sprints", keys: []sortKey{
+	{expr: "sprints.start_date", castType: "timestamptz", desc: true},
+	{expr: "sprints.id", castType: "uuid", desc: true},
+}}
+
+// sprintSortOrders maps the fields that sprints can be sorted by to their sort key.
+// Statuses sort by their place in the sprint lifecycle rather than alphabetically.
+var sprintSortOrders = map[string]sortKey{
+	"name":       {expr: "sprints.name", castType: "text"},
+	"status":     {expr: "CASE sprints.status WHEN 'Planning' THEN 1 WHEN 'Active' THEN 2 WHEN 'Completed' THEN 3 WHEN 'Cancelled' THEN 4 END", castType: "integer", nullable: true},
+	"start_date": {expr: "sprints.start_date", castType: "timestamptz"},
+	"end_date":   {expr: "sprints.end_date", castType: "timestamptz"},
+	"created_at": {expr: "sprints.created_at", castType: "timestamptz"},
+	"updated_at": {expr: "sprints.updated_at", castType: "timestamptz"},
+}
+
+// ValidateSprintSort checks that sprints can be sorted by every field of the terms
+func ValidateSprintSort(terms []query.OrderTerm) error {
+	for _, term := range terms {
+		if _, ok := sprintSortOrders[term.Field]; !ok {
+			return query.Errorf(term.Pos, "cannot sort by %q; use one of %s", term.Field, fieldList(sprintSortOrders))
+		}
+	}
+	return nil
+}
+
+// sprintKeyset returns the ordering for a filtered sprint list: the requested sort,
+// then the default order by start date
+func sprintKeyset(filters SprintFilters) keyset {
+	if len(filters.Sort) == 0 {
+		return sprintKeys
+	}
+	keys := make([]sortKey, 0, len(filters.Sort)+len(sprintKeys.keys))
+	for _, term := range filters.Sort {
+		key := sprintSortOrders[term.Field]
+		key.desc = term.Desc
+		keys = append(keys, key)
+	}
+	keys = append(keys, sprintKeys.keys...)
+	return keyset{name: expressionName("sprints", keys), keys: keys}
 }
 
 type sprintRepository struct {
@@ -38,8 +89,19 @@ func NewSprintRepository(db *gorm.DB) SprintRepository {
 	return &sprintRepository{db: db}
 }
 
+// Create inserts the sprint and subscribes its creator to it
 func (r *sprintRepository) Create(sprint *models.Sprint) error {
-	return r.db.Create(sprint).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(sprint).Error; err != nil {
+			return err
+		}
+		return watch(tx, models.Watcher{
+			EntityType: constants.WatchEntitySprint,
+			EntityID:   sprint.ID,
+			UserID:     sprint.CreatedByID,
+			Reason:     constants.WatchReasonCreator,
+		})
+	})
 }
 
 func (r *sprintRepository) GetByID(id uuid.UUID) (*models.Sprint, error) {
@@ -55,6 +117,17 @@ func (r *sprintRepository) GetByID(id uuid.UUID) (*models.Sprint, error) {
 	return &sprint, nil
 }
 
+func (r *sprintRepository) GetByIDs(ids []uuid.UUID) ([]models.Sprint, error) {
+	var sprints []models.Sprint
+	if len(ids) == 0 {
+		return sprints, nil
+	}
+	err := r.db.Preload("Project").
+		Where("id IN ?", ids).
+		Find(&sprints).Error
+	return sprints, err
+}
+
 func (r *sprintRepository) GetByProjectID(projectID uuid.UUID, filters SprintFilters) ([]models.Sprint, int64, error) {
 	var sprints []models.Sprint
 	var total int64
@@ -74,35 +147,46 @@ func (r *sprintRepository) GetByProjectID(projectID uuid.UUID, filters SprintFil
 	}
 
 	err := query.Preload("CreatedBy").
-		Order("start_date DESC").
+		Order(sprintKeyset(filters).orderBy()).
 		Find(&sprints).Error
 
 	return sprints, total, err
 }
 
-func (r *sprintRepository) GetAll(filters SprintFilters) ([]models.Sprint, int64, error) {
+func (r *sprintRepository) GetAll(filters SprintFilters) ([]models.Sprint, PageInfo, error) {
 	var sprints []models.Sprint
-	var total int64
+	var info PageInfo
 
 	query := r.db.Model(&models.Sprint{})
 	query = r.applyFilters(query, filters)
 
-	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if filters.CountTotal {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return nil, info, err
+		}
+		info.Total = &total
 	}
 
-	// Apply pagination and get results
-	if filters.Page > 0 && filters.Limit > 0 {
-		offset := (filters.Page - 1) * filters.Limit
-		query = query.Offset(offset).Limit(filters.Limit)
+	keys := sprintKeyset(filters)
+	query, err := keys.paginate(query, filters.Cursor, filters.Page, filters.Limit)
+	if err != nil {
+		return nil, info, err
 	}
 
-	err := query.Preload("CreatedBy").Preload("Project").
-		Order("start_date DESC").
+	err = query.Preload("CreatedBy").Preload("Project").
 		Find(&sprints).Error
+	if err != nil {
+		return nil, info, err
+	}
 
-	return sprints, total, err
+	if filters.Limit > 0 && len(sprints) > filters.Limit {
+		sprints = sprints[:filters.Limit]
+		if info.NextCursor, err = keys.cursorFor(r.db, "sprints", sprints[len(sprints)-1].ID); err != nil {
+			return nil, info, err
+		}
+	}
+	return sprints, info, nil
 }
 
 func (r *sprintRepository) GetActive(projectID uuid.UUID) (*models.Sprint, error) {
@@ -135,15 +219,19 @@ func (r *sprintRepository) GetItemsBySprintID(sprintID uuid.UUID) ([]models.Back
 	var items []models.BacklogItem
 	err := r.db.Preload("CreatedBy").
 		Where("sprint_id = ?", sprintID).
-		Order("position ASC").
+		Order(rankOrder).
 		Find(&items).Error
 	return items, err
 }
 
-func (r *sprintRepository) CalculateVelocity(sprintID uuid.UUID) (int, error) {
+// CalculateVelocity sums the story points of the sprint's items in the given done statuses
+func (r *sprintRepository) CalculateVelocity(sprintID uuid.UUID, doneStatuses []constants.ItemStatus) (int, error) {
 	var velocity int
+	if len(doneStatuses) == 0 {
+		return 0, nil
+	}
 	err := r.db.Model(&models.BacklogItem{}).
-		Where("sprint_id = ? AND status = ?", sprintID, constants.ItemStatusDone).
+		Where("sprint_id = ? AND status IN ?", sprintID, doneStatuses).
 		Select("COALESCE(SUM(story_points), 0)").
 		Scan(&velocity).Error
 	return velocity, err
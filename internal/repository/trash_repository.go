@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// TrashRepository reads and restores soft-deleted projects, sprints and items, and
+// permanently deletes them once they have been in the trash long enough. Rows
+// deleted together with their project share its deletion time.
+type TrashRepository interface {
+	GetProject(id uuid.UUID) (*models.Project, error)
+	GetSprint(id uuid.UUID) (*models.Sprint, error)
+	GetItem(id uuid.UUID) (*models.BacklogItem, error)
+	GetDeletedProjects() ([]models.Project, error)
+	GetDeletedSprints(projectID uuid.UUID) ([]models.Sprint, error)
+	GetDeletedItems(projectID uuid.UUID) ([]models.BacklogItem, error)
+	GetDeletedWith(project *models.Project) ([]models.Sprint, []models.BacklogItem, error)
+	RestoreItem(id uuid.UUID, history models.ItemHistory) error
+	RestoreSprint(id uuid.UUID, history models.SprintHistory) error
+	RestoreProject(project *models.Project, sprintHistories []models.SprintHistory, itemHistories []models.ItemHistory) error
+	Purge(before time.Time) (*PurgeResult, error)
+}
+
+// PurgeResult counts the rows a purge deleted permanently. StorageKeys lists the
+// stored files of the deleted attachments.
+type PurgeResult struct {
+	Projects    int
+	Sprints     int
+	Items       int
+	StorageKeys []string
+}
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &trashRepository{db: db}
+}
+
+// GetProject returns the project whether or not it is deleted
+func (r *trashRepository) GetProject(id uuid.UUID) (*models.Project, error) {
+	var project models.Project
+	err := r.db.Unscoped().Where("id = ?", id).First(&project).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+// GetSprint returns the sprint whether or not it is deleted
+func (r *trashRepository) GetSprint(id uuid.UUID) (*models.Sprint, error) {
+	var sprint models.Sprint
+	err := r.db.Unscoped().Where("id = ?", id).First(&sprint).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sprint, nil
+}
+
+// GetItem returns the item whether or not it is deleted
+func (r *trashRepository) GetItem(id uuid.UUID) (*models.BacklogItem, error) {
+	var item models.BacklogItem
+	err := r.db.Unscoped().Preload("Project", unscoped).Where("id = ?", id).First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetDeletedProjects returns the deleted projects, most recently deleted first
+func (r *trashRepository) GetDeletedProjects() ([]models.Project, error) {
+	var projects []models.Project
+	err := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&projects).Error
+	return projects, err
+}
+
+// GetDeletedSprints returns the project's deleted sprints, most recently deleted first
+func (r *trashRepository) GetDeletedSprints(projectID uuid.UUID) ([]models.Sprint, error) {
+	var sprints []models.Sprint
+	err := r.db.Unscoped().
+		Where("project_id = ? AND deleted_at IS NOT NULL", projectID).
+		Order("deleted_at DESC").
+		Find(&sprints).Error
+	return sprints, err
+}
+
+// GetDeletedItems returns the project's deleted items, most recently deleted first
+func (r *trashRepository) GetDeletedItems(projectID uuid.UUID) ([]models.BacklogItem, error) {
+	var items []models.BacklogItem
+	err := r.db.Unscoped().Preload("Project", unscoped).
+		Where("project_id = ? AND deleted_at IS NOT NULL", projectID).
+		Order("deleted_at DESC").
+		Find(&items).Error
+	return items, err
+}
+
+// GetDeletedWith returns the sprints and items that were deleted together with the
+// project. Rows deleted before it stay in the trash when it is restored.
+func (r *trashRepository) GetDeletedWith(project *models.Project) ([]models.Sprint, []models.BacklogItem, error) {
+	var sprints []models.Sprint
+	var items []models.BacklogItem
+	if !project.DeletedAt.Valid {
+		return sprints, items, nil
+	}
+	if err := r.db.Unscoped().
+		Where("project_id = ? AND deleted_at = ?", project.ID, project.DeletedAt.Time).
+		Find(&sprints).Error; err != nil {
+		return nil, nil, err
+	}
+	if err := r.db.Unscoped().
+		Where("project_id = ? AND deleted_at = ?", project.ID, project.DeletedAt.Time).
+		Find(&items).Error; err != nil {
+		return nil, nil, err
+	}
+	return sprints, items, nil
+}
+
+// RestoreItem takes the item out of the trash and writes its history entry in a
+// single transaction
+func (r *trashRepository) RestoreItem(id uuid.UUID, history models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.BacklogItem{}).
+			Where("id = ?", id).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Create(&history).Error
+	})
+}
+
+// RestoreSprint takes the sprint out of the trash and writes its history entry in a
+// single transaction
+func (r *trashRepository) RestoreSprint(id uuid.UUID, history models.SprintHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Sprint{}).
+			Where("id = ?", id).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Create(&history).Error
+	})
+}
+
+// RestoreProject takes the project out of the trash together with the sprints and
+// items deleted with it, and writes their history entries, in a single transaction
+func (r *trashRepository) RestoreProject(project *models.Project, sprintHistories []models.SprintHistory, itemHistories []models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		deletedAt := project.DeletedAt.Time
+		if err := tx.Unscoped().Model(&models.Sprint{}).
+			Where("project_id = ? AND deleted_at = ?", project.ID, deletedAt).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.BacklogItem{}).
+			Where("project_id = ? AND deleted_at = ?", project.ID, deletedAt).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.Project{}).
+			Where("id = ?", project.ID).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if len(sprintHistories) > 0 {
+			if err := tx.Create(&sprintHistories).Error; err != nil {
+				return err
+			}
+		}
+		if len(itemHistories) > 0 {
+			if err := tx.Create(&itemHistories).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Purge permanently deletes the projects, sprints and items deleted before the given
+// time in a single transaction. A purged project takes all its sprints and items
+// with it, and every purged row takes the rows that only exist for it: history,
+// comments, attachments, worklogs, links, assignees and watchers, and a project's
+// workflow, templates, saved filters, labels and custom fields. Live items lose
+// their purged parent or sprint, and sprint history keeps its entries about purged
+// items.
+func (r *trashRepository) Purge(before time.Time) (*PurgeResult, error) {
+	result := &PurgeResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var projectIDs, sprintIDs, itemIDs []uuid.UUID
+		if err := tx.Unscoped().Model(&models.Project{}).
+			Where("deleted_at < ?", before).
+			Pluck("id", &projectIDs).Error; err != nil {
+			return err
+		}
+		sprints := tx.Unscoped().Model(&models.Sprint{}).Where("deleted_at < ?", before)
+		items := tx.Unscoped().Model(&models.BacklogItem{}).Where("deleted_at < ?", before)
+		if len(projectIDs) > 0 {
+			sprints = sprints.Or("project_id IN ?", projectIDs)
+			items = items.Or("project_id IN ?", projectIDs)
+		}
+		if err := sprints.Pluck("id", &sprintIDs).Error; err != nil {
+			return err
+		}
+		if err := items.Pluck("id", &itemIDs).Error; err != nil {
+			return err
+		}
+
+		if len(itemIDs) > 0 {
+			if err := tx.Model(&models.Attachment{}).
+				Where("item_id IN ?", itemIDs).
+				Pluck("storage_key", &result.StorageKeys).Error; err != nil {
+				return err
+			}
+			if err := execAll(tx, itemIDs,
+				"DELETE FROM attachments WHERE item_id IN ?",
+				"DELETE FROM comment_mentions WHERE comment_id IN (SELECT id FROM comments WHERE item_id IN ?)",
+				"DELETE FROM comment_edits WHERE comment_id IN (SELECT id FROM comments WHERE item_id IN ?)",
+				"DELETE FROM comments WHERE item_id IN ?",
+				"DELETE FROM worklogs WHERE item_id IN ?",
+				"DELETE FROM item_assignees WHERE item_id IN ?",
+				"DELETE FROM item_histories WHERE item_id IN ?",
+				"UPDATE sprint_histories SET item_id = NULL WHERE item_id IN ?",
+				"UPDATE backlog_items SET parent_id = NULL WHERE parent_id IN ?",
+			); err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM item_links WHERE source_id IN ? OR target_id IN ?",
+				itemIDs, itemIDs).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM watchers WHERE entity_type = ? AND entity_id IN ?",
+				constants.WatchEntityItem, itemIDs).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM backlog_items WHERE id IN ?", itemIDs).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(sprintIDs) > 0 {
+			if err := execAll(tx, sprintIDs,
+				"UPDATE backlog_items SET sprint_id = NULL WHERE sprint_id IN ?",
+				"DELETE FROM sprint_histories WHERE sprint_id IN ?",
+			); err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM watchers WHERE entity_type = ? AND entity_id IN ?",
+				constants.WatchEntitySprint, sprintIDs).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM sprints WHERE id IN ?", sprintIDs).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(projectIDs) > 0 {
+			if err := execAll(tx, projectIDs,
+				"DELETE FROM workflows WHERE project_id IN ?",
+				"DELETE FROM item_templates WHERE project_id IN ?",
+				"DELETE FROM saved_filters WHERE project_id IN ?",
+				"DELETE FROM labels WHERE project_id IN ?",
+				"DELETE FROM custom_fields WHERE project_id IN ?",
+				"DELETE FROM projects WHERE id IN ?",
+			); err != nil {
+				return err
+			}
+		}
+
+		result.Projects = len(projectIDs)
+		result.Sprints = len(sprintIDs)
+		result.Items = len(itemIDs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// execAll runs each statement with the IDs bound to its single placeholder
+func execAll(tx *gorm.DB, ids []uuid.UUID, statements ...string) error {
+	for _, statement := range statements {
+		if err := tx.Exec(statement, ids).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unscoped preloads relations that may be deleted themselves
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
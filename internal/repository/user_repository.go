@@ -16,6 +16,7 @@ type UserRepository interface {
 	GetByGoogleID(googleID string) (*models.User, error)
 	Update(user *models.User) error
 	GetAll() ([]models.User, error)
+	GetByHandles(handles []string) ([]models.User, error)
 }
 
 type userRepository struct {
@@ -75,3 +76,15 @@ func (r *userRepository) GetAll() ([]models.User, error) {
 	err := r.db.Find(&users).Error
 	return users, err
 }
+
+// GetByHandles returns the users whose email address, or the local part of it,
+// matches one of the lowercased mention handles
+func (r *userRepository) GetByHandles(handles []string) ([]models.User, error) {
+	var users []models.User
+	if len(handles) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("LOWER(email) IN ? OR LOWER(split_part(email, '@', 1)) IN ?", handles, handles).
+		Find(&users).Error
+	return users, err
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+type WatcherRepository interface {
+	Watch(watcher *models.Watcher) error
+	Unwatch(entityType constants.WatchEntityType, entityID, userID uuid.UUID) (bool, error)
+	Get(entityType constants.WatchEntityType, entityID, userID uuid.UUID) (*models.Watcher, error)
+	GetByEntity(entityType constants.WatchEntityType, entityID uuid.UUID) ([]models.Watcher, error)
+	GetByUser(userID uuid.UUID, entityType constants.WatchEntityType) ([]models.Watcher, error)
+}
+
+type watcherRepository struct {
+	db *gorm.DB
+}
+
+func NewWatcherRepository(db *gorm.DB) WatcherRepository {
+	return &watcherRepository{db: db}
+}
+
+// Watch subscribes the user to the entity. A user who already watches it keeps
+// the original subscription.
+func (r *watcherRepository) Watch(watcher *models.Watcher) error {
+	return watch(r.db, *watcher)
+}
+
+// Unwatch removes the subscription and reports whether the user was watching
+func (r *watcherRepository) Unwatch(entityType constants.WatchEntityType, entityID, userID uuid.UUID) (bool, error) {
+	result := r.db.Where("entity_type = ? AND entity_id = ? AND user_id = ?", entityType, entityID, userID).
+		Delete(&models.Watcher{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *watcherRepository) Get(entityType constants.WatchEntityType, entityID, userID uuid.UUID) (*models.Watcher, error) {
+	var watcher models.Watcher
+	err := r.db.Preload("User").
+		Where("entity_type = ? AND entity_id = ? AND user_id = ?", entityType, entityID, userID).
+		First(&watcher).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &watcher, nil
+}
+
+func (r *watcherRepository) GetByEntity(entityType constants.WatchEntityType, entityID uuid.UUID) ([]models.Watcher, error) {
+	var watchers []models.Watcher
+	err := r.db.Preload("User").
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("created_at ASC").
+		Find(&watchers).Error
+	return watchers, err
+}
+
+// GetByUser returns the user's subscriptions, newest first. An empty entity type
+// returns subscriptions of every type.
+func (r *watcherRepository) GetByUser(userID uuid.UUID, entityType constants.WatchEntityType) ([]models.Watcher, error) {
+	var watchers []models.Watcher
+	query := r.db.Where("user_id = ?", userID)
+	if entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+	err := query.Order("created_at DESC").Find(&watchers).Error
+	return watchers, err
+}
+
+// watch inserts the subscriptions within a transaction, skipping users who already
+// watch the entity. Repositories use it to subscribe creators, assignees and
+// commenters together with the write that involves them.
+func watch(tx *gorm.DB, watchers ...models.Watcher) error {
+	if len(watchers) == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&watchers).Error
+}
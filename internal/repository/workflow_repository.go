@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sprint-backlog/internal/models"
+)
+
+type WorkflowRepository interface {
+	GetByProjectID(projectID uuid.UUID) (*models.Workflow, error)
+	Save(workflow *models.Workflow) error
+}
+
+type workflowRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkflowRepository(db *gorm.DB) WorkflowRepository {
+	return &workflowRepository{db: db}
+}
+
+func (r *workflowRepository) GetByProjectID(projectID uuid.UUID) (*models.Workflow, error) {
+	var workflow models.Workflow
+	err := r.db.Where("project_id = ?", projectID).First(&workflow).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+func (r *workflowRepository) Save(workflow *models.Workflow) error {
+	return r.db.Save(workflow).Error
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sprint-backlog/internal/models"
+)
+
+// WeeklyItemTime is the time a user logged on an item in the week starting on WeekStart
+type WeeklyItemTime struct {
+	WeekStart time.Time
+	ItemID    uuid.UUID
+	Minutes   int
+}
+
+type WorklogRepository interface {
+	Create(worklog *models.Worklog, history models.ItemHistory) error
+	GetByID(id uuid.UUID) (*models.Worklog, error)
+	GetByItemID(itemID uuid.UUID) ([]models.Worklog, error)
+	Update(worklog *models.Worklog, delta int, history models.ItemHistory) error
+	Delete(worklog *models.Worklog, history models.ItemHistory) error
+	GetWeeklyByUser(userID uuid.UUID, from, to time.Time) ([]WeeklyItemTime, error)
+	SumByItems(itemIDs []uuid.UUID, from, to time.Time) (int, error)
+}
+
+type worklogRepository struct {
+	db *gorm.DB
+}
+
+func NewWorklogRepository(db *gorm.DB) WorklogRepository {
+	return &worklogRepository{db: db}
+}
+
+// Create stores the worklog, adds its minutes to the item's time spent and writes its
+// history entry in a single transaction
+func (r *worklogRepository) Create(worklog *models.Worklog, history models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(worklog).Error; err != nil {
+			return err
+		}
+		if err := r.logTime(tx, worklog.ItemID, worklog.Minutes); err != nil {
+			return err
+		}
+		return tx.Create(&history).Error
+	})
+}
+
+func (r *worklogRepository) GetByID(id uuid.UUID) (*models.Worklog, error) {
+	var worklog models.Worklog
+	err := r.db.Preload("User").Where("id = ?", id).First(&worklog).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &worklog, nil
+}
+
+// GetByItemID returns the item's worklogs, most recent work first
+func (r *worklogRepository) GetByItemID(itemID uuid.UUID) ([]models.Worklog, error) {
+	var worklogs []models.Worklog
+	err := r.db.Preload("User").
+		Where("item_id = ?", itemID).
+		Order("date DESC, created_at DESC").
+		Find(&worklogs).Error
+	return worklogs, err
+}
+
+// Update saves the worklog and applies the change in minutes, delta, to the item's
+// time spent in a single transaction
+func (r *worklogRepository) Update(worklog *models.Worklog, delta int, history models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(worklog).Omit(clause.Associations).
+			Select("minutes", "date", "note").Updates(worklog).Error; err != nil {
+			return err
+		}
+		if err := r.logTime(tx, worklog.ItemID, delta); err != nil {
+			return err
+		}
+		return tx.Create(&history).Error
+	})
+}
+
+// Delete removes the worklog and takes its minutes off the item's time spent in a
+// single transaction
+func (r *worklogRepository) Delete(worklog *models.Worklog, history models.ItemHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Worklog{}, "id = ?", worklog.ID).Error; err != nil {
+			return err
+		}
+		if err := r.logTime(tx, worklog.ItemID, -worklog.Minutes); err != nil {
+			return err
+		}
+		return tx.Create(&history).Error
+	})
+}
+
+// GetWeeklyByUser sums the user's worklogs per item and week between two days,
+// inclusive. Weeks start on Monday.
+func (r *worklogRepository) GetWeeklyByUser(userID uuid.UUID, from, to time.Time) ([]WeeklyItemTime, error) {
+	var rows []WeeklyItemTime
+	err := r.db.Model(&models.Worklog{}).
+		Select("date_trunc('week', date)::date AS week_start, item_id, SUM(minutes) AS minutes").
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, from, to).
+		Group("week_start, item_id").
+		Order("week_start ASC, minutes DESC, item_id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// SumByItems sums the minutes logged on the items between two days, inclusive
+func (r *worklogRepository) SumByItems(itemIDs []uuid.UUID, from, to time.Time) (int, error) {
+	if len(itemIDs) == 0 {
+		return 0, nil
+	}
+	var minutes int
+	err := r.db.Model(&models.Worklog{}).
+		Select("COALESCE(SUM(minutes), 0)").
+		Where("item_id IN ? AND date >= ? AND date <= ?", itemIDs, from, to).
+		Scan(&minutes).Error
+	return minutes, err
+}
+
+// logTime adds minutes to the item's time spent and takes them off its remaining
+// estimate, which never drops below zero. Negative minutes give time back.
+func (r *worklogRepository) logTime(tx *gorm.DB, itemID uuid.UUID, minutes int) error {
+	if minutes == 0 {
+		return nil
+	}
+	return tx.Model(&models.BacklogItem{}).Where("id = ?", itemID).Updates(map[string]interface{}{
+		"time_spent":         gorm.Expr("time_spent + ?", minutes),
+		"remaining_estimate": gorm.Expr("GREATEST(remaining_estimate - ?, 0)", minutes),
+	}).Error
+}
@@ -1,18 +1,26 @@
 package router
 
 import (
+	"fmt"
+	"log"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"gorm.io/gorm"
 
+	"sprint-backlog/internal/config"
 	"sprint-backlog/internal/handler"
 	"sprint-backlog/internal/middleware"
 	"sprint-backlog/internal/repository"
 	"sprint-backlog/internal/service"
+	"sprint-backlog/pkg/storage"
 )
 
-func Setup(db *gorm.DB) *gin.Engine {
+// Setup builds the routes. It also returns the trash service so the caller can run
+// the periodic purge alongside the server.
+func Setup(db *gorm.DB) (*gin.Engine, service.TrashService) {
 	r := gin.New()
 
 	// Global middleware
@@ -27,13 +35,48 @@ func Setup(db *gorm.DB) *gin.Engine {
 	historyRepo := repository.NewItemHistoryRepository(db)
 	sprintRepo := repository.NewSprintRepository(db)
 	sprintHistoryRepo := repository.NewSprintHistoryRepository(db)
+	workflowRepo := repository.NewWorkflowRepository(db)
+	assigneeRepo := repository.NewItemAssigneeRepository(db)
+	linkRepo := repository.NewItemLinkRepository(db)
+	filterRepo := repository.NewSavedFilterRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	templateRepo := repository.NewItemTemplateRepository(db)
+	worklogRepo := repository.NewWorklogRepository(db)
+	watcherRepo := repository.NewWatcherRepository(db)
+	trashRepo := repository.NewTrashRepository(db)
+	labelRepo := repository.NewLabelRepository(db)
+	fieldRepo := repository.NewCustomFieldRepository(db)
+
+	// Initialize attachment storage
+	store, err := newStorage(config.AppConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up attachment storage: %v", err)
+	}
+	attachmentLimits := service.AttachmentLimits{
+		MaxSize:      config.AppConfig.AttachmentMaxSize,
+		AllowedTypes: config.AppConfig.AttachmentAllowedTypes,
+	}
 
 	// Initialize services
 	authService := service.NewAuthService(userRepo)
 	projectService := service.NewProjectService(projectRepo)
-	backlogService := service.NewBacklogService(backlogRepo, historyRepo)
-	sprintService := service.NewSprintService(sprintRepo, sprintHistoryRepo, backlogRepo, historyRepo)
-	userService := service.NewUserService(userRepo, historyRepo, sprintHistoryRepo)
+	backlogService := service.NewBacklogService(backlogRepo, historyRepo, workflowRepo, assigneeRepo, userRepo, linkRepo, templateRepo, projectRepo, labelRepo, fieldRepo, sprintRepo)
+	sprintService := service.NewSprintService(sprintRepo, sprintHistoryRepo, backlogRepo, historyRepo, workflowRepo, worklogRepo)
+	userService := service.NewUserService(userRepo, historyRepo, sprintHistoryRepo, backlogRepo)
+	boardService := service.NewBoardService(projectRepo, backlogRepo, sprintRepo, workflowRepo, linkRepo)
+	workflowService := service.NewWorkflowService(workflowRepo, projectRepo, backlogRepo)
+	linkService := service.NewItemLinkService(linkRepo, backlogRepo)
+	filterService := service.NewSavedFilterService(filterRepo, projectRepo)
+	commentService := service.NewCommentService(commentRepo, backlogRepo, userRepo)
+	attachmentService := service.NewAttachmentService(attachmentRepo, backlogRepo, store, attachmentLimits)
+	templateService := service.NewItemTemplateService(templateRepo, projectRepo)
+	worklogService := service.NewWorklogService(worklogRepo, backlogRepo)
+	watcherService := service.NewWatcherService(watcherRepo, backlogRepo, sprintRepo)
+	labelService := service.NewLabelService(labelRepo, projectRepo)
+	fieldService := service.NewCustomFieldService(fieldRepo, projectRepo)
+	estimationService := service.NewEstimationService(projectRepo)
+	trashService := service.NewTrashService(trashRepo, attachmentRepo, store, time.Duration(config.AppConfig.TrashRetentionDays)*24*time.Hour)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService)
@@ -41,6 +84,19 @@ func Setup(db *gorm.DB) *gin.Engine {
 	backlogHandler := handler.NewBacklogHandler(backlogService)
 	sprintHandler := handler.NewSprintHandler(sprintService)
 	userHandler := handler.NewUserHandler(userService)
+	boardHandler := handler.NewBoardHandler(boardService)
+	workflowHandler := handler.NewWorkflowHandler(workflowService)
+	linkHandler := handler.NewItemLinkHandler(linkService)
+	filterHandler := handler.NewSavedFilterHandler(filterService)
+	commentHandler := handler.NewCommentHandler(commentService)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, attachmentLimits.MaxSize)
+	templateHandler := handler.NewItemTemplateHandler(templateService)
+	worklogHandler := handler.NewWorklogHandler(worklogService)
+	watcherHandler := handler.NewWatcherHandler(watcherService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	labelHandler := handler.NewLabelHandler(labelService)
+	fieldHandler := handler.NewCustomFieldHandler(fieldService)
+	estimationHandler := handler.NewEstimationHandler(estimationService)
 
 	// Health check
 	r.GET("/health", func(c *gin.Context) {
@@ -72,6 +128,9 @@ func Setup(db *gorm.DB) *gin.Engine {
 				users.GET("", userHandler.GetAll)
 				users.GET("/:id", userHandler.GetByID)
 				users.GET("/:id/activities", userHandler.GetActivities)
+				users.GET("/:id/due-items", userHandler.GetDueItems)
+				users.GET("/:id/timesheet", worklogHandler.GetTimesheet)
+				users.GET("/:id/watching", watcherHandler.GetWatching)
 				users.PUT("/profile", userHandler.UpdateProfile)
 			}
 
@@ -83,6 +142,74 @@ func Setup(db *gorm.DB) *gin.Engine {
 				projects.GET("/:id", projectHandler.GetByID)
 				projects.PUT("/:id", projectHandler.Update)
 				projects.DELETE("/:id", projectHandler.Delete)
+				projects.GET("/:id/workflow", workflowHandler.GetByProject)
+				projects.PUT("/:id/workflow", workflowHandler.Update)
+				projects.PUT("/:id/wip-limits", workflowHandler.UpdateWIPLimits)
+				projects.GET("/:id/filters", filterHandler.GetByProject)
+				projects.POST("/:id/filters", filterHandler.Create)
+				projects.GET("/:id/templates", templateHandler.GetByProject)
+				projects.POST("/:id/templates", templateHandler.Create)
+				projects.GET("/:id/labels", labelHandler.GetByProject)
+				projects.POST("/:id/labels", labelHandler.Create)
+				projects.POST("/:id/labels/merge", labelHandler.Merge)
+				projects.GET("/:id/custom-fields", fieldHandler.GetByProject)
+				projects.POST("/:id/custom-fields", fieldHandler.Create)
+				projects.GET("/:id/estimation-scale", estimationHandler.Get)
+				projects.PUT("/:id/estimation-scale", estimationHandler.Update)
+				projects.GET("/:id/trash", trashHandler.GetProjectTrash)
+				projects.POST("/:id/restore", trashHandler.RestoreProject)
+			}
+
+			// Trash
+			trash := protected.Group("/trash")
+			{
+				trash.GET("/projects", trashHandler.GetDeletedProjects)
+			}
+
+			// Saved filters
+			filters := protected.Group("/filters")
+			{
+				filters.GET("/:id", filterHandler.GetByID)
+				filters.PUT("/:id", filterHandler.Update)
+				filters.DELETE("/:id", filterHandler.Delete)
+			}
+
+			// Item templates
+			templates := protected.Group("/templates")
+			{
+				templates.GET("/:id", templateHandler.GetByID)
+				templates.PUT("/:id", templateHandler.Update)
+				templates.DELETE("/:id", templateHandler.Delete)
+			}
+
+			// Labels
+			labels := protected.Group("/labels")
+			{
+				labels.PUT("/:id", labelHandler.Update)
+				labels.DELETE("/:id", labelHandler.Delete)
+			}
+
+			// Custom fields
+			customFields := protected.Group("/custom-fields")
+			{
+				customFields.GET("/:id", fieldHandler.GetByID)
+				customFields.PUT("/:id", fieldHandler.Update)
+				customFields.DELETE("/:id", fieldHandler.Delete)
+			}
+
+			// Comments
+			comments := protected.Group("/comments")
+			{
+				comments.PUT("/:id", commentHandler.Update)
+				comments.DELETE("/:id", commentHandler.Delete)
+				comments.GET("/:id/edits", commentHandler.GetEdits)
+			}
+
+			// Worklogs
+			worklogs := protected.Group("/worklogs")
+			{
+				worklogs.PUT("/:id", worklogHandler.Update)
+				worklogs.DELETE("/:id", worklogHandler.Delete)
 			}
 
 			// Backlog
@@ -90,15 +217,38 @@ func Setup(db *gorm.DB) *gin.Engine {
 			{
 				backlog.GET("", backlogHandler.GetAll)
 				backlog.POST("", backlogHandler.Create)
+				backlog.POST("/bulk", backlogHandler.Bulk)
 				backlog.GET("/:id", backlogHandler.GetByID)
 				backlog.PUT("/:id", backlogHandler.Update)
 				backlog.DELETE("/:id", backlogHandler.Delete)
 				backlog.PATCH("/:id/status", backlogHandler.UpdateStatus)
 				backlog.PATCH("/:id/priority", backlogHandler.UpdatePriority)
-				backlog.POST("/:id/comments", backlogHandler.AddComment)
+				backlog.GET("/:id/comments", commentHandler.GetByItem)
+				backlog.POST("/:id/comments", commentHandler.Create)
 				backlog.POST("/:id/labels", backlogHandler.AddLabel)
 				backlog.DELETE("/:id/labels/:label", backlogHandler.RemoveLabel)
 				backlog.GET("/:id/history", backlogHandler.GetHistory)
+				backlog.PUT("/:id/assignees", backlogHandler.SetAssignees)
+				backlog.DELETE("/:id/assignees", backlogHandler.ClearAssignees)
+				backlog.PATCH("/:id/parent", backlogHandler.SetParent)
+				backlog.PATCH("/:id/due-date", backlogHandler.SetDueDate)
+				backlog.GET("/:id/children", backlogHandler.GetChildren)
+				backlog.POST("/:id/rank", backlogHandler.Rank)
+				backlog.POST("/:id/clone", backlogHandler.Clone)
+				backlog.POST("/:id/move", backlogHandler.Move)
+				backlog.GET("/:id/links", linkHandler.GetByItem)
+				backlog.POST("/:id/links", linkHandler.Create)
+				backlog.DELETE("/:id/links/:linkId", linkHandler.Delete)
+				backlog.GET("/:id/attachments", attachmentHandler.GetByItem)
+				backlog.POST("/:id/attachments", attachmentHandler.Upload)
+				backlog.GET("/:id/attachments/:attachmentId", attachmentHandler.Download)
+				backlog.DELETE("/:id/attachments/:attachmentId", attachmentHandler.Delete)
+				backlog.GET("/:id/worklogs", worklogHandler.GetByItem)
+				backlog.POST("/:id/worklogs", worklogHandler.Create)
+				backlog.POST("/:id/watch", watcherHandler.WatchItem)
+				backlog.DELETE("/:id/watch", watcherHandler.UnwatchItem)
+				backlog.GET("/:id/watchers", watcherHandler.GetItemWatchers)
+				backlog.POST("/:id/restore", trashHandler.RestoreItem)
 			}
 
 			// Sprints
@@ -116,17 +266,17 @@ func Setup(db *gorm.DB) *gin.Engine {
 				sprints.DELETE("/:id/items/:itemId", sprintHandler.RemoveItem)
 				sprints.GET("/:id/history", sprintHandler.GetHistory)
 				sprints.GET("/:id/report", sprintHandler.GetReport)
+				sprints.POST("/:id/watch", watcherHandler.WatchSprint)
+				sprints.DELETE("/:id/watch", watcherHandler.UnwatchSprint)
+				sprints.GET("/:id/watchers", watcherHandler.GetSprintWatchers)
+				sprints.POST("/:id/restore", trashHandler.RestoreSprint)
 			}
 
 			// Board
 			board := protected.Group("/board")
 			{
-				board.GET("", func(c *gin.Context) {
-					c.JSON(501, gin.H{"message": "Not implemented yet"})
-				})
-				board.PATCH("/items/:id/move", func(c *gin.Context) {
-					c.JSON(501, gin.H{"message": "Not implemented yet"})
-				})
+				board.GET("", boardHandler.GetBoard)
+				board.PATCH("/items/:id/move", boardHandler.MoveItem)
 			}
 		}
 	}
@@ -134,5 +284,32 @@ func Setup(db *gorm.DB) *gin.Engine {
 	// Swagger documentation
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	return r
+	return r, trashService
+}
+
+// newStorage returns the configured backend for attachment files
+func newStorage(cfg *config.Config) (storage.Storage, error) {
+	switch cfg.StorageBackend {
+	case "local":
+		store, err := storage.NewLocal(cfg.StorageLocalPath)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case "s3":
+		store, err := storage.NewS3(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.StorageBackend)
+	}
 }
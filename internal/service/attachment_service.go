@@ -0,0 +1,275 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/storage"
+)
+
+var (
+	ErrAttachmentNotFound       = errors.New("attachment not found")
+	ErrAttachmentEmpty          = errors.New("file is empty")
+	ErrAttachmentTooLarge       = errors.New("file exceeds the maximum attachment size")
+	ErrAttachmentTypeNotAllowed = errors.New("file type is not allowed")
+	ErrAttachmentExists         = errors.New("item already has an attachment with the same content")
+)
+
+// AttachmentLimits restricts the files that can be attached to items
+type AttachmentLimits struct {
+	// MaxSize is the maximum file size in bytes
+	MaxSize int64
+	// AllowedTypes lists the allowed media types, e.g. image/png
+	AllowedTypes []string
+}
+
+func (l AttachmentLimits) allows(contentType string) bool {
+	for _, allowed := range l.AllowedTypes {
+		if strings.EqualFold(allowed, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+type AttachmentService interface {
+	Upload(ctx context.Context, itemID uuid.UUID, fileName, declaredType string, content io.Reader, userID uuid.UUID) (*response.AttachmentResponse, error)
+	GetByItemID(itemID uuid.UUID) ([]response.AttachmentResponse, error)
+	Open(ctx context.Context, itemID, id uuid.UUID) (*response.AttachmentResponse, io.ReadCloser, error)
+	Delete(ctx context.Context, itemID, id uuid.UUID, userID uuid.UUID) error
+}
+
+type attachmentService struct {
+	attachmentRepo repository.AttachmentRepository
+	backlogRepo    repository.BacklogRepository
+	store          storage.Storage
+	limits         AttachmentLimits
+}
+
+func NewAttachmentService(attachmentRepo repository.AttachmentRepository, backlogRepo repository.BacklogRepository, store storage.Storage, limits AttachmentLimits) AttachmentService {
+	return &attachmentService{
+		attachmentRepo: attachmentRepo,
+		backlogRepo:    backlogRepo,
+		store:          store,
+		limits:         limits,
+	}
+}
+
+// Upload attaches a file to an item. The media type is sniffed from the content
+// rather than trusted from the client. Files are stored under their SHA-256
+// checksum, so content attached to several items is stored once; writing it again
+// is harmless and keeps the file in place should its last other attachment be
+// deleted meanwhile.
+func (s *attachmentService) Upload(ctx context.Context, itemID uuid.UUID, fileName, declaredType string, content io.Reader, userID uuid.UUID) (*response.AttachmentResponse, error) {
+	data, err := io.ReadAll(io.LimitReader(content, s.limits.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrAttachmentEmpty
+	}
+	if int64(len(data)) > s.limits.MaxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	contentType := detectContentType(data, declaredType)
+	if !s.limits.allows(contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentTypeNotAllowed, contentType)
+	}
+
+	item, err := s.backlogRepo.GetByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	existing, err := s.attachmentRepo.FindByChecksum(itemID, checksum)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAttachmentExists
+	}
+
+	attachment := &models.Attachment{
+		ID:           uuid.New(),
+		ItemID:       itemID,
+		UploadedByID: userID,
+		FileName:     cleanFileName(fileName),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		Checksum:     checksum,
+		StorageKey:   "attachments/" + checksum[:2] + "/" + checksum,
+	}
+
+	history := attachmentHistory(attachment, constants.ItemActionAttachmentAdded, userID)
+	put := func() error {
+		return s.store.Put(ctx, attachment.StorageKey, bytes.NewReader(data), attachment.Size, contentType)
+	}
+	if err := s.attachmentRepo.Create(attachment, history, put); err != nil {
+		_ = releaseStoredFile(ctx, s.attachmentRepo, s.store, attachment.StorageKey)
+		return nil, err
+	}
+
+	created, err := s.attachmentRepo.GetByID(attachment.ID)
+	if err != nil {
+		return nil, err
+	}
+	return response.ToAttachmentResponse(created), nil
+}
+
+func (s *attachmentService) GetByItemID(itemID uuid.UUID) ([]response.AttachmentResponse, error) {
+	item, err := s.backlogRepo.GetByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	attachments, err := s.attachmentRepo.GetByItemID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ToAttachmentListResponse(attachments), nil
+}
+
+// Open returns an attachment with its content, which the caller must close
+func (s *attachmentService) Open(ctx context.Context, itemID, id uuid.UUID) (*response.AttachmentResponse, io.ReadCloser, error) {
+	attachment, err := s.find(itemID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+
+	return response.ToAttachmentResponse(attachment), content, nil
+}
+
+// Delete detaches a file from an item. The stored file is removed once no item
+// has it attached anymore.
+func (s *attachmentService) Delete(ctx context.Context, itemID, id uuid.UUID, userID uuid.UUID) error {
+	attachment, err := s.find(itemID, id)
+	if err != nil {
+		return err
+	}
+
+	history := attachmentHistory(attachment, constants.ItemActionAttachmentRemoved, userID)
+	if err := s.attachmentRepo.Delete(attachment.ID, history); err != nil {
+		return err
+	}
+
+	// A file left behind by a failed delete is only wasted space; uploading the
+	// same content again reuses it
+	_ = releaseStoredFile(ctx, s.attachmentRepo, s.store, attachment.StorageKey)
+	return nil
+}
+
+func (s *attachmentService) find(itemID, id uuid.UUID) (*models.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil || attachment.ItemID != itemID {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// releaseStoredFile removes a stored file once no attachment uses it anymore
+func releaseStoredFile(ctx context.Context, attachmentRepo repository.AttachmentRepository, store storage.Storage, key string) error {
+	return attachmentRepo.ReleaseStorageKey(key, func() error {
+		return store.Delete(ctx, key)
+	})
+}
+
+// detectContentType sniffs the media type of the content. Text is narrowed to the
+// declared type when that is a textual type too, since e.g. JSON and CSV sniff as
+// plain text; other declared types are ignored.
+func detectContentType(data []byte, declared string) string {
+	sniffed, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	if sniffed != "text/plain" {
+		return sniffed
+	}
+
+	declaredType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return sniffed
+	}
+	switch {
+	case strings.HasPrefix(declaredType, "text/"),
+		declaredType == "application/json",
+		declaredType == "application/xml",
+		strings.HasSuffix(declaredType, "+json"),
+		strings.HasSuffix(declaredType, "+xml"):
+		return declaredType
+	}
+	return sniffed
+}
+
+// cleanFileName keeps the base name of an uploaded file, shortened to 255 bytes
+func cleanFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	for len(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+// attachmentHistory builds the item history entry for attaching or detaching a file
+func attachmentHistory(attachment *models.Attachment, action constants.ItemAction, userID uuid.UUID) models.ItemHistory {
+	field := "attachment"
+	value, _ := json.Marshal(map[string]interface{}{
+		"attachment_id": attachment.ID,
+		"file_name":     attachment.FileName,
+		"size":          attachment.Size,
+	})
+	history := models.ItemHistory{
+		ItemID:       attachment.ItemID,
+		UserID:       userID,
+		Action:       action,
+		FieldChanged: &field,
+	}
+	if action == constants.ItemActionAttachmentRemoved {
+		history.OldValue = datatypes.JSON(value)
+	} else {
+		history.NewValue = datatypes.JSON(value)
+	}
+	return history
+}
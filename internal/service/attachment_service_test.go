@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/storage"
+)
+
+// MockAttachmentRepository is a mock implementation of AttachmentRepository
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Create(attachment *models.Attachment, history models.ItemHistory, store func() error) error {
+	args := m.Called(attachment, history)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return store()
+}
+
+func (m *MockAttachmentRepository) GetByID(id uuid.UUID) (*models.Attachment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetByItemID(itemID uuid.UUID) ([]models.Attachment, error) {
+	args := m.Called(itemID)
+	return args.Get(0).([]models.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FindByChecksum(itemID uuid.UUID, checksum string) (*models.Attachment, error) {
+	args := m.Called(itemID, checksum)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Attachment), args.Error(1)
+}
+
+// ReleaseStorageKey calls remove when the mocked count of remaining attachments is zero
+func (m *MockAttachmentRepository) ReleaseStorageKey(key string, remove func() error) error {
+	args := m.Called(key)
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	if args.Get(0).(int64) > 0 {
+		return nil
+	}
+	return remove()
+}
+
+func (m *MockAttachmentRepository) Delete(id uuid.UUID, history models.ItemHistory) error {
+	args := m.Called(id, history)
+	return args.Error(0)
+}
+
+var testAttachmentLimits = AttachmentLimits{
+	MaxSize:      64,
+	AllowedTypes: []string{"text/plain", "application/json", "image/png"},
+}
+
+const helloChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestAttachmentService_Upload(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store new content and record the attachment", func(t *testing.T) {
+		mockAttachmentRepo := new(MockAttachmentRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		store, _ := storage.NewLocal(t.TempDir())
+		service := NewAttachmentService(mockAttachmentRepo, mockBacklogRepo, store, testAttachmentLimits)
+
+		itemID := uuid.New()
+		key := "attachments/2c/" + helloChecksum
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID}, nil)
+		mockAttachmentRepo.On("FindByChecksum", itemID, helloChecksum).Return(nil, nil)
+		mockAttachmentRepo.On("Create", mock.MatchedBy(func(a *models.Attachment) bool {
+			return a.FileName == "notes.txt" && a.ContentType == "text/plain" && a.Size == 5 && a.StorageKey == key
+		}), mock.MatchedBy(func(h models.ItemHistory) bool {
+			return h.Action == constants.ItemActionAttachmentAdded && h.ItemID == itemID
+		})).Return(nil)
+		mockAttachmentRepo.On("GetByID", mock.Anything).Return(&models.Attachment{ItemID: itemID, Checksum: helloChecksum}, nil)
+
+		result, err := service.Upload(ctx, itemID, "C:\\logs\\notes.txt", "application/octet-stream", strings.NewReader("hello"), uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, helloChecksum, result.Checksum)
+		stored, err := store.Get(ctx, key)
+		if assert.NoError(t, err) {
+			data, _ := io.ReadAll(stored)
+			stored.Close()
+			assert.Equal(t, "hello", string(data))
+		}
+		mockAttachmentRepo.AssertExpectations(t)
+	})
+
+	t.Run("should write content stored for another item again", func(t *testing.T) {
+		mockAttachmentRepo := new(MockAttachmentRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		store, _ := storage.NewLocal(t.TempDir())
+		service := NewAttachmentService(mockAttachmentRepo, mockBacklogRepo, store, testAttachmentLimits)
+
+		itemID := uuid.New()
+		key := "attachments/2c/" + helloChecksum
+		_ = store.Put(ctx, key, strings.NewReader("hello"), 5, "text/plain")
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID}, nil)
+		mockAttachmentRepo.On("FindByChecksum", itemID, helloChecksum).Return(nil, nil)
+		mockAttachmentRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockAttachmentRepo.On("GetByID", mock.Anything).Return(&models.Attachment{ItemID: itemID}, nil)
+
+		_, err := service.Upload(ctx, itemID, "notes.txt", "", strings.NewReader("hello"), uuid.New())
+
+		assert.NoError(t, err)
+		_, err = store.Get(ctx, key)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should release the stored content when the attachment is not saved", func(t *testing.T) {
+		mockAttachmentRepo := new(MockAttachmentRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		store, _ := storage.NewLocal(t.TempDir())
+		service := NewAttachmentService(mockAttachmentRepo, mockBacklogRepo, store, testAttachmentLimits)
+
+		itemID := uuid.New()
+		key := "attachments/2c/" + helloChecksum
+		_ = store.Put(ctx, key, strings.NewReader("hello"), 5, "text/plain")
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID}, nil)
+		mockAttachmentRepo.On("FindByChecksum", itemID, helloChecksum).Return(nil, nil)
+		mockAttachmentRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down"))
+		mockAttachmentRepo.On("ReleaseStorageKey", key).Return(int64(1), nil)
+
+		result, err := service.Upload(ctx, itemID, "notes.txt", "", strings.NewReader("hello"), uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		_, err = store.Get(ctx, key)
+		assert.NoError(t, err)
+		mockAttachmentRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject content already attached to the item", func(t *testing.T) {
+		mockAttachmentRepo := new(MockAttachmentRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewAttachmentService(mockAttachmentRepo, mockBacklogRepo, nil, testAttachmentLimits)
+
+		itemID := uuid.New()
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID}, nil)
+		mockAttachmentRepo.On("FindByChecksum", itemID, helloChecksum).Return(&models.Attachment{ID: uuid.New()}, nil)
+
+		result, err := service.Upload(ctx, itemID, "again.txt", "", strings.NewReader("hello"), uuid.New())
+
+		assert.Equal(t, ErrAttachmentExists, err)
+		assert.Nil(t, result)
+		mockAttachmentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should enforce size and type limits", func(t *testing.T) {
+		service := NewAttachmentService(nil, nil, nil, testAttachmentLimits)
+
+		_, err := service.Upload(ctx, uuid.New(), "big.txt", "", strings.NewReader(strings.Repeat("a", 65)), uuid.New())
+		assert.Equal(t, ErrAttachmentTooLarge, err)
+
+		_, err = service.Upload(ctx, uuid.New(), "empty.txt", "", strings.NewReader(""), uuid.New())
+		assert.Equal(t, ErrAttachmentEmpty, err)
+
+		// Declared types cannot disguise the content
+		_, err = service.Upload(ctx, uuid.New(), "page.png", "image/png", strings.NewReader("<html><body>hi</body></html>"), uuid.New())
+		assert.True(t, errors.Is(err, ErrAttachmentTypeNotAllowed))
+	})
+}
+
+func TestAttachmentService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should remove content no other attachment uses", func(t *testing.T) {
+		mockAttachmentRepo := new(MockAttachmentRepository)
+		store, _ := storage.NewLocal(t.TempDir())
+		service := NewAttachmentService(mockAttachmentRepo, nil, store, testAttachmentLimits)
+
+		attachment := &models.Attachment{ID: uuid.New(), ItemID: uuid.New(), StorageKey: "attachments/2c/" + helloChecksum}
+		_ = store.Put(ctx, attachment.StorageKey, strings.NewReader("hello"), 5, "text/plain")
+		mockAttachmentRepo.On("GetByID", attachment.ID).Return(attachment, nil)
+		mockAttachmentRepo.On("Delete", attachment.ID, mock.MatchedBy(func(h models.ItemHistory) bool {
+			return h.Action == constants.ItemActionAttachmentRemoved
+		})).Return(nil)
+		mockAttachmentRepo.On("ReleaseStorageKey", attachment.StorageKey).Return(int64(0), nil)
+
+		err := service.Delete(ctx, attachment.ItemID, attachment.ID, uuid.New())
+
+		assert.NoError(t, err)
+		_, err = store.Get(ctx, attachment.StorageKey)
+		assert.Equal(t, storage.ErrNotFound, err)
+	})
+
+	t.Run("should not find an attachment of another item", func(t *testing.T) {
+		mockAttachmentRepo := new(MockAttachmentRepository)
+		service := NewAttachmentService(mockAttachmentRepo, nil, nil, testAttachmentLimits)
+
+		attachment := &models.Attachment{ID: uuid.New(), ItemID: uuid.New()}
+		mockAttachmentRepo.On("GetByID", attachment.ID).Return(attachment, nil)
+
+		err := service.Delete(ctx, uuid.New(), attachment.ID, uuid.New())
+
+		assert.Equal(t, ErrAttachmentNotFound, err)
+		mockAttachmentRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDetectContentType(t *testing.T) {
+	assert.Equal(t, "application/json", detectContentType([]byte(`{"a": 1}`), "application/json; charset=utf-8"))
+	assert.Equal(t, "text/plain", detectContentType([]byte("plain"), "image/png"))
+	assert.Equal(t, "image/png", detectContentType([]byte("\x89PNG\r\n\x1a\n0000"), "text/plain"))
+}
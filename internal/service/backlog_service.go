@@ -3,7 +3,14 @@ package service
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/datatypes"
@@ -13,6 +20,8 @@ import (
 	"sprint-backlog/internal/models"
 	"sprint-backlog/internal/repository"
 	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/optional"
+	"sprint-backlog/pkg/query"
 )
 
 var (
@@ -20,35 +29,109 @@ var (
 	ErrInvalidItemType     = errors.New("invalid item type")
 	ErrInvalidPriority     = errors.New("invalid priority")
 	ErrInvalidStatus       = errors.New("invalid status")
+	ErrAssigneeNotFound    = errors.New("assignee user not found")
+	ErrParentNotFound      = errors.New("parent item not found")
+	ErrParentNotInProject  = errors.New("parent item belongs to another project")
+	ErrInvalidParentType   = errors.New("item type cannot be placed under the parent type")
+	ErrParentCycle         = errors.New("parent link would create a cycle")
+	ErrInvalidRankAnchor   = errors.New("exactly one of before_id and after_id must be set to another item")
+	ErrRankAnchorNotFound  = errors.New("rank anchor item not found in the item's project")
+	ErrSameProject         = errors.New("item already belongs to the project")
+)
+
+// ErrInvalidQuery wraps syntax and field errors in query-language expressions
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Bulk operation errors
+var (
+	ErrInvalidBulkOperation = errors.New("operation must be set_status, set_priority, set_sprint, add_label, remove_label, set_story_points or delete")
+	ErrEmptyLabel           = errors.New("label cannot be empty")
+	ErrBulkValidationFailed = errors.New("one or more items failed validation; no changes were applied")
 )
 
 type BacklogService interface {
 	Create(req *request.CreateBacklogItemRequest, userID uuid.UUID) (*response.BacklogItemResponse, error)
 	GetByID(id uuid.UUID) (*response.BacklogItemResponse, error)
-	GetAll(params *request.BacklogQueryParams) (*response.BacklogListResponse, error)
+	GetByKey(key string) (*response.BacklogItemResponse, error)
+	GetAll(params *request.BacklogQueryParams, userID uuid.UUID) (*response.BacklogListResponse, error)
 	Update(id uuid.UUID, req *request.UpdateBacklogItemRequest, userID uuid.UUID) (*response.BacklogItemResponse, error)
 	Delete(id uuid.UUID) error
-	UpdateStatus(id uuid.UUID, status constants.ItemStatus, userID uuid.UUID) (*response.BacklogItemResponse, error)
+	UpdateStatus(id uuid.UUID, req *request.UpdateStatusRequest, userID uuid.UUID) (*response.BacklogItemResponse, error)
 	UpdatePriority(id uuid.UUID, priority constants.Priority, userID uuid.UUID) (*response.BacklogItemResponse, error)
 	AddLabel(id uuid.UUID, label string, userID uuid.UUID) (*response.BacklogItemResponse, error)
 	RemoveLabel(id uuid.UUID, label string, userID uuid.UUID) (*response.BacklogItemResponse, error)
-	AddComment(id uuid.UUID, content string, userID uuid.UUID) (*response.ItemHistoryResponse, error)
-	GetHistory(id uuid.UUID) ([]response.ItemHistoryResponse, error)
+	GetHistory(id uuid.UUID, params *request.HistoryQueryParams) ([]response.ItemHistoryResponse, string, error)
+	SetAssignees(id uuid.UUID, req *request.SetAssigneesRequest, userID uuid.UUID) (*response.BacklogItemResponse, error)
+	ClearAssignees(id uuid.UUID, userID uuid.UUID) (*response.BacklogItemResponse, error)
+	SetParent(id uuid.UUID, req *request.SetParentRequest, userID uuid.UUID) (*response.BacklogItemResponse, error)
+	SetDueDate(id uuid.UUID, req *request.SetDueDateRequest, userID uuid.UUID) (*response.BacklogItemResponse, error)
+	GetChildren(id uuid.UUID) ([]response.BacklogItemResponse, error)
+	Rank(id uuid.UUID, req *request.RankItemRequest, userID uuid.UUID) (*response.BacklogItemResponse, error)
+	Clone(id uuid.UUID, req *request.CloneItemRequest, userID uuid.UUID) (*response.BacklogItemResponse, error)
+	Move(id uuid.UUID, req *request.MoveItemRequest, userID uuid.UUID) (*response.BacklogItemResponse, error)
+	Bulk(req *request.BulkUpdateRequest, userID uuid.UUID) (*response.BulkUpdateResponse, error)
 }
 
 type backlogService struct {
-	backlogRepo repository.BacklogRepository
-	historyRepo repository.ItemHistoryRepository
+	backlogRepo  repository.BacklogRepository
+	historyRepo  repository.ItemHistoryRepository
+	workflowRepo repository.WorkflowRepository
+	assigneeRepo repository.ItemAssigneeRepository
+	userRepo     repository.UserRepository
+	linkRepo     repository.ItemLinkRepository
+	templateRepo repository.ItemTemplateRepository
+	projectRepo  repository.ProjectRepository
+	labelRepo    repository.LabelRepository
+	fieldRepo    repository.CustomFieldRepository
+	sprintRepo   repository.SprintRepository
 }
 
-func NewBacklogService(backlogRepo repository.BacklogRepository, historyRepo repository.ItemHistoryRepository) BacklogService {
+func NewBacklogService(
+	backlogRepo repository.BacklogRepository,
+	historyRepo repository.ItemHistoryRepository,
+	workflowRepo repository.WorkflowRepository,
+	assigneeRepo repository.ItemAssigneeRepository,
+	userRepo repository.UserRepository,
+	linkRepo repository.ItemLinkRepository,
+	templateRepo repository.ItemTemplateRepository,
+	projectRepo repository.ProjectRepository,
+	labelRepo repository.LabelRepository,
+	fieldRepo repository.CustomFieldRepository,
+	sprintRepo repository.SprintRepository,
+) BacklogService {
 	return &backlogService{
-		backlogRepo: backlogRepo,
-		historyRepo: historyRepo,
+		backlogRepo:  backlogRepo,
+		historyRepo:  historyRepo,
+		workflowRepo: workflowRepo,
+		assigneeRepo: assigneeRepo,
+		userRepo:     userRepo,
+		linkRepo:     linkRepo,
+		templateRepo: templateRepo,
+		projectRepo:  projectRepo,
+		labelRepo:    labelRepo,
+		fieldRepo:    fieldRepo,
+		sprintRepo:   sprintRepo,
 	}
 }
 
 func (s *backlogService) Create(req *request.CreateBacklogItemRequest, userID uuid.UUID) (*response.BacklogItemResponse, error) {
+	// Fill empty fields from the template
+	var template *models.ItemTemplate
+	if req.TemplateID != nil {
+		var err error
+		template, err = s.templateRepo.GetByID(*req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		if template == nil {
+			return nil, ErrTemplateNotFound
+		}
+		if template.ProjectID != req.ProjectID {
+			return nil, ErrTemplateNotInProject
+		}
+		applyTemplate(req, template)
+	}
+
 	// Validate type
 	if !req.Type.IsValid() {
 		return nil, ErrInvalidItemType
@@ -59,15 +142,38 @@ func (s *backlogService) Create(req *request.CreateBacklogItemRequest, userID uu
 		return nil, ErrInvalidPriority
 	}
 
+	workflow, err := loadWorkflow(s.workflowRepo, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set default status if not provided
 	status := req.Status
 	if status == "" {
-		status = constants.ItemStatusNew
+		status = workflow.InitialStatus()
 	}
-	if !status.IsValid() {
+	if !workflow.HasStatus(status) {
 		return nil, ErrInvalidStatus
 	}
 
+	// Validate parent link
+	if req.ParentID != nil {
+		if err := s.validateParent(uuid.Nil, req.ProjectID, req.Type, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.SprintID != nil {
+		if err := s.validateSprint(req.ProjectID, *req.SprintID); err != nil {
+			return nil, err
+		}
+	}
+
+	dueDate, err := parseDueDate(req.DueDate)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get max position
 	maxPos, err := s.backlogRepo.GetMaxPosition(req.ProjectID)
 	if err != nil {
@@ -78,6 +184,7 @@ func (s *backlogService) Create(req *request.CreateBacklogItemRequest, userID uu
 	item := &models.BacklogItem{
 		ProjectID:   req.ProjectID,
 		SprintID:    req.SprintID,
+		ParentID:    req.ParentID,
 		CreatedByID: userID,
 		Title:       strings.TrimSpace(req.Title),
 		Type:        req.Type,
@@ -85,7 +192,15 @@ func (s *backlogService) Create(req *request.CreateBacklogItemRequest, userID uu
 		Status:      status,
 		StoryPoints: req.StoryPoints,
 		Labels:      req.Labels,
+		DueDate:     dueDate,
 		Position:    maxPos + 1,
+
+		OriginalEstimate:  req.OriginalEstimate,
+		RemainingEstimate: req.RemainingEstimate,
+	}
+	if item.RemainingEstimate == nil && item.OriginalEstimate != nil {
+		remaining := *item.OriginalEstimate
+		item.RemainingEstimate = &remaining
 	}
 
 	// Set description if provided
@@ -94,12 +209,59 @@ func (s *backlogService) Create(req *request.CreateBacklogItemRequest, userID uu
 		item.Description = &desc
 	}
 
-	if err := s.backlogRepo.Create(item); err != nil {
+	var children []models.BacklogItem
+	if template != nil {
+		children, err = templateChildren(template, item, workflow.InitialStatus(), userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check labels against the project's catalog
+	labels := newLabelCatalogCache(s.projectRepo, s.labelRepo)
+	if item.Labels, err = labels.resolve(req.ProjectID, item.Labels); err != nil {
+		return nil, err
+	}
+	for i := range children {
+		if children[i].Labels, err = labels.resolve(req.ProjectID, children[i].Labels); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check estimates against the project's estimation scale
+	scales := newEstimationScaleCache(s.projectRepo)
+	if item.StoryPoints, item.Estimate, err = scales.resolve(req.ProjectID, req.StoryPoints, req.Estimate); err != nil {
+		return nil, err
+	}
+	for i := range children {
+		if children[i].StoryPoints, children[i].Estimate, err = scales.resolve(req.ProjectID, children[i].StoryPoints, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check custom field values; template children start without any
+	fields, err := loadCustomFields(s.fieldRepo, s.userRepo, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if item.CustomFields, _, err = fields.apply(nil, req.CustomFields, true); err != nil {
+		return nil, err
+	}
+
+	if len(children) > 0 {
+		err = s.backlogRepo.CreateWithChildren(item, children)
+	} else {
+		err = s.backlogRepo.Create(item)
+	}
+	if err != nil {
 		return nil, err
 	}
 
 	// Record history
 	s.recordHistory(item.ID, userID, constants.ItemActionCreated, nil, nil, nil, nil)
+	for _, child := range children {
+		s.recordHistory(child.ID, userID, constants.ItemActionCreated, nil, nil, nil, nil)
+	}
 
 	// Fetch created item with relations
 	created, err := s.backlogRepo.GetByID(item.ID)
@@ -107,7 +269,62 @@ func (s *backlogService) Create(req *request.CreateBacklogItemRequest, userID uu
 		return nil, err
 	}
 
-	return response.ToBacklogItemResponse(created), nil
+	return s.itemResponse(created)
+}
+
+// applyTemplate fills the fields the request leaves empty with the
+// template's values; anything set explicitly on the request wins.
+func applyTemplate(req *request.CreateBacklogItemRequest, template *models.ItemTemplate) {
+	if req.Type == "" {
+		req.Type = template.Type
+	}
+	if req.Priority == "" {
+		req.Priority = template.Priority
+	}
+	if strings.TrimSpace(req.Description) == "" && template.Description != nil {
+		req.Description = *template.Description
+	}
+	if req.StoryPoints == nil && template.StoryPoints != nil {
+		points := *template.StoryPoints
+		req.StoryPoints = &points
+	}
+	if req.Labels == nil && len(template.Labels) > 0 {
+		req.Labels = append([]string(nil), template.Labels...)
+	}
+}
+
+// templateChildren builds the standard child items a template creates
+// under parent. Children land in the parent's sprint with the workflow's
+// initial status and inherit the parent's priority unless they set one.
+func templateChildren(template *models.ItemTemplate, parent *models.BacklogItem, status constants.ItemStatus, userID uuid.UUID) ([]models.BacklogItem, error) {
+	children := make([]models.BacklogItem, 0, len(template.Children))
+	for i, child := range template.Children {
+		if !parent.Type.CanParent(child.Type) {
+			return nil, ErrInvalidParentType
+		}
+		priority := child.Priority
+		if priority == "" {
+			priority = parent.Priority
+		}
+		item := models.BacklogItem{
+			ProjectID:   parent.ProjectID,
+			SprintID:    parent.SprintID,
+			CreatedByID: userID,
+			Title:       child.Title,
+			Type:        child.Type,
+			Priority:    priority,
+			Status:      status,
+			StoryPoints: child.StoryPoints,
+			Labels:      child.Labels,
+			Position:    parent.Position + i + 1,
+		}
+		if child.Description != "" {
+			desc := child.Description
+			item.Description = &desc
+		}
+		children = append(children, item)
+	}
+	return children, nil
 }
 
 func (s *backlogService) GetByID(id uuid.UUID) (*response.BacklogItemResponse, error) {
@@ -119,10 +336,28 @@ func (s *backlogService) GetByID(id uuid.UUID) (*response.BacklogItemResponse, e
 		return nil, ErrBacklogItemNotFound
 	}
 
-	return response.ToBacklogItemResponse(item), nil
+	return s.itemResponse(item)
+}
+
+// GetByKey looks up an item by its human-readable key such as PROJ-123
+func (s *backlogService) GetByKey(key string) (*response.BacklogItemResponse, error) {
+	projectKey, number, ok := parseItemKey(key)
+	if !ok {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	item, err := s.backlogRepo.GetByKey(projectKey, number)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	return s.itemResponse(item)
 }
 
-func (s *backlogService) GetAll(params *request.BacklogQueryParams) (*response.BacklogListResponse, error) {
+func (s *backlogService) GetAll(params *request.BacklogQueryParams, userID uuid.UUID) (*response.BacklogListResponse, error) {
 	// Set defaults
 	if params.Page < 1 {
 		params.Page = 1
@@ -134,56 +369,40 @@ func (s *backlogService) GetAll(params *request.BacklogQueryParams) (*response.B
 		params.Limit = 100
 	}
 
-	// Build filters
-	filters := repository.BacklogFilters{
-		Search: params.Search,
-		Page:   params.Page,
-		Limit:  params.Limit,
-	}
-
-	// Parse type filter
-	for _, t := range params.Type {
-		itemType := constants.ItemType(t)
-		if itemType.IsValid() {
-			filters.Type = append(filters.Type, itemType)
-		}
+	filters, err := buildBacklogFilters(params, userID)
+	if err != nil {
+		return nil, err
 	}
+	filters.Page = params.Page
+	filters.Limit = params.Limit
+	filters.Cursor = params.Cursor
+	filters.CountTotal = includeTotal(params.IncludeTotal, params.Cursor)
 
-	// Parse priority filter
-	for _, p := range params.Priority {
-		priority := constants.Priority(p)
-		if priority.IsValid() {
-			filters.Priority = append(filters.Priority, priority)
-		}
+	items, info, err := s.backlogRepo.GetAll(filters)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse status filter
-	for _, s := range params.Status {
-		status := constants.ItemStatus(s)
-		if status.IsValid() {
-			filters.Status = append(filters.Status, status)
-		}
+	// Page numbers do not apply when paging by cursor
+	page := params.Page
+	if params.Cursor != "" {
+		page = 0
 	}
-
-	// Parse sprint filter
-	if params.SprintID != "" {
-		if params.SprintID == "none" {
-			nilID := uuid.Nil
-			filters.SprintID = &nilID
-		} else if sprintID, err := uuid.Parse(params.SprintID); err == nil {
-			filters.SprintID = &sprintID
-		}
+	result := response.ToBacklogListResponse(items, info.Total, page, params.Limit, info.NextCursor)
+	refs := make([]*response.BacklogItemResponse, len(result.Items))
+	for i := range result.Items {
+		refs[i] = &result.Items[i]
 	}
-
-	// Parse labels filter
-	filters.Labels = params.Labels
-
-	items, total, err := s.backlogRepo.GetAll(filters)
-	if err != nil {
+	if err := s.enrich(refs...); err != nil {
 		return nil, err
 	}
+	if filters.Search != "" {
+		if err := s.attachHighlights(refs, filters.Search); err != nil {
+			return nil, err
+		}
+	}
 
-	return response.ToBacklogListResponse(items, total, params.Page, params.Limit), nil
+	return result, nil
 }
 
 func (s *backlogService) Update(id uuid.UUID, req *request.UpdateBacklogItemRequest, userID uuid.UUID) (*response.BacklogItemResponse, error) {
@@ -198,11 +417,16 @@ func (s *backlogService) Update(id uuid.UUID, req *request.UpdateBacklogItemRequ
 
 	// Track changes for history
 	changes := make(map[string][2]interface{})
+	var columns []string
+	var wipHistory []models.ItemHistory
+	var wipGuards []repository.WIPGuard
+	var warnings []string
 
 	// Update fields if provided
 	if req.Title != "" && req.Title != item.Title {
 		changes["title"] = [2]interface{}{item.Title, req.Title}
 		item.Title = strings.TrimSpace(req.Title)
+		columns = append(columns, "title")
 	}
 
 	if req.Description != "" {
@@ -214,6 +438,7 @@ func (s *backlogService) Update(id uuid.UUID, req *request.UpdateBacklogItemRequ
 			changes["description"] = [2]interface{}{oldDesc, req.Description}
 			desc := strings.TrimSpace(req.Description)
 			item.Description = &desc
+			columns = append(columns, "description")
 		}
 	}
 
@@ -221,8 +446,12 @@ func (s *backlogService) Update(id uuid.UUID, req *request.UpdateBacklogItemRequ
 		if !req.Type.IsValid() {
 			return nil, ErrInvalidItemType
 		}
+		if err := s.validateTypeInHierarchy(item, req.Type); err != nil {
+			return nil, err
+		}
 		changes["type"] = [2]interface{}{item.Type, req.Type}
 		item.Type = req.Type
+		columns = append(columns, "type")
 	}
 
 	if req.Priority != "" && req.Priority != item.Priority {
@@ -231,32 +460,111 @@ func (s *backlogService) Update(id uuid.UUID, req *request.UpdateBacklogItemRequ
 		}
 		changes["priority"] = [2]interface{}{item.Priority, req.Priority}
 		item.Priority = req.Priority
+		columns = append(columns, "priority")
+	}
+
+	// Apply the sprint first so the WIP limit is checked in the column the item lands in
+	if req.SprintID != nil {
+		if err := s.validateSprint(item.ProjectID, *req.SprintID); err != nil {
+			return nil, err
+		}
+		changes["sprint_id"] = [2]interface{}{item.SprintID, req.SprintID}
+		item.SprintID = req.SprintID
+		columns = append(columns, "sprint_id")
 	}
 
 	if req.Status != "" && req.Status != item.Status {
-		if !req.Status.IsValid() {
-			return nil, ErrInvalidStatus
+		workflows := newWorkflowCache(s.workflowRepo)
+		workflow, err := workflows.get(item.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateTransition(workflow, item.Status, req.Status); err != nil {
+			return nil, err
+		}
+		override, guards, err := checkWIPLimit(s.backlogRepo, workflow, item, req.Status, itemWIPScope(item.SprintID), req.OverrideWIPLimit)
+		if err != nil {
+			return nil, err
+		}
+		wipGuards = guards
+		if override != nil {
+			wipHistory = append(wipHistory, override.history(id, userID))
+		}
+		warnings, err = blockedWarnings(s.linkRepo, workflows, item, req.Status)
+		if err != nil {
+			return nil, err
 		}
 		changes["status"] = [2]interface{}{item.Status, req.Status}
 		item.Status = req.Status
+		columns = append(columns, "status")
 	}
 
-	if req.StoryPoints != nil {
-		changes["story_points"] = [2]interface{}{item.StoryPoints, req.StoryPoints}
-		item.StoryPoints = req.StoryPoints
+	if req.StoryPoints != nil || req.Estimate != nil {
+		points, estimate, err := newEstimationScaleCache(s.projectRepo).resolve(item.ProjectID, req.StoryPoints, req.Estimate)
+		if err != nil {
+			return nil, err
+		}
+		changes["story_points"] = [2]interface{}{item.StoryPoints, points}
+		if !optional.Equal(item.Estimate, estimate) {
+			changes["estimate"] = [2]interface{}{item.Estimate, estimate}
+		}
+		item.StoryPoints = points
+		item.Estimate = estimate
+		columns = append(columns, "story_points", "estimate")
 	}
 
 	if req.Labels != nil {
-		changes["labels"] = [2]interface{}{item.Labels, req.Labels}
-		item.Labels = req.Labels
+		labels, err := newLabelCatalogCache(s.projectRepo, s.labelRepo).resolve(item.ProjectID, req.Labels)
+		if err != nil {
+			return nil, err
+		}
+		changes["labels"] = [2]interface{}{item.Labels, labels}
+		item.Labels = labels
+		columns = append(columns, "labels")
 	}
 
-	if req.SprintID != nil {
-		changes["sprint_id"] = [2]interface{}{item.SprintID, req.SprintID}
-		item.SprintID = req.SprintID
+	if req.DueDate != nil {
+		dueDate, err := parseDueDate(req.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		if !sameDueDate(item.DueDate, dueDate) {
+			changes["due_date"] = [2]interface{}{formatDueDate(item.DueDate), formatDueDate(dueDate)}
+			item.DueDate = dueDate
+			columns = append(columns, "due_date")
+		}
+	}
+
+	if req.OriginalEstimate != nil {
+		changes["original_estimate"] = [2]interface{}{item.OriginalEstimate, req.OriginalEstimate}
+		item.OriginalEstimate = req.OriginalEstimate
+		columns = append(columns, "original_estimate")
+	}
+
+	if req.RemainingEstimate != nil {
+		changes["remaining_estimate"] = [2]interface{}{item.RemainingEstimate, req.RemainingEstimate}
+		item.RemainingEstimate = req.RemainingEstimate
+		columns = append(columns, "remaining_estimate")
+	}
+
+	// Custom field changes are recorded under the field's key
+	if len(req.CustomFields) > 0 {
+		fields, err := loadCustomFields(s.fieldRepo, s.userRepo, item.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		values, fieldChanges, err := fields.apply(item.CustomFields, req.CustomFields, false)
+		if err != nil {
+			return nil, err
+		}
+		for key, change := range fieldChanges {
+			changes[key] = change
+		}
+		item.CustomFields = values
+		columns = append(columns, "custom_fields")
 	}
 
-	if err := s.backlogRepo.Update(item); err != nil {
+	if err := s.backlogRepo.Update(item, columns, wipGuards...); err != nil {
 		return nil, err
 	}
 
@@ -266,6 +574,9 @@ func (s *backlogService) Update(id uuid.UUID, req *request.UpdateBacklogItemRequ
 		newVal, _ := json.Marshal(vals[1])
 		s.recordHistory(id, userID, constants.ItemActionUpdated, &field, datatypes.JSON(oldVal), datatypes.JSON(newVal), nil)
 	}
+	for i := range wipHistory {
+		s.historyRepo.Create(&wipHistory[i])
+	}
 
 	// Fetch updated item with relations
 	updated, err := s.backlogRepo.GetByID(id)
@@ -273,7 +584,12 @@ func (s *backlogService) Update(id uuid.UUID, req *request.UpdateBacklogItemRequ
 		return nil, err
 	}
 
-	return response.ToBacklogItemResponse(updated), nil
+	resp, err := s.itemResponse(updated)
+	if err != nil {
+		return nil, err
+	}
+	resp.Warnings = warnings
+	return resp, nil
 }
 
 func (s *backlogService) Delete(id uuid.UUID) error {
@@ -288,10 +604,8 @@ func (s *backlogService) Delete(id uuid.UUID) error {
 	return s.backlogRepo.Delete(id)
 }
 
-func (s *backlogService) UpdateStatus(id uuid.UUID, status constants.ItemStatus, userID uuid.UUID) (*response.BacklogItemResponse, error) {
-	if !status.IsValid() {
-		return nil, ErrInvalidStatus
-	}
+func (s *backlogService) UpdateStatus(id uuid.UUID, req *request.UpdateStatusRequest, userID uuid.UUID) (*response.BacklogItemResponse, error) {
+	status := req.Status
 
 	// Get current item
 	item, err := s.backlogRepo.GetByID(id)
@@ -302,10 +616,28 @@ func (s *backlogService) UpdateStatus(id uuid.UUID, status constants.ItemStatus,
 		return nil, ErrBacklogItemNotFound
 	}
 
+	// Validate against the project workflow
+	workflows := newWorkflowCache(s.workflowRepo)
+	workflow, err := workflows.get(item.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTransition(workflow, item.Status, status); err != nil {
+		return nil, err
+	}
+	override, guards, err := checkWIPLimit(s.backlogRepo, workflow, item, status, itemWIPScope(item.SprintID), req.OverrideWIPLimit)
+	if err != nil {
+		return nil, err
+	}
+	warnings, err := blockedWarnings(s.linkRepo, workflows, item, status)
+	if err != nil {
+		return nil, err
+	}
+
 	oldStatus := item.Status
 
 	// Update status
-	if err := s.backlogRepo.UpdateStatus(id, status); err != nil {
+	if err := s.backlogRepo.UpdateStatus(id, status, guards...); err != nil {
 		return nil, err
 	}
 
@@ -314,6 +646,10 @@ func (s *backlogService) UpdateStatus(id uuid.UUID, status constants.ItemStatus,
 	newVal, _ := json.Marshal(status)
 	field := "status"
 	s.recordHistory(id, userID, constants.ItemActionStatusChanged, &field, datatypes.JSON(oldVal), datatypes.JSON(newVal), nil)
+	if override != nil {
+		history := override.history(id, userID)
+		s.historyRepo.Create(&history)
+	}
 
 	// Fetch updated item
 	updated, err := s.backlogRepo.GetByID(id)
@@ -321,7 +657,12 @@ func (s *backlogService) UpdateStatus(id uuid.UUID, status constants.ItemStatus,
 		return nil, err
 	}
 
-	return response.ToBacklogItemResponse(updated), nil
+	resp, err := s.itemResponse(updated)
+	if err != nil {
+		return nil, err
+	}
+	resp.Warnings = warnings
+	return resp, nil
 }
 
 func (s *backlogService) UpdatePriority(id uuid.UUID, priority constants.Priority, userID uuid.UUID) (*response.BacklogItemResponse, error) {
@@ -357,7 +698,7 @@ func (s *backlogService) UpdatePriority(id uuid.UUID, priority constants.Priorit
 		return nil, err
 	}
 
-	return response.ToBacklogItemResponse(updated), nil
+	return s.itemResponse(updated)
 }
 
 func (s *backlogService) AddLabel(id uuid.UUID, label string, userID uuid.UUID) (*response.BacklogItemResponse, error) {
@@ -375,6 +716,13 @@ func (s *backlogService) AddLabel(id uuid.UUID, label string, userID uuid.UUID)
 		return nil, ErrBacklogItemNotFound
 	}
 
+	// Check the label against the project's catalog
+	resolved, err := newLabelCatalogCache(s.projectRepo, s.labelRepo).resolve(item.ProjectID, []string{label})
+	if err != nil {
+		return nil, err
+	}
+	label = resolved[0]
+
 	// Add label
 	if err := s.backlogRepo.AddLabel(id, label); err != nil {
 		return nil, err
@@ -390,7 +738,7 @@ func (s *backlogService) AddLabel(id uuid.UUID, label string, userID uuid.UUID)
 		return nil, err
 	}
 
-	return response.ToBacklogItemResponse(updated), nil
+	return s.itemResponse(updated)
 }
 
 func (s *backlogService) RemoveLabel(id uuid.UUID, label string, userID uuid.UUID) (*response.BacklogItemResponse, error) {
@@ -418,15 +766,35 @@ func (s *backlogService) RemoveLabel(id uuid.UUID, label string, userID uuid.UUI
 		return nil, err
 	}
 
-	return response.ToBacklogItemResponse(updated), nil
+	return s.itemResponse(updated)
 }
 
-func (s *backlogService) AddComment(id uuid.UUID, content string, userID uuid.UUID) (*response.ItemHistoryResponse, error) {
-	content = strings.TrimSpace(content)
-	if content == "" {
-		return nil, errors.New("comment cannot be empty")
+// GetHistory returns the item's history, newest first. When a page is requested it
+// also returns the cursor of the next page, or "" on the last one.
+func (s *backlogService) GetHistory(id uuid.UUID, params *request.HistoryQueryParams) ([]response.ItemHistoryResponse, string, error) {
+	// Check if item exists
+	item, err := s.backlogRepo.GetByID(id)
+	if err != nil {
+		return nil, "", err
+	}
+	if item == nil {
+		return nil, "", ErrBacklogItemNotFound
+	}
+
+	// Fetch one extra entry to tell whether another page follows
+	limit, fetch := historyLimit(params)
+	histories, err := s.historyRepo.GetByItemID(id, params.Cursor, fetch)
+	if err != nil {
+		return nil, "", err
 	}
+	histories, next := historyPage(histories, limit, func(h models.ItemHistory) (time.Time, uuid.UUID) {
+		return h.Timestamp, h.ID
+	})
+
+	return response.ToItemHistoryListResponse(histories), next, nil
+}
 
+func (s *backlogService) SetAssignees(id uuid.UUID, req *request.SetAssigneesRequest, userID uuid.UUID) (*response.BacklogItemResponse, error) {
 	// Check if item exists
 	item, err := s.backlogRepo.GetByID(id)
 	if err != nil {
@@ -436,23 +804,141 @@ func (s *backlogService) AddComment(id uuid.UUID, content string, userID uuid.UU
 		return nil, ErrBacklogItemNotFound
 	}
 
-	// Create history entry for comment
-	history := &models.ItemHistory{
-		ItemID:  id,
-		UserID:  userID,
-		Action:  constants.ItemActionCommentAdded,
-		Comment: &content,
+	// Validate assignees, ignoring duplicates
+	seen := make(map[uuid.UUID]bool, len(req.UserIDs))
+	assignees := make([]models.ItemAssignee, 0, len(req.UserIDs))
+	for _, assigneeID := range req.UserIDs {
+		if seen[assigneeID] {
+			continue
+		}
+		seen[assigneeID] = true
+
+		user, err := s.userRepo.GetByID(assigneeID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, ErrAssigneeNotFound
+		}
+		assignees = append(assignees, models.ItemAssignee{UserID: assigneeID, AssignedByID: userID})
 	}
 
-	if err := s.historyRepo.Create(history); err != nil {
+	return s.replaceAssignees(item, assignees, userID)
+}
+
+func (s *backlogService) ClearAssignees(id uuid.UUID, userID uuid.UUID) (*response.BacklogItemResponse, error) {
+	// Check if item exists
+	item, err := s.backlogRepo.GetByID(id)
+	if err != nil {
 		return nil, err
 	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
 
-	return response.ToItemHistoryResponse(history), nil
+	return s.replaceAssignees(item, nil, userID)
 }
 
-func (s *backlogService) GetHistory(id uuid.UUID) ([]response.ItemHistoryResponse, error) {
-	// Check if item exists
+// replaceAssignees stores the new assignee list, recording one history entry per
+// user that was assigned or unassigned
+func (s *backlogService) replaceAssignees(item *models.BacklogItem, assignees []models.ItemAssignee, userID uuid.UUID) (*response.BacklogItemResponse, error) {
+	current := make(map[uuid.UUID]bool, len(item.Assignees))
+	for _, a := range item.Assignees {
+		current[a.UserID] = true
+	}
+	next := make(map[uuid.UUID]bool, len(assignees))
+	for _, a := range assignees {
+		next[a.UserID] = true
+	}
+
+	field := "assignee"
+	var histories []models.ItemHistory
+	for _, a := range assignees {
+		if !current[a.UserID] {
+			newVal, _ := json.Marshal(a.UserID)
+			histories = append(histories, models.ItemHistory{
+				ItemID:       item.ID,
+				UserID:       userID,
+				Action:       constants.ItemActionAssigned,
+				FieldChanged: &field,
+				NewValue:     datatypes.JSON(newVal),
+			})
+		}
+	}
+	for _, a := range item.Assignees {
+		if !next[a.UserID] {
+			oldVal, _ := json.Marshal(a.UserID)
+			histories = append(histories, models.ItemHistory{
+				ItemID:       item.ID,
+				UserID:       userID,
+				Action:       constants.ItemActionUnassigned,
+				FieldChanged: &field,
+				OldValue:     datatypes.JSON(oldVal),
+			})
+		}
+	}
+
+	if err := s.assigneeRepo.Replace(item.ID, assignees, histories); err != nil {
+		return nil, err
+	}
+
+	// Fetch updated item
+	updated, err := s.backlogRepo.GetByID(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.itemResponse(updated)
+}
+
+func (s *backlogService) SetParent(id uuid.UUID, req *request.SetParentRequest, userID uuid.UUID) (*response.BacklogItemResponse, error) {
+	// Get current item
+	item, err := s.backlogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	if req.ParentID != nil {
+		if err := s.validateParent(item.ID, item.ProjectID, item.Type, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	oldParent := item.ParentID
+	if (oldParent == nil && req.ParentID == nil) || (oldParent != nil && req.ParentID != nil && *oldParent == *req.ParentID) {
+		return s.itemResponse(item)
+	}
+
+	// Update parent
+	if err := s.backlogRepo.UpdateParent(id, req.ParentID); err != nil {
+		return nil, err
+	}
+
+	// Record history
+	oldVal, _ := json.Marshal(oldParent)
+	newVal, _ := json.Marshal(req.ParentID)
+	field := "parent_id"
+	s.recordHistory(id, userID, constants.ItemActionUpdated, &field, datatypes.JSON(oldVal), datatypes.JSON(newVal), nil)
+
+	// Fetch updated item
+	updated, err := s.backlogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.itemResponse(updated)
+}
+
+func (s *backlogService) SetDueDate(id uuid.UUID, req *request.SetDueDateRequest, userID uuid.UUID) (*response.BacklogItemResponse, error) {
+	dueDate, err := parseDueDate(req.DueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get current item
 	item, err := s.backlogRepo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -461,12 +947,1033 @@ func (s *backlogService) GetHistory(id uuid.UUID) ([]response.ItemHistoryRespons
 		return nil, ErrBacklogItemNotFound
 	}
 
-	histories, err := s.historyRepo.GetByItemID(id)
+	if sameDueDate(item.DueDate, dueDate) {
+		return s.itemResponse(item)
+	}
+
+	// Update due date
+	if err := s.backlogRepo.UpdateDueDate(id, dueDate); err != nil {
+		return nil, err
+	}
+
+	// Record history
+	oldVal, _ := json.Marshal(formatDueDate(item.DueDate))
+	newVal, _ := json.Marshal(formatDueDate(dueDate))
+	field := "due_date"
+	s.recordHistory(id, userID, constants.ItemActionUpdated, &field, datatypes.JSON(oldVal), datatypes.JSON(newVal), nil)
+
+	// Fetch updated item
+	updated, err := s.backlogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.itemResponse(updated)
+}
+
+func (s *backlogService) GetChildren(id uuid.UUID) ([]response.BacklogItemResponse, error) {
+	// Check if item exists
+	item, err := s.backlogRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	children, err := s.backlogRepo.GetChildren(id)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]response.BacklogItemResponse, len(children))
+	refs := make([]*response.BacklogItemResponse, len(children))
+	for i := range children {
+		responses[i] = *response.ToBacklogItemResponse(&children[i])
+		refs[i] = &responses[i]
+	}
+	if err := s.enrich(refs...); err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// Rank places an item directly before or after an anchor item of the same project
+// and records the move in its history.
+func (s *backlogService) Rank(id uuid.UUID, req *request.RankItemRequest, userID uuid.UUID) (*response.BacklogItemResponse, error) {
+	if (req.BeforeID == nil) == (req.AfterID == nil) {
+		return nil, ErrInvalidRankAnchor
+	}
+	anchorID, after := req.BeforeID, false
+	if req.AfterID != nil {
+		anchorID, after = req.AfterID, true
+	}
+	if *anchorID == id {
+		return nil, ErrInvalidRankAnchor
+	}
+
+	// Get current item
+	item, err := s.backlogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	anchor, err := s.backlogRepo.GetByID(*anchorID)
+	if err != nil {
+		return nil, err
+	}
+	if anchor == nil || anchor.ProjectID != item.ProjectID {
+		return nil, ErrRankAnchorNotFound
+	}
+
+	newRank, err := s.backlogRepo.MoveRank(id, anchor.ID, after)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record history
+	oldVal, _ := json.Marshal(item.Rank)
+	newVal, _ := json.Marshal(newRank)
+	field := "rank"
+	s.recordHistory(id, userID, constants.ItemActionUpdated, &field, datatypes.JSON(oldVal), datatypes.JSON(newVal), nil)
+
+	// Fetch updated item
+	updated, err := s.backlogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.itemResponse(updated)
+}
+
+// Clone copies an item, into another project when the request names one. The clone
+// starts in the initial status of its project's workflow, outside any sprint and
+// unassigned; within the same project it keeps the original's parent. Every clone
+// and its original get a history entry referring to each other.
+func (s *backlogService) Clone(id uuid.UUID, req *request.CloneItemRequest, userID uuid.UUID) (*response.BacklogItemResponse, error) {
+	item, err := s.backlogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	projectID := item.ProjectID
+	target := item.Project
+	var targetScale *estimationScale
+	if req.ProjectID != nil && *req.ProjectID != item.ProjectID {
+		project, err := s.projectRepo.GetByID(*req.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if project == nil {
+			return nil, ErrProjectNotFound
+		}
+		projectID = project.ID
+		target = *project
+		targetScale = projectEstimationScale(project)
+	}
+
+	workflow, err := loadWorkflow(s.workflowRepo, projectID)
+	if err != nil {
+		return nil, err
+	}
+	maxPos, err := s.backlogRepo.GetMaxPosition(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := cloneItem(item, projectID, workflow.InitialStatus(), req.IncludeLabels, userID)
+	clone.Position = maxPos + 1
+	if title := strings.TrimSpace(req.Title); title != "" {
+		clone.Title = title
+	}
+	if projectID == item.ProjectID {
+		clone.ParentID = item.ParentID
+	}
+
+	var children, originalChildren []models.BacklogItem
+	if req.IncludeChildren {
+		if originalChildren, err = s.backlogRepo.GetChildren(item.ID); err != nil {
+			return nil, err
+		}
+		for i := range originalChildren {
+			child := cloneItem(&originalChildren[i], projectID, workflow.InitialStatus(), req.IncludeLabels, userID)
+			child.Position = clone.Position + i + 1
+			children = append(children, child)
+		}
+	}
+
+	// Labels carried into another project must fit its catalog, estimates are
+	// mapped onto its scale, and custom field values belong to the source
+	// project's fields
+	if projectID != item.ProjectID {
+		labels := newLabelCatalogCache(s.projectRepo, s.labelRepo)
+		if clone.Labels, err = labels.resolve(projectID, clone.Labels); err != nil {
+			return nil, err
+		}
+		clone.StoryPoints, clone.Estimate = targetScale.remap(clone.StoryPoints, clone.Estimate)
+		clone.CustomFields = datatypes.JSONMap{}
+		for i := range children {
+			if children[i].Labels, err = labels.resolve(projectID, children[i].Labels); err != nil {
+				return nil, err
+			}
+			children[i].StoryPoints, children[i].Estimate = targetScale.remap(children[i].StoryPoints, children[i].Estimate)
+			children[i].CustomFields = datatypes.JSONMap{}
+		}
+	}
+
+	var links []models.ItemLink
+	var linkHistoryEntries []models.ItemHistory
+	if req.IncludeLinks {
+		originals, err := s.linkRepo.GetByItemID(item.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, original := range originals {
+			link := models.ItemLink{
+				ID:          uuid.New(),
+				SourceID:    original.SourceID,
+				TargetID:    original.TargetID,
+				Type:        original.Type,
+				CreatedByID: userID,
+			}
+			if link.SourceID == item.ID {
+				link.SourceID = clone.ID
+			} else {
+				link.TargetID = clone.ID
+			}
+			links = append(links, link)
+			linkHistoryEntries = append(linkHistoryEntries, linkHistories(&link, constants.ItemActionLinkAdded, userID)...)
+		}
+	}
+
+	// The clones' keys are known once they are numbered in the target project
+	histories := func(placed *models.BacklogItem, placedChildren []models.BacklogItem) []models.ItemHistory {
+		numbered := *placed
+		numbered.Project = target
+		histories := cloneHistories(item, &numbered, userID)
+		for i := range placedChildren {
+			child := placedChildren[i]
+			child.Project = target
+			histories = append(histories, cloneHistories(&originalChildren[i], &child, userID)...)
+		}
+		return append(histories, linkHistoryEntries...)
+	}
+	if err := s.backlogRepo.CreateClone(&clone, children, links, histories); err != nil {
+		return nil, err
+	}
+
+	created, err := s.backlogRepo.GetByID(clone.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.itemResponse(created)
+}
+
+// cloneItem copies the content of an item into a new, unsaved item. Estimates are
+// copied with the full original estimate remaining; labels only when asked for.
+// Custom field values are copied as well.
+func cloneItem(item *models.BacklogItem, projectID uuid.UUID, status constants.ItemStatus, withLabels bool, userID uuid.UUID) models.BacklogItem {
+	clone := models.BacklogItem{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		CreatedByID: userID,
+		Title:       item.Title,
+		Type:        item.Type,
+		Priority:    item.Priority,
+		Status:      status,
+		StoryPoints: item.StoryPoints,
+		Estimate:    item.Estimate,
+		DueDate:     item.DueDate,
+
+		OriginalEstimate: item.OriginalEstimate,
+	}
+	if item.Description != nil {
+		desc := *item.Description
+		clone.Description = &desc
+	}
+	if item.OriginalEstimate != nil {
+		remaining := *item.OriginalEstimate
+		clone.RemainingEstimate = &remaining
+	}
+	if withLabels && len(item.Labels) > 0 {
+		clone.Labels = append([]string(nil), item.Labels...)
+	}
+	if len(item.CustomFields) > 0 {
+		clone.CustomFields = maps.Clone(item.CustomFields)
+	}
+	return clone
+}
+
+// cloneHistories records the creation of a clone on the clone and on its original,
+// each referring to the other item
+func cloneHistories(original, clone *models.BacklogItem, userID uuid.UUID) []models.ItemHistory {
+	clonedFrom := "cloned_from"
+	cloneField := "clone"
+	fromVal, _ := json.Marshal(map[string]interface{}{
+		"item_id":    original.ID,
+		"project_id": original.ProjectID,
+		"key":        original.Key(),
+	})
+	toVal, _ := json.Marshal(map[string]interface{}{
+		"item_id":    clone.ID,
+		"project_id": clone.ProjectID,
+		"key":        clone.Key(),
+	})
+	return []models.ItemHistory{
+		{
+			ItemID:       clone.ID,
+			UserID:       userID,
+			Action:       constants.ItemActionCreated,
+			FieldChanged: &clonedFrom,
+			NewValue:     datatypes.JSON(fromVal),
+		},
+		{
+			ItemID:       original.ID,
+			UserID:       userID,
+			Action:       constants.ItemActionCloned,
+			FieldChanged: &cloneField,
+			NewValue:     datatypes.JSON(toVal),
+		},
+	}
+}
+
+// Move moves an item and its descendants to another project. They are numbered and
+// ranked last there like new items and leave their sprint, since sprints belong to
+// one project; the item is detached from a parent left behind. Statuses missing from
+// the target workflow are mapped by category, labels must fit the target's catalog
+// and estimates are mapped onto its estimation scale, and custom field values are
+// dropped since fields belong to one project. Every moved item gets a history entry
+// with its old and new project and key.
+func (s *backlogService) Move(id uuid.UUID, req *request.MoveItemRequest, userID uuid.UUID) (*response.BacklogItemResponse, error) {
+	item, err := s.backlogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+	if req.ProjectID == item.ProjectID {
+		return nil, ErrSameProject
+	}
+
+	project, err := s.projectRepo.GetByID(req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	source, err := loadWorkflow(s.workflowRepo, item.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := loadWorkflow(s.workflowRepo, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	maxPos, err := s.backlogRepo.GetMaxPosition(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the descendants breadth-first so that parents are placed before
+	// their children
+	items := []models.BacklogItem{*item}
+	for i := 0; i < len(items); i++ {
+		children, err := s.backlogRepo.GetChildren(items[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, children...)
+	}
+
+	labels := newLabelCatalogCache(s.projectRepo, s.labelRepo)
+	scale := projectEstimationScale(project)
+	befores := make([]models.BacklogItem, len(items))
+	for i := range items {
+		moved := &items[i]
+		moved.Project = item.Project
+		befores[i] = *moved
+
+		moved.Project = *project
+		moved.ProjectID = project.ID
+		moved.SprintID = nil
+		moved.Status = movedStatus(source, target, moved.Status)
+		moved.Position = maxPos + i + 1
+		if moved.Labels, err = labels.resolve(project.ID, moved.Labels); err != nil {
+			return nil, err
+		}
+		moved.StoryPoints, moved.Estimate = scale.remap(moved.StoryPoints, moved.Estimate)
+		moved.CustomFields = datatypes.JSONMap{}
+		if i == 0 {
+			moved.ParentID = nil
+		}
+	}
+
+	histories := func(placed []models.BacklogItem) []models.ItemHistory {
+		var histories []models.ItemHistory
+		for i := range placed {
+			histories = append(histories, moveHistories(&befores[i], &placed[i], userID)...)
+		}
+		return histories
+	}
+	if err := s.backlogRepo.MoveToProject(items, histories); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.backlogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.itemResponse(updated)
+}
+
+// movedStatus returns the status an item takes in the target workflow: its current
+// status when the target has it, otherwise the first target status of the same
+// category, otherwise the target's initial status
+func movedStatus(source, target *models.Workflow, status constants.ItemStatus) constants.ItemStatus {
+	if target.HasStatus(status) {
+		return status
+	}
+	if category, ok := source.Category(status); ok {
+		if statuses := target.StatusesIn(category); len(statuses) > 0 {
+			return statuses[0]
+		}
+	}
+	return target.InitialStatus()
+}
+
+// moveHistories records a move on the moved item: the project change with the old
+// and new keys, and the sprint, parent, status, labels, estimate and custom field
+// values it lost or changed on the way
+func moveHistories(before, after *models.BacklogItem, userID uuid.UUID) []models.ItemHistory {
+	entry := func(action constants.ItemAction, field string, oldValue, newValue interface{}) models.ItemHistory {
+		oldVal, _ := json.Marshal(oldValue)
+		newVal, _ := json.Marshal(newValue)
+		return models.ItemHistory{
+			ItemID:       after.ID,
+			UserID:       userID,
+			Action:       action,
+			FieldChanged: &field,
+			OldValue:     datatypes.JSON(oldVal),
+			NewValue:     datatypes.JSON(newVal),
+		}
+	}
+
+	histories := []models.ItemHistory{entry(constants.ItemActionMoved, "project",
+		map[string]interface{}{"project_id": before.ProjectID, "key": before.Key()},
+		map[string]interface{}{"project_id": after.ProjectID, "key": after.Key()},
+	)}
+	if before.SprintID != nil {
+		histories = append(histories, entry(constants.ItemActionUpdated, "sprint_id", before.SprintID, after.SprintID))
+	}
+	if before.ParentID != nil && after.ParentID == nil {
+		histories = append(histories, entry(constants.ItemActionUpdated, "parent_id", before.ParentID, after.ParentID))
+	}
+	if before.Status != after.Status {
+		histories = append(histories, entry(constants.ItemActionUpdated, "status", before.Status, after.Status))
+	}
+	if !slices.Equal(before.Labels, after.Labels) {
+		histories = append(histories, entry(constants.ItemActionUpdated, "labels", before.Labels, after.Labels))
+	}
+	if !optional.Equal(before.StoryPoints, after.StoryPoints) {
+		histories = append(histories, entry(constants.ItemActionUpdated, "story_points", before.StoryPoints, after.StoryPoints))
+	}
+	if !optional.Equal(before.Estimate, after.Estimate) {
+		histories = append(histories, entry(constants.ItemActionUpdated, "estimate", before.Estimate, after.Estimate))
+	}
+	keys := make([]string, 0, len(before.CustomFields))
+	for key := range before.CustomFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		histories = append(histories, entry(constants.ItemActionUpdated, key, before.CustomFields[key], nil))
+	}
+	return histories
+}
+
+// Bulk applies one operation to many items. Every item is validated first with the
+// same rules as the single-item endpoints; the changes are applied in one transaction
+// only when all items pass, otherwise ErrBulkValidationFailed is returned along with
+// the per-item results.
+func (s *backlogService) Bulk(req *request.BulkUpdateRequest, userID uuid.UUID) (*response.BulkUpdateResponse, error) {
+	if err := validateBulkRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Drop duplicate IDs, keeping the request order
+	seen := make(map[uuid.UUID]bool, len(req.ItemIDs))
+	ids := make([]uuid.UUID, 0, len(req.ItemIDs))
+	for _, id := range req.ItemIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	result := &response.BulkUpdateResponse{
+		Operation: req.Operation,
+		Results:   make([]response.BulkItemResult, len(ids)),
+	}
+	workflows := newWorkflowCache(s.workflowRepo)
+	wip := newWIPTracker(s.backlogRepo)
+	labels := newLabelCatalogCache(s.projectRepo, s.labelRepo)
+	scales := newEstimationScaleCache(s.projectRepo)
+
+	// The target sprint must exist; each item is then checked against its project
+	var sprint *models.Sprint
+	if req.Operation == constants.BulkOperationSetSprint && req.SprintID != nil {
+		var err error
+		if sprint, err = s.getSprint(*req.SprintID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Items are validated as locked by the repository, so the change applies to
+	// their current state
+	err := s.backlogRepo.ApplyBulk(ids, func(items []models.BacklogItem) (*repository.BulkChanges, error) {
+		byID := make(map[uuid.UUID]*models.BacklogItem, len(items))
+		for i := range items {
+			byID[items[i].ID] = &items[i]
+		}
+
+		changes := &repository.BulkChanges{}
+		for i, id := range ids {
+			res := &result.Results[i]
+			res.ID = id
+
+			item, ok := byID[id]
+			if !ok {
+				res.Error = ErrBacklogItemNotFound.Error()
+				result.Failed++
+				continue
+			}
+			res.Key = item.Key()
+
+			itemHistories, warnings, err := s.bulkChange(item, req, userID, sprint, workflows, wip, labels, scales)
+			if err != nil {
+				res.Error = err.Error()
+				result.Failed++
+				continue
+			}
+			res.Warnings = warnings
+			if len(itemHistories) == 0 {
+				continue
+			}
+
+			res.Changed = true
+			result.Changed++
+			changes.Histories = append(changes.Histories, itemHistories...)
+			if req.Operation == constants.BulkOperationDelete {
+				changes.DeletedIDs = append(changes.DeletedIDs, id)
+			} else {
+				changes.Updates = append(changes.Updates, repository.BulkUpdate{ID: id, Columns: bulkColumns(req.Operation, item)})
+			}
+		}
+
+		if result.Failed > 0 {
+			return nil, ErrBulkValidationFailed
+		}
+		changes.Guards = wip.guards
+		return changes, nil
+	})
+	if errors.Is(err, ErrBulkValidationFailed) {
+		return result, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Applied = true
+
+	return result, nil
+}
+
+// validateBulkRequest checks the operation and its value before any item is loaded
+func validateBulkRequest(req *request.BulkUpdateRequest) error {
+	switch req.Operation {
+	case constants.BulkOperationSetStatus:
+		if req.Status == "" {
+			return ErrInvalidStatus
+		}
+	case constants.BulkOperationSetPriority:
+		if !req.Priority.IsValid() {
+			return ErrInvalidPriority
+		}
+	case constants.BulkOperationAddLabel, constants.BulkOperationRemoveLabel:
+		req.Label = strings.TrimSpace(req.Label)
+		if req.Label == "" {
+			return ErrEmptyLabel
+		}
+	case constants.BulkOperationSetSprint, constants.BulkOperationSetStoryPoints, constants.BulkOperationDelete:
+	default:
+		return ErrInvalidBulkOperation
+	}
+	return nil
+}
+
+// bulkChange validates the operation for one item and applies it to the loaded item.
+// It returns the item's history entries, which are empty when nothing changes.
+func (s *backlogService) bulkChange(item *models.BacklogItem, req *request.BulkUpdateRequest, userID uuid.UUID, sprint *models.Sprint, workflows *workflowCache, wip *wipTracker, labels *labelCatalogCache, scales *estimationScaleCache) ([]models.ItemHistory, []string, error) {
+	history := func(action constants.ItemAction, field string, oldValue, newValue interface{}) models.ItemHistory {
+		h := models.ItemHistory{ItemID: item.ID, UserID: userID, Action: action}
+		if field != "" {
+			h.FieldChanged = &field
+		}
+		if oldValue != nil {
+			oldVal, _ := json.Marshal(oldValue)
+			h.OldValue = datatypes.JSON(oldVal)
+		}
+		if newValue != nil {
+			newVal, _ := json.Marshal(newValue)
+			h.NewValue = datatypes.JSON(newVal)
+		}
+		return h
+	}
+
+	switch req.Operation {
+	case constants.BulkOperationSetStatus:
+		if item.Status == req.Status {
+			return nil, nil, nil
+		}
+		workflow, err := workflows.get(item.ProjectID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := validateTransition(workflow, item.Status, req.Status); err != nil {
+			return nil, nil, err
+		}
+		override, err := wip.check(workflow, item, req.Status, req.OverrideWIPLimit)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnings, err := blockedWarnings(s.linkRepo, workflows, item, req.Status)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		histories := []models.ItemHistory{history(constants.ItemActionStatusChanged, "status", item.Status, req.Status)}
+		if override != nil {
+			histories = append(histories, override.history(item.ID, userID))
+		}
+		item.Status = req.Status
+		return histories, warnings, nil
+
+	case constants.BulkOperationSetPriority:
+		if item.Priority == req.Priority {
+			return nil, nil, nil
+		}
+		h := history(constants.ItemActionPriorityChanged, "priority", item.Priority, req.Priority)
+		item.Priority = req.Priority
+		return []models.ItemHistory{h}, nil, nil
+
+	case constants.BulkOperationSetSprint:
+		if (item.SprintID == nil && req.SprintID == nil) || (item.SprintID != nil && req.SprintID != nil && *item.SprintID == *req.SprintID) {
+			return nil, nil, nil
+		}
+		if sprint != nil && sprint.ProjectID != item.ProjectID {
+			return nil, nil, ErrSprintNotInProject
+		}
+		var h models.ItemHistory
+		if req.SprintID == nil {
+			h = history(constants.ItemActionSprintRemoved, "sprint_id", item.SprintID, nil)
+		} else {
+			h = history(constants.ItemActionSprintAssigned, "sprint_id", item.SprintID, req.SprintID)
+		}
+		item.SprintID = req.SprintID
+		return []models.ItemHistory{h}, nil, nil
+
+	case constants.BulkOperationAddLabel:
+		resolved, err := labels.resolve(item.ProjectID, []string{req.Label})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, label := range item.Labels {
+			if label == resolved[0] {
+				return nil, nil, nil
+			}
+		}
+		item.Labels = append(item.Labels, resolved[0])
+		return []models.ItemHistory{history(constants.ItemActionLabelAdded, "", nil, resolved[0])}, nil, nil
+
+	case constants.BulkOperationRemoveLabel:
+		labels := make([]string, 0, len(item.Labels))
+		for _, label := range item.Labels {
+			if label != req.Label {
+				labels = append(labels, label)
+			}
+		}
+		if len(labels) == len(item.Labels) {
+			return nil, nil, nil
+		}
+		item.Labels = labels
+		return []models.ItemHistory{history(constants.ItemActionLabelRemoved, "", req.Label, nil)}, nil, nil
+
+	case constants.BulkOperationSetStoryPoints:
+		points, estimate, err := scales.resolve(item.ProjectID, req.StoryPoints, req.Estimate)
+		if err != nil {
+			return nil, nil, err
+		}
+		var histories []models.ItemHistory
+		if !optional.Equal(item.StoryPoints, points) {
+			histories = append(histories, history(constants.ItemActionUpdated, "story_points", item.StoryPoints, points))
+		}
+		if !optional.Equal(item.Estimate, estimate) {
+			histories = append(histories, history(constants.ItemActionUpdated, "estimate", item.Estimate, estimate))
+		}
+		item.StoryPoints = points
+		item.Estimate = estimate
+		return histories, nil, nil
+
+	case constants.BulkOperationDelete:
+		return []models.ItemHistory{history(constants.ItemActionDeleted, "", item.Title, nil)}, nil, nil
+	}
+
+	return nil, nil, ErrInvalidBulkOperation
+}
+
+// bulkColumns returns the columns an operation changes on an item, with the values
+// bulkChange gave them
+func bulkColumns(operation constants.BulkOperation, item *models.BacklogItem) map[string]interface{} {
+	switch operation {
+	case constants.BulkOperationSetStatus:
+		return map[string]interface{}{"status": item.Status}
+	case constants.BulkOperationSetPriority:
+		return map[string]interface{}{"priority": item.Priority}
+	case constants.BulkOperationSetSprint:
+		return map[string]interface{}{"sprint_id": item.SprintID}
+	case constants.BulkOperationAddLabel, constants.BulkOperationRemoveLabel:
+		return map[string]interface{}{"labels": item.Labels}
+	case constants.BulkOperationSetStoryPoints:
+		return map[string]interface{}{"story_points": item.StoryPoints, "estimate": item.Estimate}
+	}
+	return nil
+}
+
+// getSprint loads the sprint items are being moved into
+func (s *backlogService) getSprint(sprintID uuid.UUID) (*models.Sprint, error) {
+	sprint, err := s.sprintRepo.GetByID(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint == nil {
+		return nil, ErrSprintNotFound
+	}
+	return sprint, nil
+}
+
+// validateSprint checks that an item of the project may be placed in the sprint
+func (s *backlogService) validateSprint(projectID, sprintID uuid.UUID) error {
+	sprint, err := s.getSprint(sprintID)
+	if err != nil {
+		return err
+	}
+	if sprint.ProjectID != projectID {
+		return ErrSprintNotInProject
+	}
+	return nil
+}
+
+// validateParent checks that parentID may hold an item of the given type. itemID is
+// uuid.Nil for items that do not exist yet and therefore cannot be part of a cycle.
+func (s *backlogService) validateParent(itemID, projectID uuid.UUID, itemType constants.ItemType, parentID uuid.UUID) error {
+	if parentID == itemID {
+		return ErrParentCycle
+	}
+
+	parent, err := s.backlogRepo.GetByID(parentID)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return ErrParentNotFound
+	}
+	if parent.ProjectID != projectID {
+		return ErrParentNotInProject
+	}
+	if !parent.Type.CanParent(itemType) {
+		return ErrInvalidParentType
+	}
+
+	if itemID != uuid.Nil {
+		ancestors, err := s.backlogRepo.GetAncestorIDs(parentID)
+		if err != nil {
+			return err
+		}
+		for _, ancestorID := range ancestors {
+			if ancestorID == itemID {
+				return ErrParentCycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateTypeInHierarchy checks that changing the item's type keeps it valid under
+// its parent and above its children
+func (s *backlogService) validateTypeInHierarchy(item *models.BacklogItem, itemType constants.ItemType) error {
+	if item.Parent != nil && item.Parent.ID != uuid.Nil && !item.Parent.Type.CanParent(itemType) {
+		return ErrInvalidParentType
+	}
+
+	children, err := s.backlogRepo.GetChildren(item.ID)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if !itemType.CanParent(child.Type) {
+			return ErrInvalidParentType
+		}
+	}
+
+	return nil
+}
+
+// itemResponse converts the item to a response that includes the computed fields
+func (s *backlogService) itemResponse(item *models.BacklogItem) (*response.BacklogItemResponse, error) {
+	resp := response.ToBacklogItemResponse(item)
+	if err := s.enrich(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// enrich adds the computed fields to item responses: epic rollups and blocked flags
+func (s *backlogService) enrich(items ...*response.BacklogItemResponse) error {
+	workflows := newWorkflowCache(s.workflowRepo)
+	if err := attachRollups(s.backlogRepo, workflows, items); err != nil {
+		return err
+	}
+	return markBlocked(s.linkRepo, workflows, items)
+}
+
+// attachHighlights adds the highlighted search snippets to the matching items
+func (s *backlogService) attachHighlights(items []*response.BacklogItemResponse, search string) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	highlights, err := s.backlogRepo.GetSearchHighlights(ids, search)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uuid.UUID]*response.SearchHighlights, len(highlights))
+	for _, h := range highlights {
+		snippet := &response.SearchHighlights{Title: h.Title, Description: h.Description}
+		if h.Comment != nil {
+			snippet.Comment = *h.Comment
+		}
+		byID[h.ItemID] = snippet
+	}
+
+	for _, item := range items {
+		item.Highlights = byID[item.ID]
+	}
+	return nil
+}
+
+// attachRollups fills in the child rollup of every epic in the list. Children count
+// as done when their status is in the done category of the project workflow.
+func attachRollups(backlogRepo repository.BacklogRepository, workflows *workflowCache, items []*response.BacklogItemResponse) error {
+	projects := make(map[uuid.UUID]uuid.UUID)
+	var epicIDs []uuid.UUID
+	for _, item := range items {
+		if item.Type == constants.ItemTypeEpic {
+			projects[item.ID] = item.ProjectID
+			epicIDs = append(epicIDs, item.ID)
+		}
+	}
+	if len(epicIDs) == 0 {
+		return nil
+	}
+
+	stats, err := backlogRepo.GetChildStats(epicIDs)
+	if err != nil {
+		return err
+	}
+
+	rollups := make(map[uuid.UUID]*response.EpicRollupResponse, len(epicIDs))
+	for _, stat := range stats {
+		workflow, err := workflows.get(projects[stat.ParentID])
+		if err != nil {
+			return err
+		}
+
+		rollup, ok := rollups[stat.ParentID]
+		if !ok {
+			rollup = &response.EpicRollupResponse{}
+			rollups[stat.ParentID] = rollup
+		}
+		rollup.ChildCount += stat.Count
+		rollup.TotalPoints += stat.StoryPoints
+		if category, _ := workflow.Category(stat.Status); category == constants.StatusCategoryDone {
+			rollup.DoneCount += stat.Count
+			rollup.CompletedPoints += stat.StoryPoints
+		}
+	}
+
+	for _, item := range items {
+		if item.Type != constants.ItemTypeEpic {
+			continue
+		}
+		rollup, ok := rollups[item.ID]
+		if !ok {
+			rollup = &response.EpicRollupResponse{}
+		}
+
+		// Progress is weighted by story points, falling back to item counts
+		// when no child is estimated
+		switch {
+		case rollup.TotalPoints > 0:
+			rollup.Progress = math.Round(float64(rollup.CompletedPoints)*1000/float64(rollup.TotalPoints)) / 10
+		case rollup.ChildCount > 0:
+			rollup.Progress = math.Round(float64(rollup.DoneCount)*1000/float64(rollup.ChildCount)) / 10
+		}
+		item.Rollup = rollup
+	}
+
+	return nil
+}
+
+// parseItemKey splits an item key such as PROJ-123 into its project key and number
+func parseItemKey(key string) (string, int, bool) {
+	i := strings.LastIndex(key, "-")
+	if i <= 0 {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(key[i+1:])
+	if err != nil || number < 1 {
+		return "", 0, false
+	}
+	return strings.ToUpper(key[:i]), number, true
+}
+
+// compileQuery parses and compiles a query-language expression. userID resolves "me".
+func compileQuery(q string, userID uuid.UUID) (*repository.BacklogQuery, error) {
+	parsed, err := query.Parse(q)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+	}
+	compiled, err := repository.CompileBacklogQuery(parsed, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+	}
+	return compiled, nil
+}
+
+// buildBacklogFilters converts backlog query params into repository filters.
+// userID resolves the "me" assignee filter. The q param is combined with the other
+// filters and fails with ErrInvalidQuery when it cannot be compiled. The sort param
+// overrides the query's order and fails with ErrInvalidSort when it is invalid.
+func buildBacklogFilters(params *request.BacklogQueryParams, userID uuid.UUID) (repository.BacklogFilters, error) {
+	filters := repository.BacklogFilters{
+		Search: strings.TrimSpace(params.Search),
+	}
+
+	order, err := parseSort(params.Sort, repository.ValidateBacklogSort)
+	if err != nil {
+		return filters, err
+	}
+	filters.Sort = order
+
+	// Parse query language expression
+	if strings.TrimSpace(params.Q) != "" {
+		compiled, err := compileQuery(params.Q, userID)
+		if err != nil {
+			return filters, err
+		}
+		filters.Query = compiled
+	}
+
+	// Parse type filter
+	for _, t := range params.Type {
+		itemType := constants.ItemType(t)
+		if itemType.IsValid() {
+			filters.Type = append(filters.Type, itemType)
+		}
+	}
+
+	// Parse priority filter
+	for _, p := range params.Priority {
+		priority := constants.Priority(p)
+		if priority.IsValid() {
+			filters.Priority = append(filters.Priority, priority)
+		}
+	}
+
+	// Parse status filter; statuses are defined per project workflow
+	for _, s := range params.Status {
+		if status := strings.TrimSpace(s); status != "" {
+			filters.Status = append(filters.Status, constants.ItemStatus(status))
+		}
+	}
+
+	// Parse sprint filter
+	if params.SprintID != "" {
+		if params.SprintID == "none" {
+			nilID := uuid.Nil
+			filters.SprintID = &nilID
+		} else if sprintID, err := uuid.Parse(params.SprintID); err == nil {
+			filters.SprintID = &sprintID
+		}
+	}
+
+	// Parse parent filter
+	if params.ParentID != "" {
+		if params.ParentID == "none" {
+			nilID := uuid.Nil
+			filters.ParentID = &nilID
+		} else if parentID, err := uuid.Parse(params.ParentID); err == nil {
+			filters.ParentID = &parentID
+		}
+	}
+
+	// Parse labels filter
+	filters.Labels = params.Labels
+
+	// Parse due date filters; their format is validated on binding
+	if dueBefore, err := parseDueDate(&params.DueBefore); err == nil {
+		filters.DueBefore = dueBefore
+	}
+	if dueAfter, err := parseDueDate(&params.DueAfter); err == nil {
+		filters.DueAfter = dueAfter
+	}
+	if params.Overdue {
+		day := today()
+		filters.OverdueOn = &day
+	}
+
+	// Parse assignee filter
+	for _, a := range params.Assignee {
+		switch a = strings.TrimSpace(a); a {
+		case "me":
+			filters.AssigneeIDs = append(filters.AssigneeIDs, userID)
+		case "unassigned":
+			filters.Unassigned = true
+		default:
+			if assigneeID, err := uuid.Parse(a); err == nil {
+				filters.AssigneeIDs = append(filters.AssigneeIDs, assigneeID)
+			}
+		}
+	}
 
-	return response.ToItemHistoryListResponse(histories), nil
+	return filters, nil
 }
 
 // recordHistory is a helper function to record item history
@@ -0,0 +1,1448 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/datatypes"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+func TestBacklogService_SetParent(t *testing.T) {
+	projectID := uuid.New()
+
+	t.Run("should attach a story to an epic", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		story := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeStory}
+		epic := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeEpic}
+
+		mockBacklogRepo.On("GetByID", story.ID).Return(story, nil)
+		mockBacklogRepo.On("GetByID", epic.ID).Return(epic, nil)
+		mockBacklogRepo.On("GetAncestorIDs", epic.ID).Return([]uuid.UUID{}, nil)
+		mockBacklogRepo.On("UpdateParent", story.ID, &epic.ID).Return(nil)
+		mockHistoryRepo.On("Create", mock.AnythingOfType("*models.ItemHistory")).Return(nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{story.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.SetParent(story.ID, &request.SetParentRequest{ParentID: &epic.ID}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockBacklogRepo.AssertExpectations(t)
+		mockHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a parent of the wrong type", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		epic := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeEpic}
+		story := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeStory}
+
+		mockBacklogRepo.On("GetByID", epic.ID).Return(epic, nil)
+		mockBacklogRepo.On("GetByID", story.ID).Return(story, nil)
+
+		result, err := service.SetParent(epic.ID, &request.SetParentRequest{ParentID: &story.ID}, uuid.New())
+
+		assert.Equal(t, ErrInvalidParentType, err)
+		assert.Nil(t, result)
+		mockBacklogRepo.AssertNotCalled(t, "UpdateParent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a parent from another project", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		story := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeStory}
+		epic := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Type: constants.ItemTypeEpic}
+
+		mockBacklogRepo.On("GetByID", story.ID).Return(story, nil)
+		mockBacklogRepo.On("GetByID", epic.ID).Return(epic, nil)
+
+		_, err := service.SetParent(story.ID, &request.SetParentRequest{ParentID: &epic.ID}, uuid.New())
+
+		assert.Equal(t, ErrParentNotInProject, err)
+	})
+
+	t.Run("should reject a parent that descends from the item", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		story := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeStory}
+		task := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeTask}
+		subtask := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeSubtask}
+
+		// The task already descends from the subtask, so the link would close a loop
+		mockBacklogRepo.On("GetByID", subtask.ID).Return(subtask, nil)
+		mockBacklogRepo.On("GetByID", task.ID).Return(task, nil)
+		mockBacklogRepo.On("GetAncestorIDs", task.ID).Return([]uuid.UUID{story.ID, subtask.ID}, nil)
+
+		_, err := service.SetParent(subtask.ID, &request.SetParentRequest{ParentID: &task.ID}, uuid.New())
+
+		assert.Equal(t, ErrParentCycle, err)
+	})
+
+	t.Run("should return not found for missing parent", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		story := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeStory}
+		parentID := uuid.New()
+
+		mockBacklogRepo.On("GetByID", story.ID).Return(story, nil)
+		mockBacklogRepo.On("GetByID", parentID).Return(nil, nil)
+
+		_, err := service.SetParent(story.ID, &request.SetParentRequest{ParentID: &parentID}, uuid.New())
+
+		assert.Equal(t, ErrParentNotFound, err)
+	})
+}
+
+func TestBacklogService_GetByID_EpicRollup(t *testing.T) {
+	t.Run("should roll up children by workflow category", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, mockWorkflowRepo, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		epic := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Type: constants.ItemTypeEpic}
+
+		mockBacklogRepo.On("GetByID", epic.ID).Return(epic, nil)
+		mockBacklogRepo.On("GetChildStats", []uuid.UUID{epic.ID}).Return([]repository.ChildStats{
+			{ParentID: epic.ID, Status: constants.ItemStatusDone, Count: 2, StoryPoints: 5},
+			{ParentID: epic.ID, Status: constants.ItemStatusArchived, Count: 1, StoryPoints: 3},
+			{ParentID: epic.ID, Status: constants.ItemStatusInProgress, Count: 1, StoryPoints: 8},
+		}, nil)
+		mockWorkflowRepo.On("GetByProjectID", epic.ProjectID).Return(nil, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{epic.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.GetByID(epic.ID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result.Rollup)
+		assert.Equal(t, int64(4), result.Rollup.ChildCount)
+		assert.Equal(t, int64(2), result.Rollup.DoneCount)
+		assert.Equal(t, int64(16), result.Rollup.TotalPoints)
+		assert.Equal(t, int64(5), result.Rollup.CompletedPoints)
+		assert.Equal(t, 31.3, result.Rollup.Progress)
+	})
+
+	t.Run("should not roll up non-epic items", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		story := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Type: constants.ItemTypeStory}
+		mockBacklogRepo.On("GetByID", story.ID).Return(story, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{story.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.GetByID(story.ID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.Rollup)
+		mockBacklogRepo.AssertNotCalled(t, "GetChildStats", mock.Anything)
+	})
+}
+
+func TestBacklogService_GetByKey(t *testing.T) {
+	t.Run("should look up an item by project key and number", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{
+			ID:      uuid.New(),
+			Number:  123,
+			Type:    constants.ItemTypeBug,
+			Project: models.Project{ID: uuid.New(), Key: "PROJ"},
+		}
+		mockBacklogRepo.On("GetByKey", "PROJ", 123).Return(item, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.GetByKey("proj-123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "PROJ-123", result.Key)
+	})
+
+	t.Run("should return not found for malformed keys", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		for _, key := range []string{"PROJ", "PROJ-", "-12", "PROJ-0", "PROJ-x1"} {
+			_, err := service.GetByKey(key)
+			assert.Equal(t, ErrBacklogItemNotFound, err, key)
+		}
+		mockBacklogRepo.AssertNotCalled(t, "GetByKey", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBacklogService_Rank(t *testing.T) {
+	projectID := uuid.New()
+
+	t.Run("should place the item after the anchor", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeTask, Rank: "a"}
+		anchor := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeTask, Rank: "c"}
+		ranked := *item
+		ranked.Rank = "ci"
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil).Once()
+		mockBacklogRepo.On("GetByID", anchor.ID).Return(anchor, nil)
+		mockBacklogRepo.On("MoveRank", item.ID, anchor.ID, true).Return("ci", nil)
+		mockBacklogRepo.On("GetByID", item.ID).Return(&ranked, nil).Once()
+		mockHistoryRepo.On("Create", mock.AnythingOfType("*models.ItemHistory")).Return(nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.Rank(item.ID, &request.RankItemRequest{AfterID: &anchor.ID}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ci", result.Rank)
+		mockBacklogRepo.AssertExpectations(t)
+		mockHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should require exactly one anchor", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		id, before, after := uuid.New(), uuid.New(), uuid.New()
+
+		_, err := service.Rank(id, &request.RankItemRequest{}, uuid.New())
+		assert.Equal(t, ErrInvalidRankAnchor, err)
+
+		_, err = service.Rank(id, &request.RankItemRequest{BeforeID: &before, AfterID: &after}, uuid.New())
+		assert.Equal(t, ErrInvalidRankAnchor, err)
+
+		_, err = service.Rank(id, &request.RankItemRequest{BeforeID: &id}, uuid.New())
+		assert.Equal(t, ErrInvalidRankAnchor, err)
+
+		mockBacklogRepo.AssertNotCalled(t, "MoveRank", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject an anchor from another project", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID}
+		anchor := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New()}
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockBacklogRepo.On("GetByID", anchor.ID).Return(anchor, nil)
+
+		_, err := service.Rank(item.ID, &request.RankItemRequest{BeforeID: &anchor.ID}, uuid.New())
+
+		assert.Equal(t, ErrRankAnchorNotFound, err)
+		mockBacklogRepo.AssertNotCalled(t, "MoveRank", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestBacklogService_Bulk(t *testing.T) {
+	projectID := uuid.New()
+
+	t.Run("should apply a priority change in one call", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		high := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Priority: constants.PriorityHigh}
+		low := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Priority: constants.PriorityLow}
+		ids := []uuid.UUID{high.ID, low.ID}
+
+		mockBacklogRepo.On("GetByIDs", ids).Return([]models.BacklogItem{*high, *low}, nil)
+		mockBacklogRepo.On("ApplyBulk", ids, mock.MatchedBy(func(changes *repository.BulkChanges) bool {
+			return len(changes.Updates) == 1 && changes.Updates[0].ID == low.ID &&
+				assert.ObjectsAreEqual(map[string]interface{}{"priority": constants.PriorityHigh}, changes.Updates[0].Columns) &&
+				changes.DeletedIDs == nil &&
+				len(changes.Histories) == 1 && changes.Histories[0].ItemID == low.ID &&
+				changes.Histories[0].Action == constants.ItemActionPriorityChanged
+		})).Return(nil)
+
+		result, err := service.Bulk(&request.BulkUpdateRequest{
+			ItemIDs:   []uuid.UUID{high.ID, low.ID, high.ID},
+			Operation: constants.BulkOperationSetPriority,
+			Priority:  constants.PriorityHigh,
+		}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.True(t, result.Applied)
+		assert.Equal(t, 1, result.Changed)
+		assert.Len(t, result.Results, 2)
+		assert.False(t, result.Results[0].Changed)
+		assert.True(t, result.Results[1].Changed)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should apply nothing when an item fails validation", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		mockLabelRepo := new(MockLabelRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, mockProjectRepo, mockLabelRepo, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID}
+		missingID := uuid.New()
+		ids := []uuid.UUID{item.ID, missingID}
+
+		mockBacklogRepo.On("GetByIDs", ids).Return([]models.BacklogItem{*item}, nil)
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockLabelRepo.On("GetByProjectID", projectID).Return([]models.Label{}, nil)
+
+		result, err := service.Bulk(&request.BulkUpdateRequest{
+			ItemIDs:   ids,
+			Operation: constants.BulkOperationAddLabel,
+			Label:     " frontend ",
+		}, uuid.New())
+
+		assert.Equal(t, ErrBulkValidationFailed, err)
+		assert.False(t, result.Applied)
+		assert.Equal(t, 1, result.Failed)
+		assert.Empty(t, result.Results[0].Error)
+		assert.Equal(t, ErrBacklogItemNotFound.Error(), result.Results[1].Error)
+		mockBacklogRepo.AssertNotCalled(t, "ApplyBulk", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should count earlier items of the batch towards WIP limits", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, mockWorkflowRepo, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		limit := 2
+		workflow := &models.Workflow{
+			ProjectID: projectID,
+			Statuses: []models.WorkflowStatus{
+				{Name: "Open", Category: constants.StatusCategoryTodo},
+				{Name: "Doing", Category: constants.StatusCategoryInProgress, WIPLimit: &limit},
+			},
+		}
+		first := models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: "Open"}
+		second := models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: "Open"}
+		ids := []uuid.UUID{first.ID, second.ID}
+
+		mockBacklogRepo.On("GetByIDs", ids).Return([]models.BacklogItem{first, second}, nil)
+		mockBacklogRepo.On("CountByStatus", projectID, &uuid.Nil).
+			Return(map[constants.ItemStatus]int64{"Doing": 1}, nil).Once()
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(workflow, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{first.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.Bulk(&request.BulkUpdateRequest{
+			ItemIDs:   ids,
+			Operation: constants.BulkOperationSetStatus,
+			Status:    "Doing",
+		}, uuid.New())
+
+		assert.Equal(t, ErrBulkValidationFailed, err)
+		assert.Empty(t, result.Results[0].Error)
+		assert.Equal(t, ErrWIPLimitExceeded.Error(), result.Results[1].Error)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject items outside the sprint's project", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockSprintRepo := new(MockSprintRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockSprintRepo)
+
+		sprint := &models.Sprint{ID: uuid.New(), ProjectID: projectID}
+		inProject := models.BacklogItem{ID: uuid.New(), ProjectID: projectID}
+		elsewhere := models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New()}
+		ids := []uuid.UUID{inProject.ID, elsewhere.ID}
+
+		mockSprintRepo.On("GetByID", sprint.ID).Return(sprint, nil)
+		mockBacklogRepo.On("GetByIDs", ids).Return([]models.BacklogItem{inProject, elsewhere}, nil)
+
+		result, err := service.Bulk(&request.BulkUpdateRequest{
+			ItemIDs:   ids,
+			Operation: constants.BulkOperationSetSprint,
+			SprintID:  &sprint.ID,
+		}, uuid.New())
+
+		assert.Equal(t, ErrBulkValidationFailed, err)
+		assert.Empty(t, result.Results[0].Error)
+		assert.Equal(t, ErrSprintNotInProject.Error(), result.Results[1].Error)
+		mockBacklogRepo.AssertNotCalled(t, "ApplyBulk", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a missing sprint before loading items", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockSprintRepo := new(MockSprintRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockSprintRepo)
+
+		sprintID := uuid.New()
+		mockSprintRepo.On("GetByID", sprintID).Return(nil, nil)
+
+		result, err := service.Bulk(&request.BulkUpdateRequest{
+			ItemIDs:   []uuid.UUID{uuid.New()},
+			Operation: constants.BulkOperationSetSprint,
+			SprintID:  &sprintID,
+		}, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrSprintNotFound, err)
+		mockBacklogRepo.AssertNotCalled(t, "GetByIDs", mock.Anything)
+	})
+
+	t.Run("should reject an operation without its value", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		_, err := service.Bulk(&request.BulkUpdateRequest{
+			ItemIDs:   []uuid.UUID{uuid.New()},
+			Operation: constants.BulkOperationAddLabel,
+			Label:     "  ",
+		}, uuid.New())
+		assert.Equal(t, ErrEmptyLabel, err)
+
+		_, err = service.Bulk(&request.BulkUpdateRequest{
+			ItemIDs:   []uuid.UUID{uuid.New()},
+			Operation: "archive",
+		}, uuid.New())
+		assert.Equal(t, ErrInvalidBulkOperation, err)
+
+		mockBacklogRepo.AssertNotCalled(t, "GetByIDs", mock.Anything)
+	})
+}
+
+func TestBacklogService_GetAll_Search(t *testing.T) {
+	t.Run("should sort by relevance and attach highlights", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		item := models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Type: constants.ItemTypeBug, Title: "Login fails"}
+		comment := "still <mark>failing</mark> on staging"
+
+		mockBacklogRepo.On("GetAll", mock.MatchedBy(func(filters repository.BacklogFilters) bool {
+			return filters.Search == "login failure" && len(filters.Sort) == 1 && filters.Sort[0].Field == "relevance"
+		})).Return([]models.BacklogItem{item}, repository.PageInfo{}, nil)
+		mockBacklogRepo.On("GetSearchHighlights", []uuid.UUID{item.ID}, "login failure").Return([]repository.SearchHighlight{
+			{ItemID: item.ID, Title: "<mark>Login</mark> <mark>fails</mark>", Comment: &comment},
+		}, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.GetAll(&request.BacklogQueryParams{Search: " login failure ", Sort: "relevance"}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+		assert.Equal(t, "<mark>Login</mark> <mark>fails</mark>", result.Items[0].Highlights.Title)
+		assert.Equal(t, comment, result.Items[0].Highlights.Comment)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not load highlights without a search", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		item := models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Type: constants.ItemTypeTask}
+		mockBacklogRepo.On("GetAll", mock.Anything).Return([]models.BacklogItem{item}, repository.PageInfo{}, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.GetAll(&request.BacklogQueryParams{}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.Items[0].Highlights)
+		mockBacklogRepo.AssertNotCalled(t, "GetSearchHighlights", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBacklogService_GetAll_Query(t *testing.T) {
+	t.Run("should compile the query into filters", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		userID := uuid.New()
+		mockBacklogRepo.On("GetAll", mock.MatchedBy(func(filters repository.BacklogFilters) bool {
+			return filters.Query != nil && filters.Query.Where != "" && len(filters.Query.Args) > 0
+		})).Return([]models.BacklogItem{}, repository.PageInfo{}, nil)
+		mockLinkRepo.On("GetBlockers", mock.Anything).Return([]models.ItemLink{}, nil)
+
+		_, err := service.GetAll(&request.BacklogQueryParams{
+			Q: "assignee = me AND priority in (High, Critical) ORDER BY updated DESC",
+		}, userID)
+
+		assert.NoError(t, err)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should filter by custom fields", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		mockBacklogRepo.On("GetAll", mock.MatchedBy(func(filters repository.BacklogFilters) bool {
+			return filters.Query != nil && len(filters.Query.Args) == 7 &&
+				filters.Query.Args[0] == "environment" && filters.Query.Args[3] == "prod" &&
+				filters.Query.Args[6] == float64(3)
+		})).Return([]models.BacklogItem{}, repository.PageInfo{}, nil)
+		mockLinkRepo.On("GetBlockers", mock.Anything).Return([]models.ItemLink{}, nil)
+
+		_, err := service.GetAll(&request.BacklogQueryParams{Q: "cf.environment = prod AND cf.effort > 3"}, uuid.New())
+
+		assert.NoError(t, err)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject an invalid query", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		result, err := service.GetAll(&request.BacklogQueryParams{Q: "points >= lots"}, uuid.New())
+
+		assert.True(t, errors.Is(err, ErrInvalidQuery))
+		assert.Nil(t, result)
+		mockBacklogRepo.AssertNotCalled(t, "GetAll", mock.Anything)
+	})
+}
+
+func TestBacklogService_GetAll_Sort(t *testing.T) {
+	t.Run("should pass the parsed sort to the repository", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		mockBacklogRepo.On("GetAll", mock.MatchedBy(func(filters repository.BacklogFilters) bool {
+			return len(filters.Sort) == 2 &&
+				filters.Sort[0].Field == "priority" && filters.Sort[0].Desc &&
+				filters.Sort[1].Field == "updated_at" && !filters.Sort[1].Desc
+		})).Return([]models.BacklogItem{}, repository.PageInfo{}, nil)
+		mockLinkRepo.On("GetBlockers", mock.Anything).Return([]models.ItemLink{}, nil)
+
+		_, err := service.GetAll(&request.BacklogQueryParams{Sort: "priority:desc,updated_at"}, uuid.New())
+
+		assert.NoError(t, err)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject unknown fields and directions", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		for _, sort := range []string{"description", "priority:up", "title,title"} {
+			result, err := service.GetAll(&request.BacklogQueryParams{Sort: sort}, uuid.New())
+
+			assert.True(t, errors.Is(err, ErrInvalidSort), sort)
+			assert.Nil(t, result)
+		}
+		mockBacklogRepo.AssertNotCalled(t, "GetAll", mock.Anything)
+	})
+}
+
+func TestBacklogService_GetAll_Pagination(t *testing.T) {
+	t.Run("should count numbered pages by default", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		total := int64(25)
+		mockBacklogRepo.On("GetAll", mock.MatchedBy(func(filters repository.BacklogFilters) bool {
+			return filters.CountTotal && filters.Page == 2 && filters.Cursor == ""
+		})).Return([]models.BacklogItem{}, repository.PageInfo{Total: &total, NextCursor: "next"}, nil)
+		mockLinkRepo.On("GetBlockers", mock.Anything).Return([]models.ItemLink{}, nil)
+
+		result, err := service.GetAll(&request.BacklogQueryParams{Page: 2, Limit: 10}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(25), *result.Total)
+		assert.Equal(t, 3, *result.TotalPages)
+		assert.Equal(t, 2, result.Page)
+		assert.Equal(t, "next", result.NextCursor)
+		assert.True(t, result.HasMore)
+	})
+
+	t.Run("should skip the count when paging by cursor", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		mockBacklogRepo.On("GetAll", mock.MatchedBy(func(filters repository.BacklogFilters) bool {
+			return !filters.CountTotal && filters.Cursor == "abc"
+		})).Return([]models.BacklogItem{}, repository.PageInfo{}, nil)
+		mockLinkRepo.On("GetBlockers", mock.Anything).Return([]models.ItemLink{}, nil)
+
+		result, err := service.GetAll(&request.BacklogQueryParams{Cursor: "abc"}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.Total)
+		assert.Nil(t, result.TotalPages)
+		assert.Zero(t, result.Page)
+		assert.False(t, result.HasMore)
+	})
+}
+
+type MockItemAssigneeRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemAssigneeRepository) GetByItemID(itemID uuid.UUID) ([]models.ItemAssignee, error) {
+	args := m.Called(itemID)
+	return args.Get(0).([]models.ItemAssignee), args.Error(1)
+}
+
+func (m *MockItemAssigneeRepository) Replace(itemID uuid.UUID, assignees []models.ItemAssignee, histories []models.ItemHistory) error {
+	args := m.Called(itemID, assignees, histories)
+	return args.Error(0)
+}
+
+func TestBacklogService_SetAssignees(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("should assign each user once and record the changes", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockAssigneeRepo := new(MockItemAssigneeRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, mockAssigneeRepo, mockUserRepo, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		kept, added, removed := uuid.New(), uuid.New(), uuid.New()
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Assignees: []models.ItemAssignee{{UserID: kept}, {UserID: removed}}}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockUserRepo.On("GetByID", kept).Return(&models.User{ID: kept}, nil).Once()
+		mockUserRepo.On("GetByID", added).Return(&models.User{ID: added}, nil).Once()
+		mockAssigneeRepo.On("Replace", item.ID, []models.ItemAssignee{
+			{UserID: kept, AssignedByID: userID},
+			{UserID: added, AssignedByID: userID},
+		}, mock.MatchedBy(func(histories []models.ItemHistory) bool {
+			return len(histories) == 2 &&
+				histories[0].Action == constants.ItemActionAssigned && string(histories[0].NewValue) == `"`+added.String()+`"` &&
+				histories[1].Action == constants.ItemActionUnassigned && string(histories[1].OldValue) == `"`+removed.String()+`"`
+		})).Return(nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.SetAssignees(item.ID, &request.SetAssigneesRequest{UserIDs: []uuid.UUID{kept, added, kept, added}}, userID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockUserRepo.AssertExpectations(t)
+		mockAssigneeRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject an unknown user", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockAssigneeRepo := new(MockItemAssigneeRepository)
+		mockUserRepo := new(MockUserRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, mockAssigneeRepo, mockUserRepo, nil, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID}
+		unknown := uuid.New()
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockUserRepo.On("GetByID", unknown).Return(nil, nil)
+
+		result, err := service.SetAssignees(item.ID, &request.SetAssigneesRequest{UserIDs: []uuid.UUID{unknown}}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrAssigneeNotFound, err)
+		mockAssigneeRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return not found for a missing item", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		id := uuid.New()
+		mockBacklogRepo.On("GetByID", id).Return(nil, nil)
+
+		result, err := service.SetAssignees(id, &request.SetAssigneesRequest{UserIDs: []uuid.UUID{uuid.New()}}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrBacklogItemNotFound, err)
+	})
+}
+
+func TestBacklogService_ClearAssignees(t *testing.T) {
+	t.Run("should unassign everyone and record each removal", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockAssigneeRepo := new(MockItemAssigneeRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, mockAssigneeRepo, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Assignees: []models.ItemAssignee{{UserID: uuid.New()}, {UserID: uuid.New()}}}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockAssigneeRepo.On("Replace", item.ID, []models.ItemAssignee(nil), mock.MatchedBy(func(histories []models.ItemHistory) bool {
+			return len(histories) == 2 &&
+				histories[0].Action == constants.ItemActionUnassigned && histories[1].Action == constants.ItemActionUnassigned
+		})).Return(nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.ClearAssignees(item.ID, uuid.New())
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockAssigneeRepo.AssertExpectations(t)
+	})
+
+	t.Run("should record nothing for an unassigned item", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockAssigneeRepo := new(MockItemAssigneeRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, mockAssigneeRepo, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New()}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockAssigneeRepo.On("Replace", item.ID, []models.ItemAssignee(nil), []models.ItemHistory(nil)).Return(nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		_, err := service.ClearAssignees(item.ID, uuid.New())
+
+		assert.NoError(t, err)
+		mockAssigneeRepo.AssertExpectations(t)
+	})
+}
+
+func TestBacklogService_GetAll_Assignee(t *testing.T) {
+	t.Run("should filter by users, the current user and unassigned items", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		userID, assigneeID := uuid.New(), uuid.New()
+		mockBacklogRepo.On("GetAll", mock.MatchedBy(func(filters repository.BacklogFilters) bool {
+			return assert.ObjectsAreEqual([]uuid.UUID{userID, assigneeID}, filters.AssigneeIDs) && filters.Unassigned
+		})).Return([]models.BacklogItem{}, repository.PageInfo{}, nil)
+		mockLinkRepo.On("GetBlockers", mock.Anything).Return([]models.ItemLink{}, nil)
+
+		_, err := service.GetAll(&request.BacklogQueryParams{
+			Assignee: []string{"me", assigneeID.String(), " unassigned ", "nobody"},
+		}, userID)
+
+		assert.NoError(t, err)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+}
+
+func TestBacklogService_GetHistory(t *testing.T) {
+	t.Run("should return the whole history without a page", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New()}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockHistoryRepo.On("GetByItemID", item.ID, "", 0).Return([]models.ItemHistory{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+
+		history, next, err := service.GetHistory(item.ID, &request.HistoryQueryParams{})
+
+		assert.NoError(t, err)
+		assert.Len(t, history, 2)
+		assert.Empty(t, next)
+	})
+
+	t.Run("should return a page and the cursor after its last entry", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New()}
+		now := time.Now()
+		histories := []models.ItemHistory{
+			{ID: uuid.New(), Timestamp: now},
+			{ID: uuid.New(), Timestamp: now.Add(-time.Minute)},
+			{ID: uuid.New(), Timestamp: now.Add(-2 * time.Minute)},
+		}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockHistoryRepo.On("GetByItemID", item.ID, "", 3).Return(histories, nil)
+
+		history, next, err := service.GetHistory(item.ID, &request.HistoryQueryParams{Limit: 2})
+
+		assert.NoError(t, err)
+		assert.Len(t, history, 2)
+		assert.Equal(t, repository.HistoryCursor(histories[1].Timestamp, histories[1].ID), next)
+	})
+}
+
+func TestBacklogService_Create_Template(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+	description := "## Acceptance criteria"
+	templatePoints := 5
+
+	newTemplate := func() *models.ItemTemplate {
+		return &models.ItemTemplate{
+			ID:          uuid.New(),
+			ProjectID:   projectID,
+			Name:        "Story",
+			Type:        constants.ItemTypeStory,
+			Priority:    constants.PriorityMedium,
+			Description: &description,
+			StoryPoints: &templatePoints,
+			Labels:      []string{"feature"},
+			Children: []models.TemplateChild{
+				{Title: "Write tests", Type: constants.ItemTypeSubtask},
+				{Title: "Update docs", Type: constants.ItemTypeSubtask, Priority: constants.PriorityLow},
+			},
+		}
+	}
+
+	t.Run("should fill empty fields and create child items", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockTemplateRepo := new(MockItemTemplateRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		mockLabelRepo := new(MockLabelRepository)
+		mockFieldRepo := new(MockCustomFieldRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, mockWorkflowRepo, nil, nil, mockLinkRepo, mockTemplateRepo, mockProjectRepo, mockLabelRepo, mockFieldRepo, nil)
+
+		template := newTemplate()
+		points := 8
+		itemID := uuid.New()
+
+		mockTemplateRepo.On("GetByID", template.ID).Return(template, nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(nil, nil)
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockLabelRepo.On("GetByProjectID", projectID).Return([]models.Label{}, nil)
+		mockFieldRepo.On("GetByProjectID", projectID).Return([]models.CustomField{}, nil)
+		mockBacklogRepo.On("GetMaxPosition", projectID).Return(0, nil)
+		mockBacklogRepo.On("CreateWithChildren",
+			mock.MatchedBy(func(item *models.BacklogItem) bool {
+				return item.Type == constants.ItemTypeStory &&
+					item.Priority == constants.PriorityHigh &&
+					*item.Description == description &&
+					*item.StoryPoints == points &&
+					len(item.Labels) == 1 && item.Labels[0] == "feature"
+			}),
+			mock.MatchedBy(func(children []models.BacklogItem) bool {
+				return len(children) == 2 &&
+					children[0].Title == "Write tests" && children[0].Priority == constants.PriorityHigh &&
+					children[1].Priority == constants.PriorityLow &&
+					children[0].Status == constants.ItemStatusNew
+			}),
+		).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.BacklogItem).ID = itemID
+		}).Return(nil)
+		mockHistoryRepo.On("Create", mock.AnythingOfType("*models.ItemHistory")).Return(nil)
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID, ProjectID: projectID, Type: constants.ItemTypeStory}, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{itemID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.Create(&request.CreateBacklogItemRequest{
+			ProjectID:   projectID,
+			TemplateID:  &template.ID,
+			Title:       "Checkout flow",
+			Priority:    constants.PriorityHigh,
+			StoryPoints: &points,
+		}, userID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockBacklogRepo.AssertExpectations(t)
+		mockHistoryRepo.AssertNumberOfCalls(t, "Create", 3)
+	})
+
+	t.Run("should reject a template from another project", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockTemplateRepo := new(MockItemTemplateRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, mockTemplateRepo, nil, nil, nil, nil)
+
+		template := newTemplate()
+		template.ProjectID = uuid.New()
+		mockTemplateRepo.On("GetByID", template.ID).Return(template, nil)
+
+		result, err := service.Create(&request.CreateBacklogItemRequest{
+			ProjectID:  projectID,
+			TemplateID: &template.ID,
+			Title:      "Checkout flow",
+		}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrTemplateNotInProject, err)
+		mockBacklogRepo.AssertNotCalled(t, "CreateWithChildren", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject template children the overriding type cannot hold", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockTemplateRepo := new(MockItemTemplateRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, mockWorkflowRepo, nil, nil, nil, mockTemplateRepo, nil, nil, nil, nil)
+
+		template := newTemplate()
+		mockTemplateRepo.On("GetByID", template.ID).Return(template, nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(nil, nil)
+		mockBacklogRepo.On("GetMaxPosition", projectID).Return(0, nil)
+
+		result, err := service.Create(&request.CreateBacklogItemRequest{
+			ProjectID:  projectID,
+			TemplateID: &template.ID,
+			Title:      "Checkout flow",
+			Type:       constants.ItemTypeEpic,
+		}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrInvalidParentType, err)
+		mockBacklogRepo.AssertNotCalled(t, "CreateWithChildren", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBacklogService_Update_CustomFields(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+	fields := []models.CustomField{
+		{ID: uuid.New(), ProjectID: projectID, Key: "customer", Type: constants.CustomFieldText},
+		{ID: uuid.New(), ProjectID: projectID, Key: "environment", Type: constants.CustomFieldSelect, Options: []string{"staging", "prod"}},
+	}
+
+	t.Run("should record each changed field under its key", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockFieldRepo := new(MockCustomFieldRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, nil, nil, nil, mockLinkRepo, nil, nil, nil, mockFieldRepo, nil)
+
+		item := &models.BacklogItem{
+			ID:           uuid.New(),
+			ProjectID:    projectID,
+			Type:         constants.ItemTypeBug,
+			CustomFields: datatypes.JSONMap{"customer": "Acme", "environment": "staging"},
+		}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockFieldRepo.On("GetByProjectID", projectID).Return(fields, nil)
+		mockBacklogRepo.On("Update", mock.MatchedBy(func(i *models.BacklogItem) bool {
+			_, hasCustomer := i.CustomFields["customer"]
+			return !hasCustomer && i.CustomFields["environment"] == "prod"
+		}), []string{"custom_fields"}).Return(nil)
+		mockHistoryRepo.On("Create", mock.MatchedBy(func(h *models.ItemHistory) bool {
+			return *h.FieldChanged == "environment" &&
+				string(h.OldValue) == `"staging"` && string(h.NewValue) == `"prod"`
+		})).Return(nil).Once()
+		mockHistoryRepo.On("Create", mock.MatchedBy(func(h *models.ItemHistory) bool {
+			return *h.FieldChanged == "customer" && string(h.NewValue) == "null"
+		})).Return(nil).Once()
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.Update(item.ID, &request.UpdateBacklogItemRequest{
+			CustomFields: map[string]interface{}{"customer": nil, "environment": "Prod"},
+		}, userID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockBacklogRepo.AssertExpectations(t)
+		mockHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a value outside the options", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockFieldRepo := new(MockCustomFieldRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, mockFieldRepo, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockFieldRepo.On("GetByProjectID", projectID).Return(fields, nil)
+
+		result, err := service.Update(item.ID, &request.UpdateBacklogItemRequest{
+			CustomFields: map[string]interface{}{"environment": "qa"},
+		}, userID)
+
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrInvalidCustomFieldValue))
+		mockBacklogRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBacklogService_Update_Estimate(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("should store the points of a T-shirt size", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, nil, nil, nil, mockLinkRepo, nil, mockProjectRepo, nil, nil, nil)
+
+		points := 3
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Type: constants.ItemTypeStory, StoryPoints: &points}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID, EstimationScale: constants.EstimationScaleTShirt}, nil)
+		mockBacklogRepo.On("Update", mock.MatchedBy(func(i *models.BacklogItem) bool {
+			return *i.StoryPoints == 8 && *i.Estimate == "XL"
+		}), []string{"story_points", "estimate"}).Return(nil)
+		mockHistoryRepo.On("Create", mock.MatchedBy(func(h *models.ItemHistory) bool {
+			return *h.FieldChanged == "story_points" && string(h.OldValue) == "3" && string(h.NewValue) == "8"
+		})).Return(nil).Once()
+		mockHistoryRepo.On("Create", mock.MatchedBy(func(h *models.ItemHistory) bool {
+			return *h.FieldChanged == "estimate" && string(h.NewValue) == `"XL"`
+		})).Return(nil).Once()
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		estimate := "xl"
+		result, err := service.Update(item.ID, &request.UpdateBacklogItemRequest{Estimate: &estimate}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 8, *result.StoryPoints)
+		assert.Equal(t, "XL", *result.Estimate)
+		mockBacklogRepo.AssertExpectations(t)
+		mockHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject points off the Fibonacci scale", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, mockProjectRepo, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID, EstimationScale: constants.EstimationScaleFibonacci}, nil)
+
+		points := 4
+		result, err := service.Update(item.ID, &request.UpdateBacklogItemRequest{StoryPoints: &points}, userID)
+
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrInvalidStoryPoints))
+		assert.Contains(t, err.Error(), "0, 1, 2, 3, 5, 8")
+		mockBacklogRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBacklogService_Update_RemainingEstimate(t *testing.T) {
+	t.Run("should write the remaining estimate when it is set", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		remaining := 90
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Title: "Export"}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockBacklogRepo.On("Update", item, []string{"remaining_estimate"}).Return(nil)
+		mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		_, err := service.Update(item.ID, &request.UpdateBacklogItemRequest{RemainingEstimate: &remaining}, uuid.New())
+
+		assert.NoError(t, err)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should write only the changed columns", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Title: "Export"}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockBacklogRepo.On("Update", item, []string{"title"}).Return(nil)
+		mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		_, err := service.Update(item.ID, &request.UpdateBacklogItemRequest{Title: "Export to CSV"}, uuid.New())
+
+		assert.NoError(t, err)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+}
+
+func TestBacklogService_Update_WIPLimit(t *testing.T) {
+	projectID := uuid.New()
+	limit := 2
+	workflow := &models.Workflow{
+		ProjectID: projectID,
+		Statuses: []models.WorkflowStatus{
+			{Name: "Open", Category: constants.StatusCategoryTodo},
+			{Name: "Doing", Category: constants.StatusCategoryInProgress, WIPLimit: &limit},
+		},
+	}
+
+	t.Run("should check the limit in the sprint the item moves to", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockSprintRepo := new(MockSprintRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, mockWorkflowRepo, nil, nil, nil, nil, nil, nil, nil, mockSprintRepo)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: "Open"}
+		sprintID := uuid.New()
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockSprintRepo.On("GetByID", sprintID).Return(&models.Sprint{ID: sprintID, ProjectID: projectID}, nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(workflow, nil)
+		mockBacklogRepo.On("CountByStatus", projectID, &sprintID).
+			Return(map[constants.ItemStatus]int64{"Doing": 2}, nil)
+
+		result, err := service.Update(item.ID, &request.UpdateBacklogItemRequest{Status: "Doing", SprintID: &sprintID}, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrWIPLimitExceeded, err)
+		mockBacklogRepo.AssertExpectations(t)
+		mockBacklogRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should count items without a sprint like the board does", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, mockWorkflowRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: "Open"}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(workflow, nil)
+		mockBacklogRepo.On("CountByStatus", projectID, &uuid.Nil).
+			Return(map[constants.ItemStatus]int64{"Doing": 2}, nil)
+
+		result, err := service.UpdateStatus(item.ID, &request.UpdateStatusRequest{Status: "Doing"}, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrWIPLimitExceeded, err)
+		mockBacklogRepo.AssertExpectations(t)
+		mockBacklogRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBacklogService_SetDueDate(t *testing.T) {
+	t.Run("should set the due date and record history", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Type: constants.ItemTypeTask}
+		dueDate := time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockBacklogRepo.On("UpdateDueDate", item.ID, &dueDate).Return(nil)
+		mockHistoryRepo.On("Create", mock.MatchedBy(func(h *models.ItemHistory) bool {
+			return *h.FieldChanged == "due_date" && string(h.OldValue) == "null" && string(h.NewValue) == `"2026-11-30"`
+		})).Return(nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		value := "2026-11-30"
+		result, err := service.SetDueDate(item.ID, &request.SetDueDateRequest{DueDate: &value}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockBacklogRepo.AssertExpectations(t)
+		mockHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should clear the due date with null", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockHistoryRepo := new(MockItemHistoryRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, mockHistoryRepo, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		dueDate := time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), DueDate: &dueDate}
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockBacklogRepo.On("UpdateDueDate", item.ID, (*time.Time)(nil)).Return(nil)
+		mockHistoryRepo.On("Create", mock.MatchedBy(func(h *models.ItemHistory) bool {
+			return string(h.OldValue) == `"2026-11-30"` && string(h.NewValue) == "null"
+		})).Return(nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		_, err := service.SetDueDate(item.ID, &request.SetDueDateRequest{}, uuid.New())
+
+		assert.NoError(t, err)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not record unchanged due dates", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		dueDate := time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), DueDate: &dueDate}
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		value := "2026-11-30"
+		_, err := service.SetDueDate(item.ID, &request.SetDueDateRequest{DueDate: &value}, uuid.New())
+
+		assert.NoError(t, err)
+		mockBacklogRepo.AssertNotCalled(t, "UpdateDueDate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject malformed dates", func(t *testing.T) {
+		service := NewBacklogService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		value := "30/11/2026"
+		result, err := service.SetDueDate(uuid.New(), &request.SetDueDateRequest{DueDate: &value}, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrInvalidDueDate, err)
+	})
+}
+
+func TestBacklogService_GetAll_DueDate(t *testing.T) {
+	t.Run("should pass due date filters to the repository", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		mockBacklogRepo.On("GetAll", mock.MatchedBy(func(filters repository.BacklogFilters) bool {
+			return filters.DueBefore.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)) &&
+				filters.DueAfter.Equal(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)) &&
+				filters.OverdueOn.Equal(today())
+		})).Return([]models.BacklogItem{}, repository.PageInfo{}, nil)
+		mockLinkRepo.On("GetBlockers", mock.Anything).Return([]models.ItemLink{}, nil)
+
+		_, err := service.GetAll(&request.BacklogQueryParams{
+			DueBefore: "2026-12-01",
+			DueAfter:  "2026-11-01",
+			Overdue:   true,
+		}, uuid.New())
+
+		assert.NoError(t, err)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+}
+
+func TestBacklogService_Clone(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+	estimate := 240
+	description := "Steps to reproduce"
+
+	newItem := func() *models.BacklogItem {
+		spent := 90
+		return &models.BacklogItem{
+			ID:                uuid.New(),
+			ProjectID:         projectID,
+			Number:            12,
+			Title:             "Crash on login",
+			Description:       &description,
+			Type:              constants.ItemTypeBug,
+			Priority:          constants.PriorityHigh,
+			Status:            constants.ItemStatusInProgress,
+			Labels:            []string{"auth"},
+			SprintID:          func() *uuid.UUID { id := uuid.New(); return &id }(),
+			OriginalEstimate:  &estimate,
+			RemainingEstimate: &spent,
+			TimeSpent:         150,
+			Project:           models.Project{ID: projectID, Key: "APP"},
+		}
+	}
+
+	t.Run("should copy the item with its children and links", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, mockWorkflowRepo, nil, nil, mockLinkRepo, nil, nil, nil, nil, nil)
+
+		item := newItem()
+		child := models.BacklogItem{ID: uuid.New(), ProjectID: projectID, ParentID: &item.ID, Title: "Add regression test", Type: constants.ItemTypeSubtask, Status: constants.ItemStatusDone}
+		blocker := uuid.New()
+		var cloneID uuid.UUID
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(nil, nil)
+		mockBacklogRepo.On("GetMaxPosition", projectID).Return(7, nil)
+		mockBacklogRepo.On("GetChildren", item.ID).Return([]models.BacklogItem{child}, nil)
+		mockLinkRepo.On("GetByItemID", item.ID).Return([]models.ItemLink{
+			{ID: uuid.New(), SourceID: blocker, TargetID: item.ID, Type: constants.LinkTypeBlocks},
+		}, nil)
+		mockBacklogRepo.On("CreateClone",
+			mock.MatchedBy(func(clone *models.BacklogItem) bool {
+				cloneID = clone.ID
+				return clone.ID != item.ID && clone.Title == item.Title &&
+					clone.Status == constants.ItemStatusNew && clone.SprintID == nil &&
+					*clone.RemainingEstimate == estimate && clone.TimeSpent == 0 &&
+					len(clone.Labels) == 1 && clone.Position == 8
+			}),
+			mock.MatchedBy(func(children []models.BacklogItem) bool {
+				return len(children) == 1 && children[0].Title == "Add regression test" &&
+					children[0].Status == constants.ItemStatusNew
+			}),
+			mock.MatchedBy(func(links []models.ItemLink) bool {
+				return len(links) == 1 && links[0].SourceID == blocker && links[0].TargetID == cloneID
+			}),
+			mock.MatchedBy(func(histories []models.ItemHistory) bool {
+				// Two per cloned item and two per copied link
+				return len(histories) == 6 &&
+					histories[0].ItemID == cloneID && *histories[0].FieldChanged == "cloned_from" &&
+					strings.Contains(string(histories[0].NewValue), `"key":"APP-12"`) &&
+					histories[1].ItemID == item.ID && histories[1].Action == constants.ItemActionCloned &&
+					strings.Contains(string(histories[1].NewValue), `"key":"APP-1"`) &&
+					strings.Contains(string(histories[3].NewValue), `"key":"APP-2"`)
+			}),
+		).Return(nil)
+		mockBacklogRepo.On("GetByID", mock.MatchedBy(func(id uuid.UUID) bool { return id != item.ID })).
+			Return(&models.BacklogItem{ProjectID: projectID, Type: constants.ItemTypeBug}, nil)
+		mockLinkRepo.On("GetBlockers", mock.Anything).Return([]models.ItemLink{}, nil)
+
+		result, err := service.Clone(item.ID, &request.CloneItemRequest{
+			IncludeLabels:   true,
+			IncludeChildren: true,
+			IncludeLinks:    true,
+		}, userID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should clone into another project without parent or labels", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, mockWorkflowRepo, nil, nil, mockLinkRepo, nil, mockProjectRepo, nil, nil, nil)
+
+		item := newItem()
+		parentID := uuid.New()
+		item.ParentID = &parentID
+		targetID := uuid.New()
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockProjectRepo.On("GetByID", targetID).Return(&models.Project{ID: targetID, Key: "OPS"}, nil)
+		mockWorkflowRepo.On("GetByProjectID", targetID).Return(nil, nil)
+		mockBacklogRepo.On("GetMaxPosition", targetID).Return(0, nil)
+		mockBacklogRepo.On("CreateClone",
+			mock.MatchedBy(func(clone *models.BacklogItem) bool {
+				return clone.ProjectID == targetID && clone.ParentID == nil &&
+					len(clone.Labels) == 0 && clone.Title == "Crash on login (ops)"
+			}),
+			[]models.BacklogItem(nil), []models.ItemLink(nil),
+			mock.MatchedBy(func(histories []models.ItemHistory) bool {
+				return len(histories) == 2 && strings.Contains(string(histories[1].NewValue), `"key":"OPS-1"`)
+			}),
+		).Return(nil)
+		mockBacklogRepo.On("GetByID", mock.MatchedBy(func(id uuid.UUID) bool { return id != item.ID })).
+			Return(&models.BacklogItem{ProjectID: targetID, Type: constants.ItemTypeBug}, nil)
+		mockLinkRepo.On("GetBlockers", mock.Anything).Return([]models.ItemLink{}, nil)
+
+		result, err := service.Clone(item.ID, &request.CloneItemRequest{ProjectID: &targetID, Title: " Crash on login (ops) "}, userID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when target project not found", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, mockProjectRepo, nil, nil, nil)
+
+		item := newItem()
+		targetID := uuid.New()
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockProjectRepo.On("GetByID", targetID).Return(nil, nil)
+
+		result, err := service.Clone(item.ID, &request.CloneItemRequest{ProjectID: &targetID}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrProjectNotFound, err)
+	})
+}
+
+func TestBacklogService_Move(t *testing.T) {
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("should move the item and its descendants", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, mockWorkflowRepo, nil, nil, mockLinkRepo, nil, mockProjectRepo, nil, nil, nil)
+
+		sprintID := uuid.New()
+		parentID := uuid.New()
+		item := &models.BacklogItem{
+			ID:        uuid.New(),
+			ProjectID: sourceID,
+			ParentID:  &parentID,
+			SprintID:  &sprintID,
+			Number:    4,
+			Type:      constants.ItemTypeStory,
+			Status:    constants.ItemStatusInProgress,
+			Project:   models.Project{ID: sourceID, Key: "APP"},
+		}
+		child := models.BacklogItem{ID: uuid.New(), ProjectID: sourceID, ParentID: &item.ID, SprintID: &sprintID, Number: 5, Type: constants.ItemTypeSubtask, Status: constants.ItemStatusNew}
+		target := &models.Workflow{ProjectID: targetID, Statuses: []models.WorkflowStatus{
+			{Name: "Backlog", Category: constants.StatusCategoryTodo},
+			{Name: "Doing", Category: constants.StatusCategoryInProgress},
+			{Name: "Closed", Category: constants.StatusCategoryDone},
+		}}
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil).Once()
+		mockProjectRepo.On("GetByID", targetID).Return(&models.Project{ID: targetID, Key: "OPS"}, nil)
+		mockWorkflowRepo.On("GetByProjectID", sourceID).Return(nil, nil)
+		mockWorkflowRepo.On("GetByProjectID", targetID).Return(target, nil)
+		mockBacklogRepo.On("GetMaxPosition", targetID).Return(10, nil)
+		mockBacklogRepo.On("GetChildren", item.ID).Return([]models.BacklogItem{child}, nil)
+		mockBacklogRepo.On("GetChildren", child.ID).Return([]models.BacklogItem{}, nil)
+		mockBacklogRepo.On("MoveToProject",
+			mock.MatchedBy(func(items []models.BacklogItem) bool {
+				return len(items) == 2 &&
+					items[0].ProjectID == targetID && items[0].SprintID == nil && items[0].ParentID == nil &&
+					items[0].Status == "Doing" && items[0].Position == 11 &&
+					items[1].ProjectID == targetID && *items[1].ParentID == item.ID &&
+					items[1].Status == "Backlog" && items[1].Position == 12
+			}),
+			mock.MatchedBy(func(histories []models.ItemHistory) bool {
+				moves := 0
+				for _, h := range histories {
+					if h.Action == constants.ItemActionMoved {
+						moves++
+					}
+				}
+				// The item loses its sprint, parent and status; the child its sprint and status
+				return len(histories) == 7 && moves == 2 &&
+					string(histories[0].OldValue) == `{"key":"APP-4","project_id":"`+sourceID.String()+`"}` &&
+					string(histories[0].NewValue) == `{"key":"OPS-1","project_id":"`+targetID.String()+`"}`
+			}),
+		).Return(nil)
+		mockBacklogRepo.On("GetByID", item.ID).Return(&models.BacklogItem{ID: item.ID, ProjectID: targetID, Type: constants.ItemTypeStory}, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.Move(item.ID, &request.MoveItemRequest{ProjectID: targetID}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, targetID, result.ProjectID)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject labels outside a strict target catalog", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		mockLabelRepo := new(MockLabelRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, mockWorkflowRepo, nil, nil, nil, nil, mockProjectRepo, mockLabelRepo, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: sourceID, Status: constants.ItemStatusNew, Labels: []string{"Backend", "legacy"}}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockProjectRepo.On("GetByID", targetID).Return(&models.Project{ID: targetID, Key: "OPS", StrictLabels: true}, nil)
+		mockLabelRepo.On("GetByProjectID", targetID).Return([]models.Label{{Name: "backend"}}, nil)
+		mockWorkflowRepo.On("GetByProjectID", mock.Anything).Return(nil, nil)
+		mockBacklogRepo.On("GetMaxPosition", targetID).Return(0, nil)
+		mockBacklogRepo.On("GetChildren", item.ID).Return([]models.BacklogItem{}, nil)
+
+		result, err := service.Move(item.ID, &request.MoveItemRequest{ProjectID: targetID}, userID)
+
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrLabelNotInCatalog))
+		mockBacklogRepo.AssertNotCalled(t, "MoveToProject", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject moving to the same project", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBacklogService(mockBacklogRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: sourceID}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+
+		result, err := service.Move(item.ID, &request.MoveItemRequest{ProjectID: sourceID}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrSameProject, err)
+		mockBacklogRepo.AssertNotCalled(t, "MoveToProject", mock.Anything, mock.Anything)
+	})
+}
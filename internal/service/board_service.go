@@ -0,0 +1,278 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+var (
+	ErrInvalidProjectID   = errors.New("project ID must be a valid UUID")
+	ErrNoActiveSprint     = errors.New("project has no active sprint")
+	ErrSprintNotInProject = errors.New("sprint does not belong to this project")
+)
+
+type BoardService interface {
+	GetBoard(params *request.BoardQueryParams, userID uuid.UUID) (*response.BoardResponse, error)
+	MoveItem(id uuid.UUID, req *request.MoveBoardItemRequest, userID uuid.UUID) (*response.BoardColumnResponse, error)
+}
+
+type boardService struct {
+	projectRepo  repository.ProjectRepository
+	backlogRepo  repository.BacklogRepository
+	sprintRepo   repository.SprintRepository
+	workflowRepo repository.WorkflowRepository
+	linkRepo     repository.ItemLinkRepository
+}
+
+func NewBoardService(
+	projectRepo repository.ProjectRepository,
+	backlogRepo repository.BacklogRepository,
+	sprintRepo repository.SprintRepository,
+	workflowRepo repository.WorkflowRepository,
+	linkRepo repository.ItemLinkRepository,
+) BoardService {
+	return &boardService{
+		projectRepo:  projectRepo,
+		backlogRepo:  backlogRepo,
+		sprintRepo:   sprintRepo,
+		workflowRepo: workflowRepo,
+		linkRepo:     linkRepo,
+	}
+}
+
+func (s *boardService) GetBoard(params *request.BoardQueryParams, userID uuid.UUID) (*response.BoardResponse, error) {
+	projectID, err := uuid.Parse(params.ProjectID)
+	if err != nil {
+		return nil, ErrInvalidProjectID
+	}
+
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	// Resolve the sprint scope before parsing the remaining filters
+	sprint, err := s.resolveSprint(projectID, params.SprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := buildBacklogFilters(&params.BacklogQueryParams, userID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint != nil {
+		filters.SprintID = &sprint.ID
+	}
+
+	// Load every matching card; the board is not paginated
+	items, _, err := s.backlogRepo.GetByProjectID(projectID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// One column per workflow status, optionally narrowed by the status filter
+	workflows := newWorkflowCache(s.workflowRepo)
+	workflow, err := workflows.get(projectID)
+	if err != nil {
+		return nil, err
+	}
+	statuses := workflow.Statuses
+	if len(filters.Status) > 0 {
+		statuses = make([]models.WorkflowStatus, 0, len(filters.Status))
+		for _, status := range filters.Status {
+			category, _ := workflow.Category(status)
+			statuses = append(statuses, models.WorkflowStatus{Name: status, Category: category, WIPLimit: workflow.WIPLimit(status)})
+		}
+	}
+
+	// Column load ignores the card filters so it can be compared to the WIP limit
+	var scope *uuid.UUID
+	if sprint != nil {
+		scope = &sprint.ID
+	} else if params.SprintID == "none" {
+		scope = filters.SprintID
+	}
+	counts, err := s.backlogRepo.CountByStatus(projectID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	board := response.ToBoardResponse(project, sprint, statuses, counts, items)
+	if scope == nil {
+		// Limits apply per sprint, so no single limit fits a column spanning the project
+		for i := range board.Columns {
+			board.Columns[i].WIPLimit = nil
+		}
+	}
+	if err := markBlocked(s.linkRepo, workflows, boardCards(board.Columns)); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+func (s *boardService) MoveItem(id uuid.UUID, req *request.MoveBoardItemRequest, userID uuid.UUID) (*response.BoardColumnResponse, error) {
+	item, err := s.backlogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	workflows := newWorkflowCache(s.workflowRepo)
+	workflow, err := workflows.get(item.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTransition(workflow, item.Status, req.Status); err != nil {
+		return nil, err
+	}
+
+	column := repository.BoardColumn{
+		ProjectID: item.ProjectID,
+		Status:    req.Status,
+	}
+
+	// Scope the column to the board the card was moved on
+	sprint, err := s.resolveSprint(item.ProjectID, req.SprintID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint != nil {
+		if item.SprintID == nil || *item.SprintID != sprint.ID {
+			return nil, ErrItemNotInSprint
+		}
+		column.SprintID = &sprint.ID
+	} else if req.SprintID == "none" {
+		if item.SprintID != nil {
+			return nil, ErrItemNotInSprint
+		}
+		nilID := uuid.Nil
+		column.SprintID = &nilID
+	}
+
+	// Limits are checked in the item's own scope, whichever board it was moved on
+	override, guards, err := checkWIPLimit(s.backlogRepo, workflow, item, req.Status, itemWIPScope(item.SprintID), req.OverrideWIPLimit)
+	if err != nil {
+		return nil, err
+	}
+	warnings, err := blockedWarnings(s.linkRepo, workflows, item, req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record a status change only when the card changes columns
+	var histories []models.ItemHistory
+	if item.Status != req.Status {
+		oldVal, _ := json.Marshal(item.Status)
+		newVal, _ := json.Marshal(req.Status)
+		field := "status"
+		histories = append(histories, models.ItemHistory{
+			ItemID:       id,
+			UserID:       userID,
+			Action:       constants.ItemActionStatusChanged,
+			FieldChanged: &field,
+			OldValue:     datatypes.JSON(oldVal),
+			NewValue:     datatypes.JSON(newVal),
+		})
+	}
+	if override != nil {
+		histories = append(histories, override.history(id, userID))
+	}
+
+	if err := s.backlogRepo.MoveToColumn(id, column, req.Position, histories, guards...); err != nil {
+		return nil, err
+	}
+
+	// Return the affected column in its new order
+	items, _, err := s.backlogRepo.GetByProjectID(item.ProjectID, repository.BacklogFilters{
+		Status:   []constants.ItemStatus{req.Status},
+		SprintID: column.SprintID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.backlogRepo.CountByStatus(item.ProjectID, column.SprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := response.ToBoardColumnResponse(req.Status, items)
+	resp.Category, _ = workflow.Category(req.Status)
+	if column.SprintID != nil {
+		resp.WIPLimit = workflow.WIPLimit(req.Status)
+	}
+	resp.Load = counts[req.Status]
+	resp.Warnings = warnings
+	cards := make([]*response.BacklogItemResponse, len(resp.Items))
+	for i := range resp.Items {
+		cards[i] = &resp.Items[i]
+	}
+	if err := markBlocked(s.linkRepo, workflows, cards); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// boardCards returns pointers to every card on the given columns
+func boardCards(columns []response.BoardColumnResponse) []*response.BacklogItemResponse {
+	var cards []*response.BacklogItemResponse
+	for i := range columns {
+		for j := range columns[i].Items {
+			cards = append(cards, &columns[i].Items[j])
+		}
+	}
+	return cards
+}
+
+// resolveSprint maps the sprint_id board parameter to a sprint of the project.
+// It returns nil when the board is not scoped to a single sprint.
+func (s *boardService) resolveSprint(projectID uuid.UUID, sprintParam string) (*models.Sprint, error) {
+	switch sprintParam {
+	case "", "none":
+		return nil, nil
+	case "active":
+		sprint, err := s.sprintRepo.GetActive(projectID)
+		if err != nil {
+			return nil, err
+		}
+		if sprint == nil {
+			return nil, ErrNoActiveSprint
+		}
+		return sprint, nil
+	}
+
+	sprintID, err := uuid.Parse(sprintParam)
+	if err != nil {
+		return nil, ErrSprintNotFound
+	}
+
+	sprint, err := s.sprintRepo.GetByID(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint == nil {
+		return nil, ErrSprintNotFound
+	}
+	if sprint.ProjectID != projectID {
+		return nil, ErrSprintNotInProject
+	}
+
+	return sprint, nil
+}
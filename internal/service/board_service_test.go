@@ -0,0 +1,256 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+func TestBoardService_GetBoard(t *testing.T) {
+	projectID := uuid.New()
+	project := &models.Project{ID: projectID, Name: "Operations", Key: "OPS"}
+
+	t.Run("should group the cards into one column per workflow status", func(t *testing.T) {
+		mockProjectRepo := new(MockProjectRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBoardService(mockProjectRepo, mockBacklogRepo, nil, mockWorkflowRepo, mockLinkRepo)
+
+		three, five := 3, 5
+		items := []models.BacklogItem{
+			{ID: uuid.New(), ProjectID: projectID, Status: constants.ItemStatusNew, StoryPoints: &three},
+			{ID: uuid.New(), ProjectID: projectID, Status: constants.ItemStatusDone, StoryPoints: &five},
+			{ID: uuid.New(), ProjectID: projectID, Status: constants.ItemStatusNew},
+		}
+		mockProjectRepo.On("GetByID", projectID).Return(project, nil)
+		mockBacklogRepo.On("GetByProjectID", projectID, mock.MatchedBy(func(f repository.BacklogFilters) bool {
+			return f.SprintID == nil
+		})).Return(items, int64(3), nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(nil, nil)
+		mockBacklogRepo.On("CountByStatus", projectID, (*uuid.UUID)(nil)).
+			Return(map[constants.ItemStatus]int64{constants.ItemStatusNew: 4, constants.ItemStatusDone: 1}, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{items[0].ID, items[2].ID, items[1].ID}).Return([]models.ItemLink{}, nil)
+
+		result, err := service.GetBoard(&request.BoardQueryParams{ProjectID: projectID.String()}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "OPS", result.Project.Key)
+		assert.Nil(t, result.Sprint)
+		assert.Len(t, result.Columns, 5)
+		assert.Equal(t, constants.ItemStatusNew, result.Columns[0].Status)
+		assert.Equal(t, 2, result.Columns[0].Count)
+		assert.Equal(t, int64(4), result.Columns[0].Load)
+		assert.Equal(t, constants.StatusCategoryTodo, result.Columns[0].Category)
+		assert.Equal(t, 1, result.Columns[3].Count)
+		assert.Equal(t, 3, result.TotalItems)
+		assert.Equal(t, 8, result.TotalPoints)
+		mockBacklogRepo.AssertExpectations(t)
+		mockLinkRepo.AssertExpectations(t)
+	})
+
+	t.Run("should scope the board and the column loads to the active sprint", func(t *testing.T) {
+		mockProjectRepo := new(MockProjectRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockSprintRepo := new(MockSprintRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		service := NewBoardService(mockProjectRepo, mockBacklogRepo, mockSprintRepo, mockWorkflowRepo, nil)
+
+		sprint := &models.Sprint{ID: uuid.New(), ProjectID: projectID, Name: "Sprint 4"}
+		mockProjectRepo.On("GetByID", projectID).Return(project, nil)
+		mockSprintRepo.On("GetActive", projectID).Return(sprint, nil)
+		mockBacklogRepo.On("GetByProjectID", projectID, mock.MatchedBy(func(f repository.BacklogFilters) bool {
+			return f.SprintID != nil && *f.SprintID == sprint.ID
+		})).Return([]models.BacklogItem{}, int64(0), nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(nil, nil)
+		mockBacklogRepo.On("CountByStatus", projectID, &sprint.ID).Return(map[constants.ItemStatus]int64{}, nil)
+
+		result, err := service.GetBoard(&request.BoardQueryParams{
+			ProjectID:          projectID.String(),
+			BacklogQueryParams: request.BacklogQueryParams{SprintID: "active"},
+		}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Sprint 4", result.Sprint.Name)
+		assert.Equal(t, 0, result.TotalItems)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when the project has no active sprint", func(t *testing.T) {
+		mockProjectRepo := new(MockProjectRepository)
+		mockSprintRepo := new(MockSprintRepository)
+		service := NewBoardService(mockProjectRepo, nil, mockSprintRepo, nil, nil)
+
+		mockProjectRepo.On("GetByID", projectID).Return(project, nil)
+		mockSprintRepo.On("GetActive", projectID).Return(nil, nil)
+
+		result, err := service.GetBoard(&request.BoardQueryParams{
+			ProjectID:          projectID.String(),
+			BacklogQueryParams: request.BacklogQueryParams{SprintID: "active"},
+		}, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrNoActiveSprint, err)
+	})
+
+	t.Run("should reject a sprint of another project", func(t *testing.T) {
+		mockProjectRepo := new(MockProjectRepository)
+		mockSprintRepo := new(MockSprintRepository)
+		service := NewBoardService(mockProjectRepo, nil, mockSprintRepo, nil, nil)
+
+		sprint := &models.Sprint{ID: uuid.New(), ProjectID: uuid.New()}
+		mockProjectRepo.On("GetByID", projectID).Return(project, nil)
+		mockSprintRepo.On("GetByID", sprint.ID).Return(sprint, nil)
+
+		result, err := service.GetBoard(&request.BoardQueryParams{
+			ProjectID:          projectID.String(),
+			BacklogQueryParams: request.BacklogQueryParams{SprintID: sprint.ID.String()},
+		}, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrSprintNotInProject, err)
+	})
+
+	t.Run("should reject an invalid project ID", func(t *testing.T) {
+		service := NewBoardService(nil, nil, nil, nil, nil)
+
+		result, err := service.GetBoard(&request.BoardQueryParams{ProjectID: "not-a-uuid"}, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrInvalidProjectID, err)
+	})
+}
+
+func TestBoardService_MoveItem(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+	limit := 2
+	workflow := &models.Workflow{
+		ProjectID: projectID,
+		Statuses: []models.WorkflowStatus{
+			{Name: "Open", Category: constants.StatusCategoryTodo},
+			{Name: "Doing", Category: constants.StatusCategoryInProgress, WIPLimit: &limit},
+			{Name: "Closed", Category: constants.StatusCategoryDone},
+		},
+	}
+	column := repository.BoardColumn{ProjectID: projectID, Status: "Doing"}
+	columnFilters := repository.BacklogFilters{Status: []constants.ItemStatus{"Doing"}}
+
+	t.Run("should move the card and return the column in its new order", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBoardService(nil, mockBacklogRepo, nil, mockWorkflowRepo, mockLinkRepo)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: "Open"}
+		other := models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: "Doing"}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(workflow, nil)
+		mockBacklogRepo.On("CountByStatus", projectID, &uuid.Nil).
+			Return(map[constants.ItemStatus]int64{"Doing": 1}, nil).Once()
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{}, nil).Once()
+		mockBacklogRepo.On("MoveToColumn", item.ID, column, 0, mock.MatchedBy(func(histories []models.ItemHistory) bool {
+			return len(histories) == 1 && histories[0].Action == constants.ItemActionStatusChanged &&
+				string(histories[0].OldValue) == `"Open"` && string(histories[0].NewValue) == `"Doing"`
+		})).Return(nil)
+
+		moved := *item
+		moved.Status = "Doing"
+		mockBacklogRepo.On("GetByProjectID", projectID, columnFilters).Return([]models.BacklogItem{moved, other}, int64(2), nil)
+		mockBacklogRepo.On("CountByStatus", projectID, (*uuid.UUID)(nil)).
+			Return(map[constants.ItemStatus]int64{"Doing": 2}, nil).Once()
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID, other.ID}).Return([]models.ItemLink{}, nil).Once()
+
+		result, err := service.MoveItem(item.ID, &request.MoveBoardItemRequest{Status: "Doing", Position: 0}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ItemStatus("Doing"), result.Status)
+		assert.Equal(t, constants.StatusCategoryInProgress, result.Category)
+		assert.Equal(t, item.ID, result.Items[0].ID)
+		assert.Nil(t, result.WIPLimit)
+		assert.Equal(t, int64(2), result.Load)
+		mockBacklogRepo.AssertExpectations(t)
+		mockLinkRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a move into a full column", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		service := NewBoardService(nil, mockBacklogRepo, nil, mockWorkflowRepo, nil)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: "Open"}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(workflow, nil)
+		mockBacklogRepo.On("CountByStatus", projectID, &uuid.Nil).
+			Return(map[constants.ItemStatus]int64{"Doing": 2}, nil)
+
+		result, err := service.MoveItem(item.ID, &request.MoveBoardItemRequest{Status: "Doing"}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrWIPLimitExceeded, err)
+		mockBacklogRepo.AssertNotCalled(t, "MoveToColumn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should record an override of a full column", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewBoardService(nil, mockBacklogRepo, nil, mockWorkflowRepo, mockLinkRepo)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: "Open"}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(workflow, nil)
+		mockBacklogRepo.On("CountByStatus", projectID, &uuid.Nil).
+			Return(map[constants.ItemStatus]int64{"Doing": 2}, nil)
+		mockLinkRepo.On("GetBlockers", mock.Anything).Return([]models.ItemLink{}, nil)
+		mockBacklogRepo.On("MoveToColumn", item.ID, column, 1, mock.MatchedBy(func(histories []models.ItemHistory) bool {
+			return len(histories) == 2 && histories[1].Action == constants.ItemActionWIPLimitOverridden
+		})).Return(nil)
+		mockBacklogRepo.On("GetByProjectID", projectID, columnFilters).Return([]models.BacklogItem{}, int64(0), nil)
+		mockBacklogRepo.On("CountByStatus", projectID, (*uuid.UUID)(nil)).
+			Return(map[constants.ItemStatus]int64{"Doing": 3}, nil)
+
+		result, err := service.MoveItem(item.ID, &request.MoveBoardItemRequest{Status: "Doing", Position: 1, OverrideWIPLimit: true}, userID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a card outside the board's sprint scope", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		service := NewBoardService(nil, mockBacklogRepo, nil, mockWorkflowRepo, nil)
+
+		sprintID := uuid.New()
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: "Open", SprintID: &sprintID}
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(workflow, nil)
+
+		result, err := service.MoveItem(item.ID, &request.MoveBoardItemRequest{Status: "Doing", SprintID: "none"}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrItemNotInSprint, err)
+		mockBacklogRepo.AssertNotCalled(t, "MoveToColumn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return not found for a missing item", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewBoardService(nil, mockBacklogRepo, nil, nil, nil)
+
+		id := uuid.New()
+		mockBacklogRepo.On("GetByID", id).Return(nil, nil)
+
+		result, err := service.MoveItem(id, &request.MoveBoardItemRequest{Status: "Doing"}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrBacklogItemNotFound, err)
+	})
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/mention"
+)
+
+var (
+	ErrEmptyComment          = errors.New("comment cannot be empty")
+	ErrCommentNotFound       = errors.New("comment not found")
+	ErrCommentForbidden      = errors.New("only the author can change a comment")
+	ErrParentCommentNotFound = errors.New("parent comment not found on this item")
+)
+
+type CommentService interface {
+	Create(itemID uuid.UUID, req *request.CreateCommentRequest, userID uuid.UUID) (*response.CommentResponse, error)
+	GetByItemID(itemID uuid.UUID) ([]response.CommentResponse, error)
+	Update(id uuid.UUID, req *request.UpdateCommentRequest, userID uuid.UUID) (*response.CommentResponse, error)
+	Delete(id uuid.UUID, userID uuid.UUID) error
+	GetEdits(id uuid.UUID) ([]response.CommentEditResponse, error)
+}
+
+type commentService struct {
+	commentRepo repository.CommentRepository
+	backlogRepo repository.BacklogRepository
+	userRepo    repository.UserRepository
+}
+
+func NewCommentService(commentRepo repository.CommentRepository, backlogRepo repository.BacklogRepository, userRepo repository.UserRepository) CommentService {
+	return &commentService{
+		commentRepo: commentRepo,
+		backlogRepo: backlogRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// Create comments on an item. A reply to a reply joins the thread of its parent.
+func (s *commentService) Create(itemID uuid.UUID, req *request.CreateCommentRequest, userID uuid.UUID) (*response.CommentResponse, error) {
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, ErrEmptyComment
+	}
+
+	item, err := s.backlogRepo.GetByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	comment := &models.Comment{
+		ID:       uuid.New(),
+		ItemID:   itemID,
+		AuthorID: userID,
+		Content:  content,
+	}
+
+	if req.ParentID != nil {
+		parent, err := s.commentRepo.GetByID(*req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || parent.ItemID != itemID {
+			return nil, ErrParentCommentNotFound
+		}
+		comment.ParentID = &parent.ID
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+		}
+	}
+
+	if comment.Mentions, err = s.resolveMentions(content); err != nil {
+		return nil, err
+	}
+
+	history := commentHistory(comment, constants.ItemActionCommentAdded, userID)
+	if err := s.commentRepo.Create(comment, history); err != nil {
+		return nil, err
+	}
+
+	return s.commentResponse(comment.ID)
+}
+
+// GetByItemID returns the item's comment threads, oldest first
+func (s *commentService) GetByItemID(itemID uuid.UUID) ([]response.CommentResponse, error) {
+	item, err := s.backlogRepo.GetByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	comments, err := s.commentRepo.GetByItemID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ToCommentThreadsResponse(comments), nil
+}
+
+// Update edits a comment and keeps its previous content in the edit history
+func (s *commentService) Update(id uuid.UUID, req *request.UpdateCommentRequest, userID uuid.UUID) (*response.CommentResponse, error) {
+	comment, err := s.commentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, ErrCommentNotFound
+	}
+	if comment.AuthorID != userID {
+		return nil, ErrCommentForbidden
+	}
+
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, ErrEmptyComment
+	}
+	if content == comment.Content {
+		return response.ToCommentResponse(comment), nil
+	}
+
+	now := time.Now()
+	edit := &models.CommentEdit{
+		CommentID: comment.ID,
+		EditorID:  userID,
+		Content:   comment.Content,
+		EditedAt:  now,
+	}
+	comment.Content = content
+	comment.EditedAt = &now
+	if comment.Mentions, err = s.resolveMentions(content); err != nil {
+		return nil, err
+	}
+
+	history := commentHistory(comment, constants.ItemActionCommentEdited, userID)
+	if err := s.commentRepo.Update(comment, edit, history); err != nil {
+		return nil, err
+	}
+
+	return s.commentResponse(comment.ID)
+}
+
+// Delete soft-deletes a comment. Its replies are kept.
+func (s *commentService) Delete(id uuid.UUID, userID uuid.UUID) error {
+	comment, err := s.commentRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if comment == nil {
+		return ErrCommentNotFound
+	}
+	if comment.AuthorID != userID {
+		return ErrCommentForbidden
+	}
+
+	history := commentHistory(comment, constants.ItemActionCommentDeleted, userID)
+	return s.commentRepo.Delete(comment.ID, history)
+}
+
+// GetEdits returns the previous versions of a comment, newest first
+func (s *commentService) GetEdits(id uuid.UUID) ([]response.CommentEditResponse, error) {
+	comment, err := s.commentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, ErrCommentNotFound
+	}
+
+	edits, err := s.commentRepo.GetEdits(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ToCommentEditListResponse(edits), nil
+}
+
+func (s *commentService) commentResponse(id uuid.UUID) (*response.CommentResponse, error) {
+	comment, err := s.commentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, ErrCommentNotFound
+	}
+	return response.ToCommentResponse(comment), nil
+}
+
+// resolveMentions finds the users mentioned in the content. A handle without a domain
+// only mentions a user when no other user's email address has the same local part.
+func (s *commentService) resolveMentions(content string) ([]models.CommentMention, error) {
+	handles := mention.Parse(content)
+	if len(handles) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.userRepo.GetByHandles(handles)
+	if err != nil {
+		return nil, err
+	}
+	byEmail := make(map[string]uuid.UUID, len(users))
+	byLocalPart := make(map[string][]uuid.UUID, len(users))
+	for _, u := range users {
+		email := strings.ToLower(u.Email)
+		byEmail[email] = u.ID
+		local, _, _ := strings.Cut(email, "@")
+		byLocalPart[local] = append(byLocalPart[local], u.ID)
+	}
+
+	var mentions []models.CommentMention
+	seen := make(map[uuid.UUID]bool)
+	for _, handle := range handles {
+		var userID uuid.UUID
+		if mention.IsEmail(handle) {
+			userID = byEmail[handle]
+		} else if ids := byLocalPart[handle]; len(ids) == 1 {
+			userID = ids[0]
+		}
+		if userID == uuid.Nil || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		mentions = append(mentions, models.CommentMention{UserID: userID})
+	}
+	return mentions, nil
+}
+
+// commentHistory builds the item history entry for a comment event. The entry only
+// refers to the comment; its content lives in the comment and its edits.
+func commentHistory(comment *models.Comment, action constants.ItemAction, userID uuid.UUID) models.ItemHistory {
+	field := "comment"
+	value, _ := json.Marshal(map[string]interface{}{
+		"comment_id": comment.ID,
+		"parent_id":  comment.ParentID,
+	})
+	history := models.ItemHistory{
+		ItemID:       comment.ItemID,
+		UserID:       userID,
+		Action:       action,
+		FieldChanged: &field,
+	}
+	if action == constants.ItemActionCommentDeleted {
+		history.OldValue = datatypes.JSON(value)
+	} else {
+		history.NewValue = datatypes.JSON(value)
+	}
+	return history
+}
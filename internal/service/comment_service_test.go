@@ -0,0 +1,204 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// MockCommentRepository is a mock implementation of CommentRepository
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) Create(comment *models.Comment, history models.ItemHistory) error {
+	args := m.Called(comment, history)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) GetByID(id uuid.UUID) (*models.Comment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) GetByItemID(itemID uuid.UUID) ([]models.Comment, error) {
+	args := m.Called(itemID)
+	return args.Get(0).([]models.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) Update(comment *models.Comment, edit *models.CommentEdit, history models.ItemHistory) error {
+	args := m.Called(comment, edit, history)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) Delete(id uuid.UUID, history models.ItemHistory) error {
+	args := m.Called(id, history)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) GetEdits(commentID uuid.UUID) ([]models.CommentEdit, error) {
+	args := m.Called(commentID)
+	return args.Get(0).([]models.CommentEdit), args.Error(1)
+}
+
+func TestCommentService_Create(t *testing.T) {
+	t.Run("should store mentions and record a history entry without the content", func(t *testing.T) {
+		mockCommentRepo := new(MockCommentRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockUserRepo := new(MockUserRepository)
+		service := NewCommentService(mockCommentRepo, mockBacklogRepo, mockUserRepo)
+
+		itemID, authorID := uuid.New(), uuid.New()
+		jane := models.User{ID: uuid.New(), Email: "Jane@example.com"}
+		bob := models.User{ID: uuid.New(), Email: "bob@example.com"}
+		otherBob := models.User{ID: uuid.New(), Email: "bob@example.org"}
+
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID}, nil)
+		mockUserRepo.On("GetByHandles", []string{"jane", "bob", "bob@example.org"}).
+			Return([]models.User{jane, bob, otherBob}, nil)
+
+		stored := &models.Comment{}
+		mockCommentRepo.On("Create", mock.AnythingOfType("*models.Comment"), mock.MatchedBy(func(h models.ItemHistory) bool {
+			return h.Action == constants.ItemActionCommentAdded && h.ItemID == itemID && h.Comment == nil && len(h.NewValue) > 0
+		})).Run(func(args mock.Arguments) {
+			*stored = *args.Get(0).(*models.Comment)
+		}).Return(nil)
+		mockCommentRepo.On("GetByID", mock.Anything).Return(stored, nil)
+
+		result, err := service.Create(itemID, &request.CreateCommentRequest{
+			Content: " @jane please pair with @bob and @bob@example.org ",
+		}, authorID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "@jane please pair with @bob and @bob@example.org", result.Content)
+		// @bob is ambiguous, so only the full address mentions a Bob
+		if assert.Len(t, stored.Mentions, 2) {
+			assert.Equal(t, jane.ID, stored.Mentions[0].UserID)
+			assert.Equal(t, otherBob.ID, stored.Mentions[1].UserID)
+		}
+		mockCommentRepo.AssertExpectations(t)
+	})
+
+	t.Run("should add a reply to a reply to the same thread", func(t *testing.T) {
+		mockCommentRepo := new(MockCommentRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewCommentService(mockCommentRepo, mockBacklogRepo, nil)
+
+		itemID, rootID := uuid.New(), uuid.New()
+		reply := &models.Comment{ID: uuid.New(), ItemID: itemID, ParentID: &rootID}
+
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID}, nil)
+		mockCommentRepo.On("GetByID", reply.ID).Return(reply, nil).Once()
+		mockCommentRepo.On("Create", mock.MatchedBy(func(c *models.Comment) bool {
+			return c.ParentID != nil && *c.ParentID == rootID
+		}), mock.Anything).Return(nil)
+		mockCommentRepo.On("GetByID", mock.Anything).Return(&models.Comment{ID: uuid.New(), ItemID: itemID, ParentID: &rootID}, nil)
+
+		result, err := service.Create(itemID, &request.CreateCommentRequest{Content: "agreed", ParentID: &reply.ID}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, rootID, *result.ParentID)
+		mockCommentRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a parent on another item", func(t *testing.T) {
+		mockCommentRepo := new(MockCommentRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewCommentService(mockCommentRepo, mockBacklogRepo, nil)
+
+		itemID := uuid.New()
+		parent := &models.Comment{ID: uuid.New(), ItemID: uuid.New()}
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID}, nil)
+		mockCommentRepo.On("GetByID", parent.ID).Return(parent, nil)
+
+		result, err := service.Create(itemID, &request.CreateCommentRequest{Content: "hi", ParentID: &parent.ID}, uuid.New())
+
+		assert.Equal(t, ErrParentCommentNotFound, err)
+		assert.Nil(t, result)
+		mockCommentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject blank content", func(t *testing.T) {
+		service := NewCommentService(nil, nil, nil)
+
+		result, err := service.Create(uuid.New(), &request.CreateCommentRequest{Content: "   "}, uuid.New())
+
+		assert.Equal(t, ErrEmptyComment, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestCommentService_Update(t *testing.T) {
+	t.Run("should keep the previous content as an edit", func(t *testing.T) {
+		mockCommentRepo := new(MockCommentRepository)
+		service := NewCommentService(mockCommentRepo, nil, nil)
+
+		authorID := uuid.New()
+		comment := &models.Comment{ID: uuid.New(), ItemID: uuid.New(), AuthorID: authorID, Content: "first draft"}
+
+		mockCommentRepo.On("GetByID", comment.ID).Return(comment, nil)
+		mockCommentRepo.On("Update", comment, mock.MatchedBy(func(e *models.CommentEdit) bool {
+			return e.Content == "first draft" && e.EditorID == authorID
+		}), mock.MatchedBy(func(h models.ItemHistory) bool {
+			return h.Action == constants.ItemActionCommentEdited
+		})).Return(nil)
+
+		result, err := service.Update(comment.ID, &request.UpdateCommentRequest{Content: "final"}, authorID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "final", result.Content)
+		assert.NotNil(t, result.EditedAt)
+		mockCommentRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not let others edit a comment", func(t *testing.T) {
+		mockCommentRepo := new(MockCommentRepository)
+		service := NewCommentService(mockCommentRepo, nil, nil)
+
+		comment := &models.Comment{ID: uuid.New(), AuthorID: uuid.New(), Content: "mine"}
+		mockCommentRepo.On("GetByID", comment.ID).Return(comment, nil)
+
+		result, err := service.Update(comment.ID, &request.UpdateCommentRequest{Content: "yours"}, uuid.New())
+
+		assert.Equal(t, ErrCommentForbidden, err)
+		assert.Nil(t, result)
+		mockCommentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCommentService_Delete(t *testing.T) {
+	t.Run("should delete the author's comment", func(t *testing.T) {
+		mockCommentRepo := new(MockCommentRepository)
+		service := NewCommentService(mockCommentRepo, nil, nil)
+
+		comment := &models.Comment{ID: uuid.New(), ItemID: uuid.New(), AuthorID: uuid.New()}
+		mockCommentRepo.On("GetByID", comment.ID).Return(comment, nil)
+		mockCommentRepo.On("Delete", comment.ID, mock.MatchedBy(func(h models.ItemHistory) bool {
+			return h.Action == constants.ItemActionCommentDeleted && h.ItemID == comment.ItemID
+		})).Return(nil)
+
+		err := service.Delete(comment.ID, comment.AuthorID)
+
+		assert.NoError(t, err)
+		mockCommentRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return not found for a missing comment", func(t *testing.T) {
+		mockCommentRepo := new(MockCommentRepository)
+		service := NewCommentService(mockCommentRepo, nil, nil)
+
+		id := uuid.New()
+		mockCommentRepo.On("GetByID", id).Return(nil, nil)
+
+		assert.Equal(t, ErrCommentNotFound, service.Delete(id, uuid.New()))
+	})
+}
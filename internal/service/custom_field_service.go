@@ -0,0 +1,407 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+var (
+	ErrCustomFieldNotFound       = errors.New("custom field not found")
+	ErrCustomFieldExists         = errors.New("a custom field with this key already exists")
+	ErrInvalidCustomFieldKey     = errors.New("custom field key must start with a lowercase letter and contain only lowercase letters, digits and underscores")
+	ErrReservedCustomFieldKey    = errors.New("custom field key is reserved for a built-in field")
+	ErrInvalidCustomFieldType    = errors.New("custom field type must be text, number, date, select, multi_select or user")
+	ErrInvalidCustomFieldOptions = errors.New("select and multi_select fields need distinct, non-empty options; other types take none")
+	ErrUnknownCustomField        = errors.New("unknown custom field")
+	ErrInvalidCustomFieldValue   = errors.New("invalid custom field value")
+	ErrCustomFieldRequired       = errors.New("custom field is required")
+)
+
+// maxCustomTextLength caps the length of text custom field values
+const maxCustomTextLength = 1000
+
+// reservedCustomFieldKeys are the built-in item fields recorded in item history;
+// custom field changes are recorded under their key, so it must not collide
+var reservedCustomFieldKeys = map[string]bool{
+	"title": true, "description": true, "type": true, "priority": true, "status": true,
+	"story_points": true, "estimate": true, "labels": true, "sprint_id": true, "parent_id": true, "due_date": true,
+	"original_estimate": true, "remaining_estimate": true, "time_spent": true,
+	"assignee": true, "assignees": true, "rank": true, "position": true, "number": true, "key": true,
+	"link": true, "comment": true, "attachment": true, "worklog": true,
+	"project": true, "clone": true, "cloned_from": true, "deleted_at": true, "custom_fields": true,
+}
+
+type CustomFieldService interface {
+	Create(projectID uuid.UUID, req *request.CreateCustomFieldRequest) (*response.CustomFieldResponse, error)
+	GetByID(id uuid.UUID) (*response.CustomFieldResponse, error)
+	GetByProjectID(projectID uuid.UUID) ([]response.CustomFieldResponse, error)
+	Update(id uuid.UUID, req *request.UpdateCustomFieldRequest) (*response.CustomFieldResponse, error)
+	Delete(id uuid.UUID, userID uuid.UUID) error
+}
+
+type customFieldService struct {
+	fieldRepo   repository.CustomFieldRepository
+	projectRepo repository.ProjectRepository
+}
+
+func NewCustomFieldService(fieldRepo repository.CustomFieldRepository, projectRepo repository.ProjectRepository) CustomFieldService {
+	return &customFieldService{
+		fieldRepo:   fieldRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (s *customFieldService) Create(projectID uuid.UUID, req *request.CreateCustomFieldRequest) (*response.CustomFieldResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	key := strings.TrimSpace(req.Key)
+	if !repository.IsCustomFieldKey(key) {
+		return nil, ErrInvalidCustomFieldKey
+	}
+	if reservedCustomFieldKeys[key] {
+		return nil, ErrReservedCustomFieldKey
+	}
+	if !req.Type.IsValid() {
+		return nil, ErrInvalidCustomFieldType
+	}
+	options, err := customFieldOptions(req.Type, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.fieldRepo.FindByKey(projectID, key)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrCustomFieldExists
+	}
+
+	field := &models.CustomField{
+		ProjectID: projectID,
+		Key:       key,
+		Name:      strings.TrimSpace(req.Name),
+		Type:      req.Type,
+		Options:   options,
+		Required:  req.Required,
+	}
+	if err := s.fieldRepo.Create(field); err != nil {
+		return nil, err
+	}
+	return response.ToCustomFieldResponse(field), nil
+}
+
+func (s *customFieldService) GetByID(id uuid.UUID) (*response.CustomFieldResponse, error) {
+	field, err := s.fieldRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if field == nil {
+		return nil, ErrCustomFieldNotFound
+	}
+	return response.ToCustomFieldResponse(field), nil
+}
+
+func (s *customFieldService) GetByProjectID(projectID uuid.UUID) ([]response.CustomFieldResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	fields, err := s.fieldRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]response.CustomFieldResponse, len(fields))
+	for i := range fields {
+		result[i] = *response.ToCustomFieldResponse(&fields[i])
+	}
+	return result, nil
+}
+
+// Update changes a field's name, options or whether it is required. Values already
+// set on items are kept: an option removed from a select stays on the items that
+// have it, and making a field required applies to new items and later edits.
+func (s *customFieldService) Update(id uuid.UUID, req *request.UpdateCustomFieldRequest) (*response.CustomFieldResponse, error) {
+	field, err := s.fieldRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if field == nil {
+		return nil, ErrCustomFieldNotFound
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		field.Name = name
+	}
+	if req.Options != nil {
+		options, err := customFieldOptions(field.Type, req.Options)
+		if err != nil {
+			return nil, err
+		}
+		field.Options = options
+	}
+	if req.Required != nil {
+		field.Required = *req.Required
+	}
+
+	if err := s.fieldRepo.Update(field); err != nil {
+		return nil, err
+	}
+	return response.ToCustomFieldResponse(field), nil
+}
+
+// Delete removes a field and its value from every item of the project
+func (s *customFieldService) Delete(id uuid.UUID, userID uuid.UUID) error {
+	field, err := s.fieldRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if field == nil {
+		return ErrCustomFieldNotFound
+	}
+	_, err = s.fieldRepo.Delete(field, userID)
+	return err
+}
+
+// customFieldOptions trims the options of a select field and checks that they are
+// present and distinct ignoring case. Other types take no options.
+func customFieldOptions(fieldType constants.CustomFieldType, options []string) ([]string, error) {
+	if !fieldType.HasOptions() {
+		if len(options) > 0 {
+			return nil, ErrInvalidCustomFieldOptions
+		}
+		return nil, nil
+	}
+	if len(options) == 0 {
+		return nil, ErrInvalidCustomFieldOptions
+	}
+	result := make([]string, len(options))
+	seen := make(map[string]bool, len(options))
+	for i, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[strings.ToLower(option)] {
+			return nil, ErrInvalidCustomFieldOptions
+		}
+		seen[strings.ToLower(option)] = true
+		result[i] = option
+	}
+	return result, nil
+}
+
+// customFieldSet validates the custom field values written to a project's items
+type customFieldSet struct {
+	fields   map[string]*models.CustomField
+	userRepo repository.UserRepository
+}
+
+func loadCustomFields(fieldRepo repository.CustomFieldRepository, userRepo repository.UserRepository, projectID uuid.UUID) (*customFieldSet, error) {
+	fields, err := fieldRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	set := &customFieldSet{fields: make(map[string]*models.CustomField, len(fields)), userRepo: userRepo}
+	for i := range fields {
+		set.fields[fields[i].Key] = &fields[i]
+	}
+	return set, nil
+}
+
+// apply writes the values over the current ones and returns the result with the
+// old and new value of each changed field. A null or empty value clears the field.
+// On create, every required field must end up with a value; on update, required
+// fields cannot be cleared.
+func (s *customFieldSet) apply(current datatypes.JSONMap, values map[string]interface{}, creating bool) (datatypes.JSONMap, map[string][2]interface{}, error) {
+	result := make(datatypes.JSONMap, len(current)+len(values))
+	for key, value := range current {
+		result[key] = value
+	}
+
+	// Check the fields in a fixed order so the same request fails the same way
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	changes := make(map[string][2]interface{})
+	for _, key := range keys {
+		field, ok := s.fields[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownCustomField, key)
+		}
+		value, err := s.normalize(field, values[key])
+		if err != nil {
+			return nil, nil, err
+		}
+		old, had := result[key]
+		if value == nil {
+			if field.Required {
+				return nil, nil, fmt.Errorf("%w: %s", ErrCustomFieldRequired, key)
+			}
+			if had {
+				changes[key] = [2]interface{}{old, nil}
+				delete(result, key)
+			}
+			continue
+		}
+		if !had || !sameCustomValue(old, value) {
+			changes[key] = [2]interface{}{old, value}
+			result[key] = value
+		}
+	}
+
+	if creating {
+		for key, field := range s.fields {
+			if _, ok := result[key]; field.Required && !ok {
+				return nil, nil, fmt.Errorf("%w: %s", ErrCustomFieldRequired, key)
+			}
+		}
+	}
+	return result, changes, nil
+}
+
+// normalize checks a value against the field's type and returns it as stored:
+// trimmed text, a number, a YYYY-MM-DD date, options spelled as defined, or a
+// user ID. Null, blank text and an empty selection return nil.
+func (s *customFieldSet) normalize(field *models.CustomField, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	invalid := func(expected string) error {
+		return fmt.Errorf("%w: %s must be %s", ErrInvalidCustomFieldValue, field.Key, expected)
+	}
+
+	switch field.Type {
+	case constants.CustomFieldText:
+		text, ok := value.(string)
+		if !ok || len(text) > maxCustomTextLength {
+			return nil, invalid(fmt.Sprintf("text of at most %d characters", maxCustomTextLength))
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			return nil, nil
+		}
+		return text, nil
+
+	case constants.CustomFieldNumber:
+		switch n := value.(type) {
+		case float64:
+			return n, nil
+		case int:
+			return float64(n), nil
+		}
+		return nil, invalid("a number")
+
+	case constants.CustomFieldDate:
+		text, ok := value.(string)
+		if !ok {
+			return nil, invalid("a date in YYYY-MM-DD format")
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			return nil, nil
+		}
+		if _, err := time.Parse(constants.DateLayout, text); err != nil {
+			return nil, invalid("a date in YYYY-MM-DD format")
+		}
+		return text, nil
+
+	case constants.CustomFieldSelect:
+		text, ok := value.(string)
+		if !ok {
+			return nil, invalid("one of " + strings.Join(field.Options, ", "))
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			return nil, nil
+		}
+		option := matchOption(text, field.Options)
+		if option == "" {
+			return nil, invalid("one of " + strings.Join(field.Options, ", "))
+		}
+		return option, nil
+
+	case constants.CustomFieldMultiSelect:
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, invalid("a list of " + strings.Join(field.Options, ", "))
+		}
+		selected := make([]string, 0, len(list))
+		seen := make(map[string]bool, len(list))
+		for _, item := range list {
+			text, _ := item.(string)
+			option := matchOption(strings.TrimSpace(text), field.Options)
+			if option == "" {
+				return nil, invalid("a list of " + strings.Join(field.Options, ", "))
+			}
+			if !seen[option] {
+				seen[option] = true
+				selected = append(selected, option)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, nil
+		}
+		return selected, nil
+
+	case constants.CustomFieldUser:
+		text, ok := value.(string)
+		if !ok {
+			return nil, invalid("a user ID")
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			return nil, nil
+		}
+		id, err := uuid.Parse(text)
+		if err != nil {
+			return nil, invalid("a user ID")
+		}
+		user, err := s.userRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, invalid("the ID of an existing user")
+		}
+		return id.String(), nil
+	}
+
+	return nil, invalid("a valid value")
+}
+
+// sameCustomValue compares a stored value, as decoded from JSON, with a normalized one
+func sameCustomValue(a, b interface{}) bool {
+	aJSON, _ := json.Marshal(a)
+	bJSON, _ := json.Marshal(b)
+	return string(aJSON) == string(bJSON)
+}
+
+// matchOption returns the option equal to text ignoring case, or ""
+func matchOption(text string, options []string) string {
+	for _, option := range options {
+		if strings.EqualFold(text, option) {
+			return option
+		}
+	}
+	return ""
+}
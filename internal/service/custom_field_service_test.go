@@ -0,0 +1,246 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/datatypes"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// MockCustomFieldRepository is a mock implementation of CustomFieldRepository
+type MockCustomFieldRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomFieldRepository) Create(field *models.CustomField) error {
+	args := m.Called(field)
+	return args.Error(0)
+}
+
+func (m *MockCustomFieldRepository) GetByID(id uuid.UUID) (*models.CustomField, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CustomField), args.Error(1)
+}
+
+func (m *MockCustomFieldRepository) GetByProjectID(projectID uuid.UUID) ([]models.CustomField, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]models.CustomField), args.Error(1)
+}
+
+func (m *MockCustomFieldRepository) FindByKey(projectID uuid.UUID, key string) (*models.CustomField, error) {
+	args := m.Called(projectID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CustomField), args.Error(1)
+}
+
+func (m *MockCustomFieldRepository) Update(field *models.CustomField) error {
+	args := m.Called(field)
+	return args.Error(0)
+}
+
+func (m *MockCustomFieldRepository) Delete(field *models.CustomField, userID uuid.UUID) (int, error) {
+	args := m.Called(field, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCustomFieldService_Create(t *testing.T) {
+	projectID := uuid.New()
+
+	t.Run("should save a select field with trimmed options", func(t *testing.T) {
+		mockFieldRepo := new(MockCustomFieldRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewCustomFieldService(mockFieldRepo, mockProjectRepo)
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockFieldRepo.On("FindByKey", projectID, "environment").Return(nil, nil)
+		mockFieldRepo.On("Create", mock.MatchedBy(func(f *models.CustomField) bool {
+			return f.Key == "environment" && f.Type == constants.CustomFieldSelect &&
+				len(f.Options) == 2 && f.Options[0] == "staging" && f.Required
+		})).Return(nil)
+
+		result, err := service.Create(projectID, &request.CreateCustomFieldRequest{
+			Key:      "environment",
+			Name:     "Environment",
+			Type:     constants.CustomFieldSelect,
+			Options:  []string{" staging ", "prod"},
+			Required: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "environment", result.Key)
+		mockFieldRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject invalid definitions", func(t *testing.T) {
+		tests := []struct {
+			name string
+			req  request.CreateCustomFieldRequest
+			err  error
+		}{
+			{"uppercase key", request.CreateCustomFieldRequest{Key: "Customer", Type: constants.CustomFieldText}, ErrInvalidCustomFieldKey},
+			{"built-in key", request.CreateCustomFieldRequest{Key: "status", Type: constants.CustomFieldText}, ErrReservedCustomFieldKey},
+			{"unknown type", request.CreateCustomFieldRequest{Key: "customer", Type: "checkbox"}, ErrInvalidCustomFieldType},
+			{"select without options", request.CreateCustomFieldRequest{Key: "component", Type: constants.CustomFieldMultiSelect}, ErrInvalidCustomFieldOptions},
+			{"duplicate options", request.CreateCustomFieldRequest{Key: "component", Type: constants.CustomFieldSelect, Options: []string{"API", "api"}}, ErrInvalidCustomFieldOptions},
+			{"options on text", request.CreateCustomFieldRequest{Key: "customer", Type: constants.CustomFieldText, Options: []string{"Acme"}}, ErrInvalidCustomFieldOptions},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockFieldRepo := new(MockCustomFieldRepository)
+				mockProjectRepo := new(MockProjectRepository)
+				service := NewCustomFieldService(mockFieldRepo, mockProjectRepo)
+				mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+
+				result, err := service.Create(projectID, &tt.req)
+
+				assert.Nil(t, result)
+				assert.Equal(t, tt.err, err)
+				mockFieldRepo.AssertNotCalled(t, "Create", mock.Anything)
+			})
+		}
+	})
+
+	t.Run("should reject a key already in use", func(t *testing.T) {
+		mockFieldRepo := new(MockCustomFieldRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewCustomFieldService(mockFieldRepo, mockProjectRepo)
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockFieldRepo.On("FindByKey", projectID, "customer").Return(&models.CustomField{ID: uuid.New(), Key: "customer"}, nil)
+
+		result, err := service.Create(projectID, &request.CreateCustomFieldRequest{Key: "customer", Name: "Customer", Type: constants.CustomFieldText})
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrCustomFieldExists, err)
+	})
+}
+
+func TestCustomFieldService_Delete(t *testing.T) {
+	t.Run("should delete the field and clear its values", func(t *testing.T) {
+		mockFieldRepo := new(MockCustomFieldRepository)
+		service := NewCustomFieldService(mockFieldRepo, nil)
+
+		userID := uuid.New()
+		field := &models.CustomField{ID: uuid.New(), Key: "customer"}
+		mockFieldRepo.On("GetByID", field.ID).Return(field, nil)
+		mockFieldRepo.On("Delete", field, userID).Return(3, nil)
+
+		err := service.Delete(field.ID, userID)
+
+		assert.NoError(t, err)
+		mockFieldRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when field not found", func(t *testing.T) {
+		mockFieldRepo := new(MockCustomFieldRepository)
+		service := NewCustomFieldService(mockFieldRepo, nil)
+
+		id := uuid.New()
+		mockFieldRepo.On("GetByID", id).Return(nil, nil)
+
+		err := service.Delete(id, uuid.New())
+
+		assert.Equal(t, ErrCustomFieldNotFound, err)
+	})
+}
+
+func TestCustomFieldSet_Apply(t *testing.T) {
+	newSet := func(userRepo *MockUserRepository) *customFieldSet {
+		return &customFieldSet{
+			userRepo: userRepo,
+			fields: map[string]*models.CustomField{
+				"customer":    {Key: "customer", Type: constants.CustomFieldText, Required: true},
+				"effort":      {Key: "effort", Type: constants.CustomFieldNumber},
+				"release":     {Key: "release", Type: constants.CustomFieldDate},
+				"environment": {Key: "environment", Type: constants.CustomFieldSelect, Options: []string{"staging", "prod"}},
+				"component":   {Key: "component", Type: constants.CustomFieldMultiSelect, Options: []string{"API", "Web"}},
+				"reviewer":    {Key: "reviewer", Type: constants.CustomFieldUser},
+			},
+		}
+	}
+
+	t.Run("should normalize values and report changes", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		set := newSet(mockUserRepo)
+		reviewer := uuid.New()
+		mockUserRepo.On("GetByID", reviewer).Return(&models.User{ID: reviewer}, nil)
+
+		current := datatypes.JSONMap{"customer": "Acme", "effort": float64(3), "release": "2026-01-15"}
+		values, changes, err := set.apply(current, map[string]interface{}{
+			"customer":    "Acme",
+			"effort":      float64(5),
+			"release":     nil,
+			"environment": "PROD",
+			"component":   []interface{}{"web", "api", "Web"},
+			"reviewer":    reviewer.String(),
+		}, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "prod", values["environment"])
+		assert.Equal(t, []string{"Web", "API"}, values["component"])
+		assert.NotContains(t, values, "release")
+		assert.Len(t, changes, 5)
+		assert.NotContains(t, changes, "customer")
+		assert.Equal(t, [2]interface{}{float64(3), float64(5)}, changes["effort"])
+		assert.Equal(t, [2]interface{}{"2026-01-15", nil}, changes["release"])
+		assert.Equal(t, "Acme", current["customer"])
+		assert.Contains(t, current, "release")
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			values map[string]interface{}
+			err    error
+		}{
+			{"unknown field", map[string]interface{}{"team": "core"}, ErrUnknownCustomField},
+			{"text as number", map[string]interface{}{"effort": "five"}, ErrInvalidCustomFieldValue},
+			{"malformed date", map[string]interface{}{"release": "15/01/2026"}, ErrInvalidCustomFieldValue},
+			{"unknown option", map[string]interface{}{"environment": "qa"}, ErrInvalidCustomFieldValue},
+			{"unknown multi-select option", map[string]interface{}{"component": []interface{}{"API", "Mobile"}}, ErrInvalidCustomFieldValue},
+			{"cleared required field", map[string]interface{}{"customer": " "}, ErrCustomFieldRequired},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				values, changes, err := newSet(nil).apply(datatypes.JSONMap{"customer": "Acme"}, tt.values, false)
+
+				assert.Nil(t, values)
+				assert.Nil(t, changes)
+				assert.True(t, errors.Is(err, tt.err))
+			})
+		}
+	})
+
+	t.Run("should reject a user that does not exist", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		reviewer := uuid.New()
+		mockUserRepo.On("GetByID", reviewer).Return(nil, nil)
+
+		_, _, err := newSet(mockUserRepo).apply(nil, map[string]interface{}{"customer": "Acme", "reviewer": reviewer.String()}, true)
+
+		assert.True(t, errors.Is(err, ErrInvalidCustomFieldValue))
+		assert.Contains(t, err.Error(), "reviewer")
+	})
+
+	t.Run("should require required fields on create", func(t *testing.T) {
+		values, _, err := newSet(nil).apply(nil, map[string]interface{}{"effort": float64(2)}, true)
+
+		assert.Nil(t, values)
+		assert.True(t, errors.Is(err, ErrCustomFieldRequired))
+		assert.Contains(t, err.Error(), "customer")
+	})
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// ErrInvalidDueDate is returned for due dates that are not YYYY-MM-DD dates
+var ErrInvalidDueDate = errors.New("due date must be a date in YYYY-MM-DD format")
+
+// today returns the current calendar day in UTC. Items are overdue from the day
+// after their due date.
+func today() time.Time {
+	return calendarDay(time.Now())
+}
+
+// calendarDay truncates t to the start of its day in UTC
+func calendarDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// parseDueDate parses an optional YYYY-MM-DD date; nil or empty returns nil
+func parseDueDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	day, err := time.Parse(constants.DateLayout, *value)
+	if err != nil {
+		return nil, ErrInvalidDueDate
+	}
+	return &day, nil
+}
+
+// formatDueDate formats a due date for history entries; nil stays nil
+func formatDueDate(day *time.Time) *string {
+	if day == nil {
+		return nil
+	}
+	formatted := day.Format(constants.DateLayout)
+	return &formatted
+}
+
+// sameDueDate reports whether two optional due dates fall on the same day
+func sameDueDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Format(constants.DateLayout) == b.Format(constants.DateLayout)
+}
+
+// isOverdue reports whether an item is past its due date on day without being closed
+func isOverdue(item *models.BacklogItem, workflow *models.Workflow, day time.Time) bool {
+	if item.DueDate == nil || !item.DueDate.Before(day) {
+		return false
+	}
+	category, _ := workflow.Category(item.Status)
+	return !category.IsClosed()
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+var (
+	ErrInvalidEstimationScale   = errors.New("estimation scale must be linear, fibonacci, powers_of_two, tshirt or custom")
+	ErrInvalidEstimationOptions = errors.New("estimation options need distinct labels and only apply to tshirt and custom scales, where custom requires them")
+	ErrInvalidStoryPoints       = errors.New("story points are not on the project's estimation scale")
+	ErrInvalidEstimate          = errors.New("estimate is not a value of the project's estimation scale")
+	ErrEstimateMismatch         = errors.New("estimate and story points do not match on the project's estimation scale")
+)
+
+var (
+	fibonacciPoints   = []int{0, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89}
+	powersOfTwoPoints = []int{0, 1, 2, 4, 8, 16, 32, 64}
+
+	// defaultTShirtOptions maps T-shirt sizes to points for projects that keep the
+	// default sizes
+	defaultTShirtOptions = []models.EstimateOption{
+		{Label: "XS", Points: 1},
+		{Label: "S", Points: 2},
+		{Label: "M", Points: 3},
+		{Label: "L", Points: 5},
+		{Label: "XL", Points: 8},
+		{Label: "XXL", Points: 13},
+	}
+)
+
+type EstimationService interface {
+	Get(projectID uuid.UUID) (*response.EstimationScaleResponse, error)
+	Update(projectID uuid.UUID, req *request.UpdateEstimationScaleRequest, userID uuid.UUID) (*response.EstimationScaleResponse, error)
+}
+
+type estimationService struct {
+	projectRepo repository.ProjectRepository
+}
+
+func NewEstimationService(projectRepo repository.ProjectRepository) EstimationService {
+	return &estimationService{
+		projectRepo: projectRepo,
+	}
+}
+
+func (s *estimationService) Get(projectID uuid.UUID) (*response.EstimationScaleResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	scale := projectEstimationScale(project)
+	return response.ToEstimationScaleResponse(project.ID, scale.scale, scale.options, 0), nil
+}
+
+// Update changes the project's estimation scale and maps the estimates of its open
+// items onto it: items keep their label when the new scale has it, taking its
+// points, otherwise they take the label of their points. Points the new scale does
+// not have are kept until the item is estimated again. Done items and items of
+// completed sprints are left as they are, so velocity of past sprints is not
+// rewritten.
+func (s *estimationService) Update(projectID uuid.UUID, req *request.UpdateEstimationScaleRequest, userID uuid.UUID) (*response.EstimationScaleResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	if !req.Scale.IsValid() {
+		return nil, ErrInvalidEstimationScale
+	}
+	options, err := estimationOptions(req.Scale, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	project.EstimationScale = req.Scale
+	project.EstimationOptions = options
+	scale := projectEstimationScale(project)
+
+	changed, err := s.projectRepo.UpdateEstimationScale(project, scale.remap, userID)
+	if err != nil {
+		return nil, err
+	}
+	return response.ToEstimationScaleResponse(project.ID, scale.scale, scale.options, changed), nil
+}
+
+// estimationOptions checks the label mapping of a scale. Labels are trimmed and
+// must be distinct ignoring case; T-shirt scales without options use the default
+// sizes.
+func estimationOptions(scale constants.EstimationScale, options []request.EstimateOptionRequest) ([]models.EstimateOption, error) {
+	if !scale.IsLabelled() {
+		if len(options) > 0 {
+			return nil, ErrInvalidEstimationOptions
+		}
+		return nil, nil
+	}
+	if len(options) == 0 {
+		if scale == constants.EstimationScaleCustom {
+			return nil, ErrInvalidEstimationOptions
+		}
+		return nil, nil
+	}
+
+	result := make([]models.EstimateOption, len(options))
+	seen := make(map[string]bool, len(options))
+	for i, option := range options {
+		label := strings.TrimSpace(option.Label)
+		if label == "" || seen[strings.ToLower(label)] {
+			return nil, ErrInvalidEstimationOptions
+		}
+		seen[strings.ToLower(label)] = true
+		result[i] = models.EstimateOption{Label: label, Points: *option.Points}
+	}
+	return result, nil
+}
+
+// estimationScale resolves the estimates written to a project's items
+type estimationScale struct {
+	scale constants.EstimationScale
+	// options lists the values items may take; it is nil for the linear scale
+	options []models.EstimateOption
+}
+
+func projectEstimationScale(project *models.Project) *estimationScale {
+	switch project.EstimationScale {
+	case constants.EstimationScaleFibonacci:
+		return &estimationScale{scale: project.EstimationScale, options: pointOptions(fibonacciPoints)}
+	case constants.EstimationScalePowersOfTwo:
+		return &estimationScale{scale: project.EstimationScale, options: pointOptions(powersOfTwoPoints)}
+	case constants.EstimationScaleTShirt:
+		if len(project.EstimationOptions) == 0 {
+			return &estimationScale{scale: project.EstimationScale, options: defaultTShirtOptions}
+		}
+		return &estimationScale{scale: project.EstimationScale, options: project.EstimationOptions}
+	case constants.EstimationScaleCustom:
+		return &estimationScale{scale: project.EstimationScale, options: project.EstimationOptions}
+	}
+	return &estimationScale{scale: constants.EstimationScaleLinear}
+}
+
+func pointOptions(points []int) []models.EstimateOption {
+	options := make([]models.EstimateOption, len(points))
+	for i, p := range points {
+		options[i] = models.EstimateOption{Label: strconv.Itoa(p), Points: p}
+	}
+	return options
+}
+
+// resolve checks an estimate given as story points, as a label or as both, and
+// returns the points and the label to store. Labels are only stored on labelled
+// scales. Nil points and label resolve to no estimate.
+func (e *estimationScale) resolve(points *int, label *string) (*int, *string, error) {
+	if label != nil && strings.TrimSpace(*label) == "" {
+		return nil, nil, ErrInvalidEstimate
+	}
+	if e.options == nil {
+		if label != nil {
+			return nil, nil, fmt.Errorf("%w; the linear scale takes story points only", ErrInvalidEstimate)
+		}
+		return points, nil, nil
+	}
+
+	if label != nil {
+		option, ok := e.byLabel(*label)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w; use one of %s", ErrInvalidEstimate, e.labels())
+		}
+		if points != nil && *points != option.Points {
+			return nil, nil, ErrEstimateMismatch
+		}
+		return e.store(option)
+	}
+	if points != nil {
+		option, ok := e.byPoints(*points)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w; use one of %s", ErrInvalidStoryPoints, e.points())
+		}
+		return e.store(option)
+	}
+	return nil, nil, nil
+}
+
+// remap maps an existing estimate onto the scale without rejecting it: the label
+// when the scale has it, otherwise the label of the points. Points the scale does
+// not have are kept without a label.
+func (e *estimationScale) remap(points *int, label *string) (*int, *string) {
+	if e.options == nil {
+		return points, nil
+	}
+	if label != nil {
+		if option, ok := e.byLabel(*label); ok {
+			p, l, _ := e.store(option)
+			return p, l
+		}
+	}
+	if points != nil {
+		if option, ok := e.byPoints(*points); ok {
+			p, l, _ := e.store(option)
+			return p, l
+		}
+	}
+	return points, nil
+}
+
+func (e *estimationScale) store(option models.EstimateOption) (*int, *string, error) {
+	points := option.Points
+	if !e.scale.IsLabelled() {
+		return &points, nil, nil
+	}
+	label := option.Label
+	return &points, &label, nil
+}
+
+func (e *estimationScale) byLabel(label string) (models.EstimateOption, bool) {
+	label = strings.TrimSpace(label)
+	for _, option := range e.options {
+		if strings.EqualFold(option.Label, label) {
+			return option, true
+		}
+	}
+	return models.EstimateOption{}, false
+}
+
+// byPoints returns the first option worth the points
+func (e *estimationScale) byPoints(points int) (models.EstimateOption, bool) {
+	for _, option := range e.options {
+		if option.Points == points {
+			return option, true
+		}
+	}
+	return models.EstimateOption{}, false
+}
+
+func (e *estimationScale) labels() string {
+	labels := make([]string, len(e.options))
+	for i, option := range e.options {
+		labels[i] = option.Label
+	}
+	return strings.Join(labels, ", ")
+}
+
+func (e *estimationScale) points() string {
+	seen := make(map[int]bool, len(e.options))
+	var points []string
+	for _, option := range e.options {
+		if !seen[option.Points] {
+			seen[option.Points] = true
+			points = append(points, strconv.Itoa(option.Points))
+		}
+	}
+	return strings.Join(points, ", ")
+}
+
+// estimationScaleCache loads each project's estimation scale at most once
+type estimationScaleCache struct {
+	projectRepo repository.ProjectRepository
+	scales      map[uuid.UUID]*estimationScale
+}
+
+func newEstimationScaleCache(projectRepo repository.ProjectRepository) *estimationScaleCache {
+	return &estimationScaleCache{projectRepo: projectRepo, scales: make(map[uuid.UUID]*estimationScale)}
+}
+
+func (c *estimationScaleCache) get(projectID uuid.UUID) (*estimationScale, error) {
+	if scale, ok := c.scales[projectID]; ok {
+		return scale, nil
+	}
+	project, err := c.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	scale := projectEstimationScale(project)
+	c.scales[projectID] = scale
+	return scale, nil
+}
+
+// resolve checks an estimate against the project's scale, loading it only when
+// there is an estimate to check
+func (c *estimationScaleCache) resolve(projectID uuid.UUID, points *int, label *string) (*int, *string, error) {
+	if points == nil && label == nil {
+		return nil, nil, nil
+	}
+	scale, err := c.get(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return scale.resolve(points, label)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+func TestEstimationService_Get(t *testing.T) {
+	t.Run("should return the default T-shirt sizes", func(t *testing.T) {
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewEstimationService(mockProjectRepo)
+
+		projectID := uuid.New()
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID, EstimationScale: constants.EstimationScaleTShirt}, nil)
+
+		result, err := service.Get(projectID)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Labelled)
+		assert.Len(t, result.Options, 6)
+		assert.Equal(t, models.EstimateOption{Label: "M", Points: 3}, result.Options[2])
+	})
+
+	t.Run("should return error when project not found", func(t *testing.T) {
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewEstimationService(mockProjectRepo)
+
+		projectID := uuid.New()
+		mockProjectRepo.On("GetByID", projectID).Return(nil, nil)
+
+		result, err := service.Get(projectID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrProjectNotFound, err)
+	})
+}
+
+func TestEstimationService_Update(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("should save a custom scale and remap the items", func(t *testing.T) {
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewEstimationService(mockProjectRepo)
+
+		project := &models.Project{ID: projectID, EstimationScale: constants.EstimationScaleLinear}
+		mockProjectRepo.On("GetByID", projectID).Return(project, nil)
+		mockProjectRepo.On("UpdateEstimationScale", mock.MatchedBy(func(p *models.Project) bool {
+			return p.EstimationScale == constants.EstimationScaleCustom && len(p.EstimationOptions) == 2 &&
+				p.EstimationOptions[0].Label == "Small"
+		}), mock.MatchedBy(func(mapEstimate repository.EstimateMapper) bool {
+			points := 10
+			p, l := mapEstimate(&points, nil)
+			return *p == 10 && *l == "Large"
+		}), userID).Return(4, nil)
+
+		small, large := 2, 10
+		result, err := service.Update(projectID, &request.UpdateEstimationScaleRequest{
+			Scale: constants.EstimationScaleCustom,
+			Options: []request.EstimateOptionRequest{
+				{Label: " Small ", Points: &small},
+				{Label: "Large", Points: &large},
+			},
+		}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.EstimationScaleCustom, result.Scale)
+		assert.Equal(t, 4, result.ItemsUpdated)
+		mockProjectRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject invalid scales", func(t *testing.T) {
+		one := 1
+		tests := []struct {
+			name string
+			req  request.UpdateEstimationScaleRequest
+			err  error
+		}{
+			{"unknown scale", request.UpdateEstimationScaleRequest{Scale: "planning_poker"}, ErrInvalidEstimationScale},
+			{"custom without options", request.UpdateEstimationScaleRequest{Scale: constants.EstimationScaleCustom}, ErrInvalidEstimationOptions},
+			{"options on fibonacci", request.UpdateEstimationScaleRequest{Scale: constants.EstimationScaleFibonacci, Options: []request.EstimateOptionRequest{{Label: "S", Points: &one}}}, ErrInvalidEstimationOptions},
+			{"duplicate labels", request.UpdateEstimationScaleRequest{Scale: constants.EstimationScaleTShirt, Options: []request.EstimateOptionRequest{{Label: "S", Points: &one}, {Label: "s", Points: &one}}}, ErrInvalidEstimationOptions},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockProjectRepo := new(MockProjectRepository)
+				service := NewEstimationService(mockProjectRepo)
+				mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+
+				result, err := service.Update(projectID, &tt.req, userID)
+
+				assert.Nil(t, result)
+				assert.Equal(t, tt.err, err)
+				mockProjectRepo.AssertNotCalled(t, "UpdateEstimationScale", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
+
+func TestEstimationScale_Resolve(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	strPtr := func(v string) *string { return &v }
+	tshirt := projectEstimationScale(&models.Project{EstimationScale: constants.EstimationScaleTShirt})
+
+	t.Run("should accept any points on the linear scale", func(t *testing.T) {
+		points, label, err := projectEstimationScale(&models.Project{}).resolve(intPtr(7), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, *points)
+		assert.Nil(t, label)
+	})
+
+	t.Run("should store points without a label on fixed scales", func(t *testing.T) {
+		points, label, err := projectEstimationScale(&models.Project{EstimationScale: constants.EstimationScalePowersOfTwo}).resolve(intPtr(16), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 16, *points)
+		assert.Nil(t, label)
+	})
+
+	t.Run("should map points to their label", func(t *testing.T) {
+		points, label, err := tshirt.resolve(intPtr(5), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, *points)
+		assert.Equal(t, "L", *label)
+	})
+
+	t.Run("should reject invalid estimates", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			scale  *estimationScale
+			points *int
+			label  *string
+			err    error
+		}{
+			{"label on linear", projectEstimationScale(&models.Project{}), nil, strPtr("M"), ErrInvalidEstimate},
+			{"unknown size", tshirt, nil, strPtr("XXXL"), ErrInvalidEstimate},
+			{"blank size", tshirt, nil, strPtr(" "), ErrInvalidEstimate},
+			{"points without a size", tshirt, intPtr(4), nil, ErrInvalidStoryPoints},
+			{"size and points disagree", tshirt, intPtr(2), strPtr("M"), ErrEstimateMismatch},
+			{"off fibonacci", projectEstimationScale(&models.Project{EstimationScale: constants.EstimationScaleFibonacci}), intPtr(20), nil, ErrInvalidStoryPoints},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				points, label, err := tt.scale.resolve(tt.points, tt.label)
+
+				assert.Nil(t, points)
+				assert.Nil(t, label)
+				assert.True(t, errors.Is(err, tt.err))
+			})
+		}
+	})
+}
+
+func TestEstimationScale_Remap(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	strPtr := func(v string) *string { return &v }
+	custom := projectEstimationScale(&models.Project{
+		EstimationScale:   constants.EstimationScaleCustom,
+		EstimationOptions: []models.EstimateOption{{Label: "M", Points: 4}, {Label: "Huge", Points: 20}},
+	})
+
+	t.Run("should keep a label the scale has and take its points", func(t *testing.T) {
+		points, label := custom.remap(intPtr(3), strPtr("M"))
+
+		assert.Equal(t, 4, *points)
+		assert.Equal(t, "M", *label)
+	})
+
+	t.Run("should label points the scale has", func(t *testing.T) {
+		points, label := custom.remap(intPtr(20), strPtr("XXL"))
+
+		assert.Equal(t, 20, *points)
+		assert.Equal(t, "Huge", *label)
+	})
+
+	t.Run("should keep points the scale does not have", func(t *testing.T) {
+		points, label := custom.remap(intPtr(7), strPtr("XL"))
+
+		assert.Equal(t, 7, *points)
+		assert.Nil(t, label)
+	})
+
+	t.Run("should drop labels on the linear scale", func(t *testing.T) {
+		points, label := projectEstimationScale(&models.Project{}).remap(intPtr(5), strPtr("L"))
+
+		assert.Equal(t, 5, *points)
+		assert.Nil(t, label)
+	})
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+var (
+	ErrInvalidLinkType    = errors.New("link type must be blocks, is_blocked_by, relates_to, duplicates or is_duplicated_by")
+	ErrLinkTargetNotFound = errors.New("linked item not found")
+	ErrSelfLink           = errors.New("an item cannot be linked to itself")
+	ErrLinkExists         = errors.New("items are already linked with this type")
+	ErrLinkCycle          = errors.New("blocking link would create a cycle")
+	ErrLinkNotFound       = errors.New("link not found")
+)
+
+type ItemLinkService interface {
+	Create(itemID uuid.UUID, req *request.CreateItemLinkRequest, userID uuid.UUID) (*response.ItemLinkResponse, error)
+	GetByItemID(itemID uuid.UUID) ([]response.ItemLinkResponse, error)
+	Delete(itemID, linkID uuid.UUID, userID uuid.UUID) error
+}
+
+type itemLinkService struct {
+	linkRepo    repository.ItemLinkRepository
+	backlogRepo repository.BacklogRepository
+}
+
+func NewItemLinkService(linkRepo repository.ItemLinkRepository, backlogRepo repository.BacklogRepository) ItemLinkService {
+	return &itemLinkService{
+		linkRepo:    linkRepo,
+		backlogRepo: backlogRepo,
+	}
+}
+
+func (s *itemLinkService) Create(itemID uuid.UUID, req *request.CreateItemLinkRequest, userID uuid.UUID) (*response.ItemLinkResponse, error) {
+	if !req.Type.IsValid() {
+		return nil, ErrInvalidLinkType
+	}
+	if req.TargetID == itemID {
+		return nil, ErrSelfLink
+	}
+
+	item, err := s.backlogRepo.GetByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	target, err := s.backlogRepo.GetByID(req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, ErrLinkTargetNotFound
+	}
+
+	// Store inverse types in their forward form
+	link := &models.ItemLink{
+		ID:          uuid.New(),
+		SourceID:    item.ID,
+		TargetID:    target.ID,
+		Type:        req.Type,
+		CreatedByID: userID,
+		Source:      *item,
+		Target:      *target,
+	}
+	if !link.Type.IsStored() {
+		link.Type = link.Type.Inverse()
+		link.SourceID, link.TargetID = link.TargetID, link.SourceID
+		link.Source, link.Target = link.Target, link.Source
+	}
+
+	existing, err := s.linkRepo.FindBetween(link.SourceID, link.TargetID, link.Type)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrLinkExists
+	}
+
+	// The new blocker must not already be blocked by its target
+	if link.Type == constants.LinkTypeBlocks {
+		cycle, err := s.linkRepo.BlocksTransitively(link.TargetID, link.SourceID)
+		if err != nil {
+			return nil, err
+		}
+		if cycle {
+			return nil, ErrLinkCycle
+		}
+	}
+
+	histories := linkHistories(link, constants.ItemActionLinkAdded, userID)
+	if err := s.linkRepo.Create(link, histories); err != nil {
+		return nil, err
+	}
+
+	return response.ToItemLinkResponse(link, itemID), nil
+}
+
+func (s *itemLinkService) GetByItemID(itemID uuid.UUID) ([]response.ItemLinkResponse, error) {
+	item, err := s.backlogRepo.GetByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	links, err := s.linkRepo.GetByItemID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ToItemLinkListResponse(links, itemID), nil
+}
+
+func (s *itemLinkService) Delete(itemID, linkID uuid.UUID, userID uuid.UUID) error {
+	link, err := s.linkRepo.GetByID(linkID)
+	if err != nil {
+		return err
+	}
+	if link == nil || (link.SourceID != itemID && link.TargetID != itemID) {
+		return ErrLinkNotFound
+	}
+
+	histories := linkHistories(link, constants.ItemActionLinkRemoved, userID)
+	return s.linkRepo.Delete(link.ID, histories)
+}
+
+// linkHistories builds one history entry per linked item, each describing the
+// link from that item's point of view
+func linkHistories(link *models.ItemLink, action constants.ItemAction, userID uuid.UUID) []models.ItemHistory {
+	field := "link"
+	entry := func(itemID, otherID uuid.UUID, linkType constants.LinkType) models.ItemHistory {
+		value, _ := json.Marshal(map[string]interface{}{
+			"link_id": link.ID,
+			"type":    linkType,
+			"item_id": otherID,
+		})
+		history := models.ItemHistory{
+			ItemID:       itemID,
+			UserID:       userID,
+			Action:       action,
+			FieldChanged: &field,
+		}
+		if action == constants.ItemActionLinkRemoved {
+			history.OldValue = datatypes.JSON(value)
+		} else {
+			history.NewValue = datatypes.JSON(value)
+		}
+		return history
+	}
+
+	return []models.ItemHistory{
+		entry(link.SourceID, link.TargetID, link.Type),
+		entry(link.TargetID, link.SourceID, link.Type.Inverse()),
+	}
+}
+
+// unresolvedBlockers counts, per item, the blocking items whose status is not in
+// the done category of their project workflow
+func unresolvedBlockers(linkRepo repository.ItemLinkRepository, workflows *workflowCache, itemIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	links, err := linkRepo.GetBlockers(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int)
+	for _, link := range links {
+		// Skip blockers that have been deleted
+		if link.Source.ID == uuid.Nil {
+			continue
+		}
+		workflow, err := workflows.get(link.Source.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if category, _ := workflow.Category(link.Source.Status); !category.IsClosed() {
+			counts[link.TargetID]++
+		}
+	}
+	return counts, nil
+}
+
+// markBlocked sets the blocked flag on items that have an unresolved blocker
+func markBlocked(linkRepo repository.ItemLinkRepository, workflows *workflowCache, items []*response.BacklogItemResponse) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	counts, err := unresolvedBlockers(linkRepo, workflows, ids)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		item.Blocked = counts[item.ID] > 0
+	}
+	return nil
+}
+
+// blockedWarnings warns when an item that still has unresolved blockers is started,
+// i.e. moved into an in-progress status from another category
+func blockedWarnings(linkRepo repository.ItemLinkRepository, workflows *workflowCache, item *models.BacklogItem, status constants.ItemStatus) ([]string, error) {
+	workflow, err := workflows.get(item.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	from, _ := workflow.Category(item.Status)
+	to, _ := workflow.Category(status)
+	if to != constants.StatusCategoryInProgress || from == constants.StatusCategoryInProgress {
+		return nil, nil
+	}
+
+	counts, err := unresolvedBlockers(linkRepo, workflows, []uuid.UUID{item.ID})
+	if err != nil {
+		return nil, err
+	}
+	if n := counts[item.ID]; n > 0 {
+		return []string{fmt.Sprintf("item was started while blocked by %d unresolved item(s)", n)}, nil
+	}
+	return nil, nil
+}
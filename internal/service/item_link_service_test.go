@@ -0,0 +1,224 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// MockItemLinkRepository is a mock implementation of ItemLinkRepository
+type MockItemLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemLinkRepository) Create(link *models.ItemLink, histories []models.ItemHistory) error {
+	args := m.Called(link, histories)
+	return args.Error(0)
+}
+
+func (m *MockItemLinkRepository) GetByID(id uuid.UUID) (*models.ItemLink, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ItemLink), args.Error(1)
+}
+
+func (m *MockItemLinkRepository) GetByItemID(itemID uuid.UUID) ([]models.ItemLink, error) {
+	args := m.Called(itemID)
+	return args.Get(0).([]models.ItemLink), args.Error(1)
+}
+
+func (m *MockItemLinkRepository) Delete(id uuid.UUID, histories []models.ItemHistory) error {
+	args := m.Called(id, histories)
+	return args.Error(0)
+}
+
+func (m *MockItemLinkRepository) FindBetween(itemID, otherID uuid.UUID, linkType constants.LinkType) (*models.ItemLink, error) {
+	args := m.Called(itemID, otherID, linkType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ItemLink), args.Error(1)
+}
+
+func (m *MockItemLinkRepository) BlocksTransitively(fromID, toID uuid.UUID) (bool, error) {
+	args := m.Called(fromID, toID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockItemLinkRepository) GetBlockers(itemIDs []uuid.UUID) ([]models.ItemLink, error) {
+	args := m.Called(itemIDs)
+	return args.Get(0).([]models.ItemLink), args.Error(1)
+}
+
+func TestItemLinkService_Create(t *testing.T) {
+	t.Run("should store is_blocked_by as a reversed blocks link", func(t *testing.T) {
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewItemLinkService(mockLinkRepo, mockBacklogRepo)
+
+		item := &models.BacklogItem{ID: uuid.New(), Title: "Blocked"}
+		blocker := &models.BacklogItem{ID: uuid.New(), Title: "Blocker"}
+		userID := uuid.New()
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockBacklogRepo.On("GetByID", blocker.ID).Return(blocker, nil)
+		mockLinkRepo.On("FindBetween", blocker.ID, item.ID, constants.LinkTypeBlocks).Return(nil, nil)
+		mockLinkRepo.On("BlocksTransitively", item.ID, blocker.ID).Return(false, nil)
+		mockLinkRepo.On("Create", mock.MatchedBy(func(link *models.ItemLink) bool {
+			return link.SourceID == blocker.ID && link.TargetID == item.ID && link.Type == constants.LinkTypeBlocks
+		}), mock.MatchedBy(func(histories []models.ItemHistory) bool {
+			return len(histories) == 2 &&
+				histories[0].ItemID == blocker.ID && histories[1].ItemID == item.ID &&
+				histories[0].Action == constants.ItemActionLinkAdded
+		})).Return(nil)
+
+		result, err := service.Create(item.ID, &request.CreateItemLinkRequest{
+			TargetID: blocker.ID,
+			Type:     constants.LinkTypeIsBlockedBy,
+		}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.LinkTypeIsBlockedBy, result.Type)
+		assert.Equal(t, blocker.ID, result.Item.ID)
+		mockLinkRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a blocking cycle", func(t *testing.T) {
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewItemLinkService(mockLinkRepo, mockBacklogRepo)
+
+		item := &models.BacklogItem{ID: uuid.New()}
+		target := &models.BacklogItem{ID: uuid.New()}
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockBacklogRepo.On("GetByID", target.ID).Return(target, nil)
+		mockLinkRepo.On("FindBetween", item.ID, target.ID, constants.LinkTypeBlocks).Return(nil, nil)
+		mockLinkRepo.On("BlocksTransitively", target.ID, item.ID).Return(true, nil)
+
+		result, err := service.Create(item.ID, &request.CreateItemLinkRequest{
+			TargetID: target.ID,
+			Type:     constants.LinkTypeBlocks,
+		}, uuid.New())
+
+		assert.Equal(t, ErrLinkCycle, err)
+		assert.Nil(t, result)
+		mockLinkRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject duplicate links", func(t *testing.T) {
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewItemLinkService(mockLinkRepo, mockBacklogRepo)
+
+		item := &models.BacklogItem{ID: uuid.New()}
+		target := &models.BacklogItem{ID: uuid.New()}
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockBacklogRepo.On("GetByID", target.ID).Return(target, nil)
+		mockLinkRepo.On("FindBetween", item.ID, target.ID, constants.LinkTypeRelatesTo).
+			Return(&models.ItemLink{ID: uuid.New()}, nil)
+
+		_, err := service.Create(item.ID, &request.CreateItemLinkRequest{
+			TargetID: target.ID,
+			Type:     constants.LinkTypeRelatesTo,
+		}, uuid.New())
+
+		assert.Equal(t, ErrLinkExists, err)
+	})
+
+	t.Run("should reject self links", func(t *testing.T) {
+		service := NewItemLinkService(new(MockItemLinkRepository), new(MockBacklogRepository))
+		itemID := uuid.New()
+
+		_, err := service.Create(itemID, &request.CreateItemLinkRequest{
+			TargetID: itemID,
+			Type:     constants.LinkTypeRelatesTo,
+		}, uuid.New())
+
+		assert.Equal(t, ErrSelfLink, err)
+	})
+}
+
+func TestItemLinkService_Delete(t *testing.T) {
+	t.Run("should return not found for a link on another item", func(t *testing.T) {
+		mockLinkRepo := new(MockItemLinkRepository)
+		service := NewItemLinkService(mockLinkRepo, new(MockBacklogRepository))
+
+		link := &models.ItemLink{ID: uuid.New(), SourceID: uuid.New(), TargetID: uuid.New(), Type: constants.LinkTypeBlocks}
+		mockLinkRepo.On("GetByID", link.ID).Return(link, nil)
+
+		err := service.Delete(uuid.New(), link.ID, uuid.New())
+
+		assert.Equal(t, ErrLinkNotFound, err)
+		mockLinkRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMarkBlocked(t *testing.T) {
+	t.Run("should flag items with unresolved blockers only", func(t *testing.T) {
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		projectID := uuid.New()
+
+		blocked := &response.BacklogItemResponse{ID: uuid.New()}
+		resolved := &response.BacklogItemResponse{ID: uuid.New()}
+		free := &response.BacklogItemResponse{ID: uuid.New()}
+
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(nil, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{blocked.ID, resolved.ID, free.ID}).Return([]models.ItemLink{
+			{TargetID: blocked.ID, Source: models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: constants.ItemStatusInProgress}},
+			{TargetID: resolved.ID, Source: models.BacklogItem{ID: uuid.New(), ProjectID: projectID, Status: constants.ItemStatusDone}},
+		}, nil)
+
+		err := markBlocked(mockLinkRepo, newWorkflowCache(mockWorkflowRepo), []*response.BacklogItemResponse{blocked, resolved, free})
+
+		assert.NoError(t, err)
+		assert.True(t, blocked.Blocked)
+		assert.False(t, resolved.Blocked)
+		assert.False(t, free.Blocked)
+		mockWorkflowRepo.AssertNumberOfCalls(t, "GetByProjectID", 1)
+	})
+}
+
+func TestBlockedWarnings(t *testing.T) {
+	t.Run("should warn when a blocked item is started", func(t *testing.T) {
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Status: constants.ItemStatusReady}
+
+		mockWorkflowRepo.On("GetByProjectID", item.ProjectID).Return(nil, nil)
+		mockLinkRepo.On("GetBlockers", []uuid.UUID{item.ID}).Return([]models.ItemLink{
+			{TargetID: item.ID, Source: models.BacklogItem{ID: uuid.New(), ProjectID: item.ProjectID, Status: constants.ItemStatusNew}},
+		}, nil)
+
+		warnings, err := blockedWarnings(mockLinkRepo, newWorkflowCache(mockWorkflowRepo), item, constants.ItemStatusInProgress)
+
+		assert.NoError(t, err)
+		assert.Len(t, warnings, 1)
+	})
+
+	t.Run("should not check blockers when the item is not started", func(t *testing.T) {
+		mockLinkRepo := new(MockItemLinkRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Status: constants.ItemStatusNew}
+		mockWorkflowRepo.On("GetByProjectID", item.ProjectID).Return(nil, nil)
+
+		warnings, err := blockedWarnings(mockLinkRepo, newWorkflowCache(mockWorkflowRepo), item, constants.ItemStatusReady)
+
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+		mockLinkRepo.AssertNotCalled(t, "GetBlockers", mock.Anything)
+	})
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+)
+
+var (
+	ErrTemplateNotFound     = errors.New("item template not found")
+	ErrTemplateExists       = errors.New("an item template with this name already exists")
+	ErrTemplateNotInProject = errors.New("item template belongs to a different project")
+)
+
+type ItemTemplateService interface {
+	Create(projectID uuid.UUID, req *request.CreateItemTemplateRequest, userID uuid.UUID) (*response.ItemTemplateResponse, error)
+	GetByProjectID(projectID uuid.UUID) ([]response.ItemTemplateResponse, error)
+	GetByID(id uuid.UUID) (*response.ItemTemplateResponse, error)
+	Update(id uuid.UUID, req *request.UpdateItemTemplateRequest) (*response.ItemTemplateResponse, error)
+	Delete(id uuid.UUID) error
+}
+
+type itemTemplateService struct {
+	templateRepo repository.ItemTemplateRepository
+	projectRepo  repository.ProjectRepository
+}
+
+func NewItemTemplateService(templateRepo repository.ItemTemplateRepository, projectRepo repository.ProjectRepository) ItemTemplateService {
+	return &itemTemplateService{
+		templateRepo: templateRepo,
+		projectRepo:  projectRepo,
+	}
+}
+
+func (s *itemTemplateService) Create(projectID uuid.UUID, req *request.CreateItemTemplateRequest, userID uuid.UUID) (*response.ItemTemplateResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	template := &models.ItemTemplate{
+		ProjectID:   projectID,
+		CreatedByID: userID,
+	}
+	if err := s.apply(template, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, err
+	}
+	return response.ToItemTemplateResponse(template), nil
+}
+
+func (s *itemTemplateService) GetByProjectID(projectID uuid.UUID) ([]response.ItemTemplateResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	templates, err := s.templateRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return response.ToItemTemplateListResponse(templates), nil
+}
+
+func (s *itemTemplateService) GetByID(id uuid.UUID) (*response.ItemTemplateResponse, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrTemplateNotFound
+	}
+	return response.ToItemTemplateResponse(template), nil
+}
+
+func (s *itemTemplateService) Update(id uuid.UUID, req *request.UpdateItemTemplateRequest) (*response.ItemTemplateResponse, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	if err := s.apply(template, (*request.CreateItemTemplateRequest)(req)); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Update(template); err != nil {
+		return nil, err
+	}
+	return response.ToItemTemplateResponse(template), nil
+}
+
+func (s *itemTemplateService) Delete(id uuid.UUID) error {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if template == nil {
+		return ErrTemplateNotFound
+	}
+	return s.templateRepo.Delete(id)
+}
+
+// apply validates req and copies it onto template, replacing every field.
+// Child types must fit under the template's type so that items created
+// from it always form a valid hierarchy.
+func (s *itemTemplateService) apply(template *models.ItemTemplate, req *request.CreateItemTemplateRequest) error {
+	if !req.Type.IsValid() {
+		return ErrInvalidItemType
+	}
+	if req.Priority != "" && !req.Priority.IsValid() {
+		return ErrInvalidPriority
+	}
+
+	children := make([]models.TemplateChild, len(req.Children))
+	for i, child := range req.Children {
+		if !child.Type.IsValid() {
+			return ErrInvalidItemType
+		}
+		if child.Priority != "" && !child.Priority.IsValid() {
+			return ErrInvalidPriority
+		}
+		if !req.Type.CanParent(child.Type) {
+			return ErrInvalidParentType
+		}
+		children[i] = models.TemplateChild{
+			Title:       strings.TrimSpace(child.Title),
+			Type:        child.Type,
+			Priority:    child.Priority,
+			Description: strings.TrimSpace(child.Description),
+			StoryPoints: child.StoryPoints,
+			Labels:      child.Labels,
+		}
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name != template.Name {
+		existing, err := s.templateRepo.FindByName(template.ProjectID, name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return ErrTemplateExists
+		}
+	}
+
+	template.Name = name
+	template.Type = req.Type
+	template.Priority = req.Priority
+	template.Description = nil
+	if desc := strings.TrimSpace(req.Description); desc != "" {
+		template.Description = &desc
+	}
+	template.StoryPoints = req.StoryPoints
+	template.Labels = req.Labels
+	template.Children = children
+	return nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+// MockItemTemplateRepository is a mock implementation of ItemTemplateRepository
+type MockItemTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemTemplateRepository) Create(template *models.ItemTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockItemTemplateRepository) GetByID(id uuid.UUID) (*models.ItemTemplate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ItemTemplate), args.Error(1)
+}
+
+func (m *MockItemTemplateRepository) GetByProjectID(projectID uuid.UUID) ([]models.ItemTemplate, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]models.ItemTemplate), args.Error(1)
+}
+
+func (m *MockItemTemplateRepository) FindByName(projectID uuid.UUID, name string) (*models.ItemTemplate, error) {
+	args := m.Called(projectID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ItemTemplate), args.Error(1)
+}
+
+func (m *MockItemTemplateRepository) Update(template *models.ItemTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockItemTemplateRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestItemTemplateService_Create(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("should save a template with child items", func(t *testing.T) {
+		mockTemplateRepo := new(MockItemTemplateRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewItemTemplateService(mockTemplateRepo, mockProjectRepo)
+
+		points := 3
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockTemplateRepo.On("FindByName", projectID, "Bug report").Return(nil, nil)
+		mockTemplateRepo.On("Create", mock.MatchedBy(func(tpl *models.ItemTemplate) bool {
+			return tpl.Name == "Bug report" && tpl.ProjectID == projectID && tpl.CreatedByID == userID &&
+				tpl.Type == constants.ItemTypeBug && *tpl.Description == "## Steps to reproduce" &&
+				len(tpl.Children) == 1 && tpl.Children[0].Title == "Write regression test"
+		})).Return(nil)
+
+		result, err := service.Create(projectID, &request.CreateItemTemplateRequest{
+			Name:        " Bug report ",
+			Type:        constants.ItemTypeBug,
+			Priority:    constants.PriorityHigh,
+			Description: "## Steps to reproduce\n",
+			StoryPoints: &points,
+			Labels:      []string{"bug"},
+			Children: []request.TemplateChildRequest{
+				{Title: " Write regression test ", Type: constants.ItemTypeSubtask},
+			},
+		}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Bug report", result.Name)
+		assert.Len(t, result.Children, 1)
+		assert.Equal(t, constants.ItemTypeSubtask, result.Children[0].Type)
+		mockTemplateRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a child type the template type cannot hold", func(t *testing.T) {
+		mockTemplateRepo := new(MockItemTemplateRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewItemTemplateService(mockTemplateRepo, mockProjectRepo)
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+
+		result, err := service.Create(projectID, &request.CreateItemTemplateRequest{
+			Name: "Epic",
+			Type: constants.ItemTypeEpic,
+			Children: []request.TemplateChildRequest{
+				{Title: "Subtask", Type: constants.ItemTypeSubtask},
+			},
+		}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrInvalidParentType, err)
+		mockTemplateRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("should reject an invalid priority", func(t *testing.T) {
+		mockTemplateRepo := new(MockItemTemplateRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewItemTemplateService(mockTemplateRepo, mockProjectRepo)
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+
+		result, err := service.Create(projectID, &request.CreateItemTemplateRequest{
+			Name:     "Story",
+			Type:     constants.ItemTypeStory,
+			Priority: "urgent",
+		}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrInvalidPriority, err)
+	})
+
+	t.Run("should reject a duplicate name", func(t *testing.T) {
+		mockTemplateRepo := new(MockItemTemplateRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewItemTemplateService(mockTemplateRepo, mockProjectRepo)
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockTemplateRepo.On("FindByName", projectID, "Story").Return(&models.ItemTemplate{ID: uuid.New()}, nil)
+
+		result, err := service.Create(projectID, &request.CreateItemTemplateRequest{
+			Name: "Story",
+			Type: constants.ItemTypeStory,
+		}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrTemplateExists, err)
+		mockTemplateRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestItemTemplateService_Update(t *testing.T) {
+	t.Run("should keep its own name without a duplicate check", func(t *testing.T) {
+		mockTemplateRepo := new(MockItemTemplateRepository)
+		service := NewItemTemplateService(mockTemplateRepo, nil)
+
+		template := &models.ItemTemplate{ID: uuid.New(), ProjectID: uuid.New(), Name: "Story", Type: constants.ItemTypeStory}
+		mockTemplateRepo.On("GetByID", template.ID).Return(template, nil)
+		mockTemplateRepo.On("Update", template).Return(nil)
+
+		result, err := service.Update(template.ID, &request.UpdateItemTemplateRequest{
+			Name: "Story",
+			Type: constants.ItemTypeBug,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ItemTypeBug, result.Type)
+		mockTemplateRepo.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+)
+
+var (
+	ErrLabelNotFound     = errors.New("label not found")
+	ErrLabelExists       = errors.New("a label with this name already exists")
+	ErrLabelNotInCatalog = errors.New("label is not in the project's label catalog")
+	ErrLabelMergeSelf    = errors.New("labels must be merged into a different label")
+)
+
+// DefaultLabelColor is used for catalog labels created without a color
+const DefaultLabelColor = "#6b7280"
+
+// defaultLabelLimit caps the labels returned for autocomplete
+const defaultLabelLimit = 20
+
+type LabelService interface {
+	Create(projectID uuid.UUID, req *request.CreateLabelRequest, userID uuid.UUID) (*response.LabelResponse, error)
+	GetByProjectID(projectID uuid.UUID, params *request.LabelQueryParams) ([]response.LabelResponse, error)
+	Update(id uuid.UUID, req *request.UpdateLabelRequest, userID uuid.UUID) (*response.LabelChangeResponse, error)
+	Delete(id uuid.UUID) error
+	Merge(projectID uuid.UUID, req *request.MergeLabelsRequest, userID uuid.UUID) (*response.LabelChangeResponse, error)
+}
+
+type labelService struct {
+	labelRepo   repository.LabelRepository
+	projectRepo repository.ProjectRepository
+}
+
+func NewLabelService(labelRepo repository.LabelRepository, projectRepo repository.ProjectRepository) LabelService {
+	return &labelService{
+		labelRepo:   labelRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (s *labelService) Create(projectID uuid.UUID, req *request.CreateLabelRequest, userID uuid.UUID) (*response.LabelResponse, error) {
+	if err := s.ensureProject(projectID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrEmptyLabel
+	}
+	existing, err := s.labelRepo.FindByName(projectID, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrLabelExists
+	}
+
+	label := &models.Label{
+		ProjectID:   projectID,
+		Name:        name,
+		Color:       strings.ToLower(req.Color),
+		CreatedByID: userID,
+	}
+	if label.Color == "" {
+		label.Color = DefaultLabelColor
+	}
+	if desc := strings.TrimSpace(req.Description); desc != "" {
+		label.Description = &desc
+	}
+
+	if err := s.labelRepo.Create(label); err != nil {
+		return nil, err
+	}
+	return response.ToLabelResponse(label, 0), nil
+}
+
+// GetByProjectID lists the catalog together with the labels used on items but
+// missing from it, each with the number of live items using it. A query keeps the
+// labels starting with it, ignoring case, for autocomplete. The most used labels
+// come first.
+func (s *labelService) GetByProjectID(projectID uuid.UUID, params *request.LabelQueryParams) ([]response.LabelResponse, error) {
+	if err := s.ensureProject(projectID); err != nil {
+		return nil, err
+	}
+
+	catalog, err := s.labelRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.labelRepo.GetUsage(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(usage))
+	for _, u := range usage {
+		counts[u.Name] = u.Count
+	}
+
+	labels := make([]response.LabelResponse, 0, len(catalog)+len(usage))
+	for i := range catalog {
+		labels = append(labels, *response.ToLabelResponse(&catalog[i], counts[catalog[i].Name]))
+		delete(counts, catalog[i].Name)
+	}
+	for name, count := range counts {
+		labels = append(labels, response.LabelResponse{Name: name, UsageCount: count})
+	}
+
+	prefix := strings.ToLower(strings.TrimSpace(params.Query))
+	if prefix != "" {
+		matching := labels[:0]
+		for _, label := range labels {
+			if strings.HasPrefix(strings.ToLower(label.Name), prefix) {
+				matching = append(matching, label)
+			}
+		}
+		labels = matching
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].UsageCount != labels[j].UsageCount {
+			return labels[i].UsageCount > labels[j].UsageCount
+		}
+		return labels[i].Name < labels[j].Name
+	})
+
+	limit := params.Limit
+	if limit == 0 && prefix != "" {
+		limit = defaultLabelLimit
+	}
+	if limit > 0 && len(labels) > limit {
+		labels = labels[:limit]
+	}
+	return labels, nil
+}
+
+// Update changes a catalog label. Renaming it rewrites the label on every item and
+// template of the project; a name another catalog label already has is rejected,
+// since that is a merge.
+func (s *labelService) Update(id uuid.UUID, req *request.UpdateLabelRequest, userID uuid.UUID) (*response.LabelChangeResponse, error) {
+	label, err := s.labelRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if label == nil {
+		return nil, ErrLabelNotFound
+	}
+
+	if req.Color != "" {
+		label.Color = strings.ToLower(req.Color)
+	}
+	if req.Description != nil {
+		label.Description = nil
+		if desc := strings.TrimSpace(*req.Description); desc != "" {
+			label.Description = &desc
+		}
+	}
+
+	oldName := label.Name
+	name := strings.TrimSpace(req.Name)
+	if name == "" || name == oldName {
+		if err := s.labelRepo.Update(label); err != nil {
+			return nil, err
+		}
+		return &response.LabelChangeResponse{Label: response.ToLabelResponse(label, 0)}, nil
+	}
+
+	existing, err := s.labelRepo.FindByName(label.ProjectID, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != label.ID {
+		return nil, ErrLabelExists
+	}
+
+	label.Name = name
+	changed, err := s.labelRepo.Rename(label, oldName, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.LabelChangeResponse{
+		Label:        response.ToLabelResponse(label, 0),
+		ItemsUpdated: changed,
+	}, nil
+}
+
+// Delete removes a label from the catalog. Items keep it as a label outside the
+// catalog.
+func (s *labelService) Delete(id uuid.UUID) error {
+	label, err := s.labelRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if label == nil {
+		return ErrLabelNotFound
+	}
+	return s.labelRepo.Delete(id)
+}
+
+// Merge replaces the source labels with the target catalog label on every item and
+// template of the project. Sources that are in the catalog are removed from it.
+func (s *labelService) Merge(projectID uuid.UUID, req *request.MergeLabelsRequest, userID uuid.UUID) (*response.LabelChangeResponse, error) {
+	if err := s.ensureProject(projectID); err != nil {
+		return nil, err
+	}
+
+	target, err := s.labelRepo.FindByName(projectID, strings.TrimSpace(req.Target))
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, ErrLabelNotFound
+	}
+
+	// Match the sources as given and as the catalog spells them
+	var sources []string
+	seen := map[string]bool{target.Name: true}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			sources = append(sources, name)
+		}
+	}
+	for _, source := range req.Sources {
+		source = strings.TrimSpace(source)
+		add(source)
+		label, err := s.labelRepo.FindByName(projectID, source)
+		if err != nil {
+			return nil, err
+		}
+		if label != nil {
+			add(label.Name)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, ErrLabelMergeSelf
+	}
+
+	changed, err := s.labelRepo.Merge(target, sources, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.LabelChangeResponse{
+		Label:        response.ToLabelResponse(target, 0),
+		ItemsUpdated: changed,
+	}, nil
+}
+
+func (s *labelService) ensureProject(projectID uuid.UUID) error {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+// labelCatalog resolves the labels written to items against a project's catalog
+type labelCatalog struct {
+	strict bool
+	// names maps the lower-cased name of each catalog label to its spelling
+	names map[string]string
+}
+
+func loadLabelCatalog(projectRepo repository.ProjectRepository, labelRepo repository.LabelRepository, projectID uuid.UUID) (*labelCatalog, error) {
+	project, err := projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	labels, err := labelRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &labelCatalog{strict: project.StrictLabels, names: make(map[string]string, len(labels))}
+	for _, label := range labels {
+		catalog.names[strings.ToLower(label.Name)] = label.Name
+	}
+	return catalog, nil
+}
+
+// resolve trims the labels, spells catalog labels the way the catalog does and
+// drops duplicates. With strict labels, labels outside the catalog are rejected.
+func (c *labelCatalog) resolve(labels []string) ([]string, error) {
+	resolved := make([]string, 0, len(labels))
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return nil, ErrEmptyLabel
+		}
+		if name, ok := c.names[strings.ToLower(label)]; ok {
+			label = name
+		} else if c.strict {
+			return nil, fmt.Errorf("%w: %s", ErrLabelNotInCatalog, label)
+		}
+		if !seen[label] {
+			seen[label] = true
+			resolved = append(resolved, label)
+		}
+	}
+	return resolved, nil
+}
+
+// labelCatalogCache loads each project's label catalog at most once
+type labelCatalogCache struct {
+	projectRepo repository.ProjectRepository
+	labelRepo   repository.LabelRepository
+	catalogs    map[uuid.UUID]*labelCatalog
+}
+
+func newLabelCatalogCache(projectRepo repository.ProjectRepository, labelRepo repository.LabelRepository) *labelCatalogCache {
+	return &labelCatalogCache{projectRepo: projectRepo, labelRepo: labelRepo, catalogs: make(map[uuid.UUID]*labelCatalog)}
+}
+
+func (c *labelCatalogCache) get(projectID uuid.UUID) (*labelCatalog, error) {
+	if catalog, ok := c.catalogs[projectID]; ok {
+		return catalog, nil
+	}
+	catalog, err := loadLabelCatalog(c.projectRepo, c.labelRepo, projectID)
+	if err != nil {
+		return nil, err
+	}
+	c.catalogs[projectID] = catalog
+	return catalog, nil
+}
+
+// resolve checks labels against the project's catalog, loading it only when there
+// are labels to check. A nil slice stays nil.
+func (c *labelCatalogCache) resolve(projectID uuid.UUID, labels []string) ([]string, error) {
+	if len(labels) == 0 {
+		return labels, nil
+	}
+	catalog, err := c.get(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return catalog.resolve(labels)
+}
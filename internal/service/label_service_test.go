@@ -0,0 +1,259 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+)
+
+// MockLabelRepository is a mock implementation of LabelRepository
+type MockLabelRepository struct {
+	mock.Mock
+}
+
+func (m *MockLabelRepository) Create(label *models.Label) error {
+	args := m.Called(label)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) GetByID(id uuid.UUID) (*models.Label, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetByProjectID(projectID uuid.UUID) ([]models.Label, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]models.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) FindByName(projectID uuid.UUID, name string) (*models.Label, error) {
+	args := m.Called(projectID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetUsage(projectID uuid.UUID) ([]repository.LabelUsage, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]repository.LabelUsage), args.Error(1)
+}
+
+func (m *MockLabelRepository) Update(label *models.Label) error {
+	args := m.Called(label)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) Rename(label *models.Label, oldName string, userID uuid.UUID) (int, error) {
+	args := m.Called(label, oldName, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLabelRepository) Merge(target *models.Label, sources []string, userID uuid.UUID) (int, error) {
+	args := m.Called(target, sources, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLabelRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestLabelService_Create(t *testing.T) {
+	projectID := uuid.New()
+
+	t.Run("should save a label with the default color", func(t *testing.T) {
+		mockLabelRepo := new(MockLabelRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewLabelService(mockLabelRepo, mockProjectRepo)
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockLabelRepo.On("FindByName", projectID, "bug").Return(nil, nil)
+		mockLabelRepo.On("Create", mock.MatchedBy(func(l *models.Label) bool {
+			return l.Name == "bug" && l.Color == DefaultLabelColor && l.Description == nil
+		})).Return(nil)
+
+		result, err := service.Create(projectID, &request.CreateLabelRequest{Name: " bug "}, uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "bug", result.Name)
+		assert.True(t, result.Managed)
+		mockLabelRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a name the catalog has in another case", func(t *testing.T) {
+		mockLabelRepo := new(MockLabelRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewLabelService(mockLabelRepo, mockProjectRepo)
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockLabelRepo.On("FindByName", projectID, "Bug").Return(&models.Label{ID: uuid.New(), Name: "bug"}, nil)
+
+		result, err := service.Create(projectID, &request.CreateLabelRequest{Name: "Bug", Color: "#FF0000"}, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrLabelExists, err)
+		mockLabelRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestLabelService_GetByProjectID(t *testing.T) {
+	projectID := uuid.New()
+
+	t.Run("should combine catalog and usage, most used first", func(t *testing.T) {
+		mockLabelRepo := new(MockLabelRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewLabelService(mockLabelRepo, mockProjectRepo)
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockLabelRepo.On("GetByProjectID", projectID).Return([]models.Label{
+			{ID: uuid.New(), Name: "backend", Color: "#00ff00"},
+			{ID: uuid.New(), Name: "bug", Color: "#ff0000"},
+		}, nil)
+		mockLabelRepo.On("GetUsage", projectID).Return([]repository.LabelUsage{
+			{Name: "bug", Count: 7},
+			{Name: "bgu", Count: 1},
+			{Name: "frontend", Count: 3},
+		}, nil)
+
+		result, err := service.GetByProjectID(projectID, &request.LabelQueryParams{Query: "B"})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 3)
+		assert.Equal(t, "bug", result[0].Name)
+		assert.Equal(t, int64(7), result[0].UsageCount)
+		assert.True(t, result[0].Managed)
+		assert.Equal(t, "bgu", result[1].Name)
+		assert.False(t, result[1].Managed)
+		assert.Nil(t, result[1].ID)
+		assert.Equal(t, "backend", result[2].Name)
+		assert.Equal(t, int64(0), result[2].UsageCount)
+	})
+}
+
+func TestLabelService_Update(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("should rename the label on items", func(t *testing.T) {
+		mockLabelRepo := new(MockLabelRepository)
+		service := NewLabelService(mockLabelRepo, nil)
+
+		label := &models.Label{ID: uuid.New(), ProjectID: projectID, Name: "bgu", Color: "#ff0000"}
+		mockLabelRepo.On("GetByID", label.ID).Return(label, nil)
+		mockLabelRepo.On("FindByName", projectID, "bug").Return(nil, nil)
+		mockLabelRepo.On("Rename", mock.MatchedBy(func(l *models.Label) bool {
+			return l.Name == "bug" && l.Color == "#00ff00"
+		}), "bgu", userID).Return(4, nil)
+
+		result, err := service.Update(label.ID, &request.UpdateLabelRequest{Name: "bug", Color: "#00FF00"}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, result.ItemsUpdated)
+		assert.Equal(t, "bug", result.Label.Name)
+		mockLabelRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("should rename a label to another case of its own name", func(t *testing.T) {
+		mockLabelRepo := new(MockLabelRepository)
+		service := NewLabelService(mockLabelRepo, nil)
+
+		label := &models.Label{ID: uuid.New(), ProjectID: projectID, Name: "bug"}
+		mockLabelRepo.On("GetByID", label.ID).Return(label, nil)
+		mockLabelRepo.On("FindByName", projectID, "Bug").Return(label, nil)
+		mockLabelRepo.On("Rename", label, "bug", userID).Return(2, nil)
+
+		result, err := service.Update(label.ID, &request.UpdateLabelRequest{Name: "Bug"}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.ItemsUpdated)
+	})
+
+	t.Run("should reject renaming onto another catalog label", func(t *testing.T) {
+		mockLabelRepo := new(MockLabelRepository)
+		service := NewLabelService(mockLabelRepo, nil)
+
+		label := &models.Label{ID: uuid.New(), ProjectID: projectID, Name: "bgu"}
+		mockLabelRepo.On("GetByID", label.ID).Return(label, nil)
+		mockLabelRepo.On("FindByName", projectID, "bug").Return(&models.Label{ID: uuid.New(), Name: "bug"}, nil)
+
+		result, err := service.Update(label.ID, &request.UpdateLabelRequest{Name: "bug"}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrLabelExists, err)
+		mockLabelRepo.AssertNotCalled(t, "Rename", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestLabelService_Merge(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("should merge sources as given and as catalogued", func(t *testing.T) {
+		mockLabelRepo := new(MockLabelRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewLabelService(mockLabelRepo, mockProjectRepo)
+
+		target := &models.Label{ID: uuid.New(), ProjectID: projectID, Name: "bug"}
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockLabelRepo.On("FindByName", projectID, "bug").Return(target, nil)
+		mockLabelRepo.On("FindByName", projectID, "defect").Return(&models.Label{ID: uuid.New(), Name: "Defect"}, nil)
+		mockLabelRepo.On("FindByName", projectID, "bgu").Return(nil, nil)
+		mockLabelRepo.On("Merge", target, []string{"defect", "Defect", "bgu"}, userID).Return(5, nil)
+
+		result, err := service.Merge(projectID, &request.MergeLabelsRequest{
+			Sources: []string{"defect", "bgu", "bug"},
+			Target:  "bug",
+		}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, result.ItemsUpdated)
+		mockLabelRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject merging a label into itself", func(t *testing.T) {
+		mockLabelRepo := new(MockLabelRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewLabelService(mockLabelRepo, mockProjectRepo)
+
+		target := &models.Label{ID: uuid.New(), ProjectID: projectID, Name: "bug"}
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockLabelRepo.On("FindByName", projectID, "bug").Return(target, nil)
+
+		result, err := service.Merge(projectID, &request.MergeLabelsRequest{Sources: []string{"bug"}, Target: "bug"}, userID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrLabelMergeSelf, err)
+	})
+}
+
+func TestLabelCatalog_Resolve(t *testing.T) {
+	catalog := &labelCatalog{names: map[string]string{"bug": "bug", "ux": "UX"}}
+
+	t.Run("should use catalog spelling and drop duplicates", func(t *testing.T) {
+		labels, err := catalog.resolve([]string{" Bug ", "ux", "bug", "custom"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"bug", "UX", "custom"}, labels)
+	})
+
+	t.Run("should reject labels outside a strict catalog", func(t *testing.T) {
+		strict := &labelCatalog{strict: true, names: catalog.names}
+
+		labels, err := strict.resolve([]string{"bug", "custom"})
+
+		assert.Nil(t, labels)
+		assert.True(t, errors.Is(err, ErrLabelNotInCatalog))
+		assert.Contains(t, err.Error(), "custom")
+	})
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/cursor"
+	"sprint-backlog/pkg/query"
+)
+
+// ErrInvalidSort wraps syntax and field errors in sort params
+var ErrInvalidSort = errors.New("invalid sort")
+
+// ErrInvalidCursor is returned when a pagination cursor is malformed or was issued
+// for another list or ordering
+var ErrInvalidCursor = cursor.ErrInvalid
+
+// includeTotal reports whether a list should count all its rows. Counting is the
+// default for numbered pages, for compatibility, and opt-in when paging by cursor.
+func includeTotal(requested *bool, cursor string) bool {
+	if requested != nil {
+		return *requested
+	}
+	return cursor == ""
+}
+
+// parseSort parses a sort param such as "priority:desc,title" and checks its fields
+// with validate. An empty param returns no terms, keeping the list's default order.
+func parseSort(spec string, validate func([]query.OrderTerm) error) ([]query.OrderTerm, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	terms, err := query.ParseSort(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSort, err)
+	}
+	if err := validate(terms); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSort, err)
+	}
+	return terms, nil
+}
+
+// defaultHistoryLimit is the page size of history lists paged by cursor alone
+const defaultHistoryLimit = 50
+
+// historyLimit sets the default page size on a paginated history request and returns
+// the page size and the number of entries to fetch for it, which is one more so that
+// a following page can be detected. Both are 0 when the whole history was requested.
+func historyLimit(params *request.HistoryQueryParams) (int, int) {
+	if !params.Paginated() {
+		return 0, 0
+	}
+	if params.Limit < 1 {
+		params.Limit = defaultHistoryLimit
+	}
+	return params.Limit, params.Limit + 1
+}
+
+// historyPage trims a history list fetched with one entry more than the limit and
+// returns the cursor continuing after the last kept entry, or "" on the last page
+func historyPage[T any](entries []T, limit int, position func(T) (time.Time, uuid.UUID)) ([]T, string) {
+	if limit < 1 || len(entries) <= limit {
+		return entries, ""
+	}
+	entries = entries[:limit]
+	timestamp, id := position(entries[limit-1])
+	return entries, repository.HistoryCursor(timestamp, id)
+}
@@ -153,6 +153,9 @@ func (s *projectService) Update(id uuid.UUID, req *request.UpdateProjectRequest)
 		desc := strings.TrimSpace(req.Description)
 		project.Description = &desc
 	}
+	if req.StrictLabels != nil {
+		project.StrictLabels = *req.StrictLabels
+	}
 
 	if err := s.projectRepo.Update(project); err != nil {
 		return nil, err
@@ -10,6 +10,7 @@ import (
 
 	"sprint-backlog/internal/dto/request"
 	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
 )
 
 // MockProjectRepository is a mock implementation of ProjectRepository
@@ -58,6 +59,11 @@ func (m *MockProjectRepository) Delete(id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockProjectRepository) UpdateEstimationScale(project *models.Project, mapEstimate repository.EstimateMapper, userID uuid.UUID) (int, error) {
+	args := m.Called(project, mapEstimate, userID)
+	return args.Int(0), args.Error(1)
+}
+
 func TestProjectService_Create(t *testing.T) {
 	t.Run("should create project successfully", func(t *testing.T) {
 		mockRepo := new(MockProjectRepository)
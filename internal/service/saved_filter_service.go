@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+)
+
+var (
+	ErrSavedFilterNotFound  = errors.New("saved filter not found")
+	ErrSavedFilterExists    = errors.New("a saved filter with this name already exists")
+	ErrSavedFilterForbidden = errors.New("only the owner can change a saved filter")
+)
+
+type SavedFilterService interface {
+	Create(projectID uuid.UUID, req *request.CreateSavedFilterRequest, userID uuid.UUID) (*response.SavedFilterResponse, error)
+	GetByProjectID(projectID, userID uuid.UUID) ([]response.SavedFilterResponse, error)
+	GetByID(id, userID uuid.UUID) (*response.SavedFilterResponse, error)
+	Update(id uuid.UUID, req *request.UpdateSavedFilterRequest, userID uuid.UUID) (*response.SavedFilterResponse, error)
+	Delete(id, userID uuid.UUID) error
+}
+
+type savedFilterService struct {
+	filterRepo  repository.SavedFilterRepository
+	projectRepo repository.ProjectRepository
+}
+
+func NewSavedFilterService(filterRepo repository.SavedFilterRepository, projectRepo repository.ProjectRepository) SavedFilterService {
+	return &savedFilterService{
+		filterRepo:  filterRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (s *savedFilterService) Create(projectID uuid.UUID, req *request.CreateSavedFilterRequest, userID uuid.UUID) (*response.SavedFilterResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	// Saved queries must compile so that they can always be applied
+	if _, err := compileQuery(req.Query, userID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	existing, err := s.filterRepo.FindByName(projectID, userID, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrSavedFilterExists
+	}
+
+	filter := &models.SavedFilter{
+		ProjectID: projectID,
+		OwnerID:   userID,
+		Name:      name,
+		Query:     strings.TrimSpace(req.Query),
+		Shared:    req.Shared,
+	}
+	if err := s.filterRepo.Create(filter); err != nil {
+		return nil, err
+	}
+
+	created, err := s.filterRepo.GetByID(filter.ID)
+	if err != nil {
+		return nil, err
+	}
+	return response.ToSavedFilterResponse(created), nil
+}
+
+func (s *savedFilterService) GetByProjectID(projectID, userID uuid.UUID) ([]response.SavedFilterResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	filters, err := s.filterRepo.GetVisible(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return response.ToSavedFilterListResponse(filters), nil
+}
+
+func (s *savedFilterService) GetByID(id, userID uuid.UUID) (*response.SavedFilterResponse, error) {
+	filter, err := s.getVisible(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return response.ToSavedFilterResponse(filter), nil
+}
+
+func (s *savedFilterService) Update(id uuid.UUID, req *request.UpdateSavedFilterRequest, userID uuid.UUID) (*response.SavedFilterResponse, error) {
+	filter, err := s.getVisible(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if filter.OwnerID != userID {
+		return nil, ErrSavedFilterForbidden
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" && name != filter.Name {
+		existing, err := s.filterRepo.FindByName(filter.ProjectID, userID, name)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrSavedFilterExists
+		}
+		filter.Name = name
+	}
+
+	if req.Query != nil {
+		if _, err := compileQuery(*req.Query, userID); err != nil {
+			return nil, err
+		}
+		filter.Query = strings.TrimSpace(*req.Query)
+	}
+
+	if req.Shared != nil {
+		filter.Shared = *req.Shared
+	}
+
+	if err := s.filterRepo.Update(filter); err != nil {
+		return nil, err
+	}
+	return response.ToSavedFilterResponse(filter), nil
+}
+
+func (s *savedFilterService) Delete(id, userID uuid.UUID) error {
+	filter, err := s.getVisible(id, userID)
+	if err != nil {
+		return err
+	}
+	if filter.OwnerID != userID {
+		return ErrSavedFilterForbidden
+	}
+	return s.filterRepo.Delete(id)
+}
+
+// getVisible loads a filter the user owns or that is shared with them. Other users'
+// private filters are reported as not found.
+func (s *savedFilterService) getVisible(id, userID uuid.UUID) (*models.SavedFilter, error) {
+	filter, err := s.filterRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil || (filter.OwnerID != userID && !filter.Shared) {
+		return nil, ErrSavedFilterNotFound
+	}
+	return filter, nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+)
+
+// MockSavedFilterRepository is a mock implementation of SavedFilterRepository
+type MockSavedFilterRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavedFilterRepository) Create(filter *models.SavedFilter) error {
+	args := m.Called(filter)
+	return args.Error(0)
+}
+
+func (m *MockSavedFilterRepository) GetByID(id uuid.UUID) (*models.SavedFilter, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SavedFilter), args.Error(1)
+}
+
+func (m *MockSavedFilterRepository) GetVisible(projectID, userID uuid.UUID) ([]models.SavedFilter, error) {
+	args := m.Called(projectID, userID)
+	return args.Get(0).([]models.SavedFilter), args.Error(1)
+}
+
+func (m *MockSavedFilterRepository) FindByName(projectID, ownerID uuid.UUID, name string) (*models.SavedFilter, error) {
+	args := m.Called(projectID, ownerID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SavedFilter), args.Error(1)
+}
+
+func (m *MockSavedFilterRepository) Update(filter *models.SavedFilter) error {
+	args := m.Called(filter)
+	return args.Error(0)
+}
+
+func (m *MockSavedFilterRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestSavedFilterService_Create(t *testing.T) {
+	t.Run("should save a valid query", func(t *testing.T) {
+		mockFilterRepo := new(MockSavedFilterRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewSavedFilterService(mockFilterRepo, mockProjectRepo)
+
+		projectID := uuid.New()
+		userID := uuid.New()
+		filterID := uuid.New()
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockFilterRepo.On("FindByName", projectID, userID, "My bugs").Return(nil, nil)
+		mockFilterRepo.On("Create", mock.MatchedBy(func(f *models.SavedFilter) bool {
+			return f.Name == "My bugs" && f.OwnerID == userID && f.Query == "type = Bug AND assignee = me" && f.Shared
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.SavedFilter).ID = filterID
+		}).Return(nil)
+		mockFilterRepo.On("GetByID", filterID).Return(&models.SavedFilter{
+			ID: filterID, ProjectID: projectID, OwnerID: userID, Name: "My bugs", Query: "type = Bug AND assignee = me", Shared: true,
+		}, nil)
+
+		result, err := service.Create(projectID, &request.CreateSavedFilterRequest{
+			Name:   " My bugs ",
+			Query:  "type = Bug AND assignee = me ",
+			Shared: true,
+		}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, filterID, result.ID)
+		assert.True(t, result.Shared)
+		mockFilterRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject an invalid query", func(t *testing.T) {
+		mockFilterRepo := new(MockSavedFilterRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewSavedFilterService(mockFilterRepo, mockProjectRepo)
+
+		projectID := uuid.New()
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+
+		result, err := service.Create(projectID, &request.CreateSavedFilterRequest{
+			Name:  "Broken",
+			Query: "priority in (High",
+		}, uuid.New())
+
+		assert.True(t, errors.Is(err, ErrInvalidQuery))
+		assert.Nil(t, result)
+		mockFilterRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("should reject a duplicate name", func(t *testing.T) {
+		mockFilterRepo := new(MockSavedFilterRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		service := NewSavedFilterService(mockFilterRepo, mockProjectRepo)
+
+		projectID := uuid.New()
+		userID := uuid.New()
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockFilterRepo.On("FindByName", projectID, userID, "Open").Return(&models.SavedFilter{ID: uuid.New()}, nil)
+
+		_, err := service.Create(projectID, &request.CreateSavedFilterRequest{
+			Name:  "Open",
+			Query: "status != Done",
+		}, userID)
+
+		assert.Equal(t, ErrSavedFilterExists, err)
+	})
+}
+
+func TestSavedFilterService_GetByID(t *testing.T) {
+	t.Run("should hide another user's private filter", func(t *testing.T) {
+		mockFilterRepo := new(MockSavedFilterRepository)
+		service := NewSavedFilterService(mockFilterRepo, new(MockProjectRepository))
+
+		filter := &models.SavedFilter{ID: uuid.New(), OwnerID: uuid.New(), Name: "Private"}
+		mockFilterRepo.On("GetByID", filter.ID).Return(filter, nil)
+
+		result, err := service.GetByID(filter.ID, uuid.New())
+
+		assert.Equal(t, ErrSavedFilterNotFound, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should return a filter shared by another user", func(t *testing.T) {
+		mockFilterRepo := new(MockSavedFilterRepository)
+		service := NewSavedFilterService(mockFilterRepo, new(MockProjectRepository))
+
+		filter := &models.SavedFilter{ID: uuid.New(), OwnerID: uuid.New(), Name: "Team", Shared: true}
+		mockFilterRepo.On("GetByID", filter.ID).Return(filter, nil)
+
+		result, err := service.GetByID(filter.ID, uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Team", result.Name)
+	})
+}
+
+func TestSavedFilterService_Update(t *testing.T) {
+	t.Run("should forbid changes to a shared filter by another user", func(t *testing.T) {
+		mockFilterRepo := new(MockSavedFilterRepository)
+		service := NewSavedFilterService(mockFilterRepo, new(MockProjectRepository))
+
+		filter := &models.SavedFilter{ID: uuid.New(), OwnerID: uuid.New(), Name: "Team", Shared: true}
+		mockFilterRepo.On("GetByID", filter.ID).Return(filter, nil)
+
+		shared := false
+		_, err := service.Update(filter.ID, &request.UpdateSavedFilterRequest{Shared: &shared}, uuid.New())
+
+		assert.Equal(t, ErrSavedFilterForbidden, err)
+		mockFilterRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
@@ -40,7 +40,7 @@ type SprintService interface {
 	Cancel(id uuid.UUID, userID uuid.UUID) (*response.SprintResponse, error)
 	AddItem(sprintID uuid.UUID, itemID uuid.UUID, userID uuid.UUID) (*response.SprintWithItemsResponse, error)
 	RemoveItem(sprintID uuid.UUID, itemID uuid.UUID, userID uuid.UUID) (*response.SprintWithItemsResponse, error)
-	GetHistory(id uuid.UUID) ([]response.SprintHistoryResponse, error)
+	GetHistory(id uuid.UUID, params *request.HistoryQueryParams) ([]response.SprintHistoryResponse, string, error)
 	GetReport(id uuid.UUID) (*response.SprintReportResponse, error)
 }
 
@@ -49,6 +49,8 @@ type sprintService struct {
 	sprintHistoryRepo repository.SprintHistoryRepository
 	backlogRepo       repository.BacklogRepository
 	itemHistoryRepo   repository.ItemHistoryRepository
+	workflowRepo      repository.WorkflowRepository
+	worklogRepo       repository.WorklogRepository
 }
 
 func NewSprintService(
@@ -56,12 +58,16 @@ func NewSprintService(
 	sprintHistoryRepo repository.SprintHistoryRepository,
 	backlogRepo repository.BacklogRepository,
 	itemHistoryRepo repository.ItemHistoryRepository,
+	workflowRepo repository.WorkflowRepository,
+	worklogRepo repository.WorklogRepository,
 ) SprintService {
 	return &sprintService{
 		sprintRepo:        sprintRepo,
 		sprintHistoryRepo: sprintHistoryRepo,
 		backlogRepo:       backlogRepo,
 		itemHistoryRepo:   itemHistoryRepo,
+		workflowRepo:      workflowRepo,
+		worklogRepo:       worklogRepo,
 	}
 }
 
@@ -129,10 +135,19 @@ func (s *sprintService) GetAll(params *request.SprintQueryParams) (*response.Spr
 
 	// Build filters
 	filters := repository.SprintFilters{
-		Page:  params.Page,
-		Limit: params.Limit,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Cursor:     params.Cursor,
+		CountTotal: includeTotal(params.IncludeTotal, params.Cursor),
 	}
 
+	// Parse sort order
+	order, err := parseSort(params.Sort, repository.ValidateSprintSort)
+	if err != nil {
+		return nil, err
+	}
+	filters.Sort = order
+
 	// Parse project filter
 	if params.ProjectID != "" {
 		if projectID, err := uuid.Parse(params.ProjectID); err == nil {
@@ -148,12 +163,17 @@ func (s *sprintService) GetAll(params *request.SprintQueryParams) (*response.Spr
 		}
 	}
 
-	sprints, total, err := s.sprintRepo.GetAll(filters)
+	sprints, info, err := s.sprintRepo.GetAll(filters)
 	if err != nil {
 		return nil, err
 	}
 
-	return response.ToSprintListResponse(sprints, total, params.Page, params.Limit), nil
+	// Page numbers do not apply when paging by cursor
+	page := params.Page
+	if params.Cursor != "" {
+		page = 0
+	}
+	return response.ToSprintListResponse(sprints, info.Total, page, params.Limit, info.NextCursor), nil
 }
 
 func (s *sprintService) GetWithItems(id uuid.UUID) (*response.SprintWithItemsResponse, error) {
@@ -317,8 +337,12 @@ func (s *sprintService) Complete(id uuid.UUID, userID uuid.UUID) (*response.Spri
 		return nil, ErrSprintNotActive
 	}
 
-	// Calculate velocity
-	velocity, err := s.sprintRepo.CalculateVelocity(id)
+	// Calculate velocity from the statuses the project workflow considers done
+	workflow, err := loadWorkflow(s.workflowRepo, sprint.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	velocity, err := s.sprintRepo.CalculateVelocity(id, workflow.StatusesIn(constants.StatusCategoryDone))
 	if err != nil {
 		return nil, err
 	}
@@ -410,7 +434,7 @@ func (s *sprintService) AddItem(sprintID uuid.UUID, itemID uuid.UUID, userID uui
 	// Update item's sprint
 	oldSprintID := item.SprintID
 	item.SprintID = &sprintID
-	if err := s.backlogRepo.Update(item); err != nil {
+	if err := s.backlogRepo.Update(item, []string{"sprint_id"}); err != nil {
 		return nil, err
 	}
 
@@ -462,7 +486,7 @@ func (s *sprintService) RemoveItem(sprintID uuid.UUID, itemID uuid.UUID, userID
 	// Update item's sprint
 	oldSprintID := item.SprintID
 	item.SprintID = nil
-	if err := s.backlogRepo.Update(item); err != nil {
+	if err := s.backlogRepo.Update(item, []string{"sprint_id"}); err != nil {
 		return nil, err
 	}
 
@@ -486,22 +510,29 @@ func (s *sprintService) RemoveItem(sprintID uuid.UUID, itemID uuid.UUID, userID
 	return response.ToSprintWithItemsResponse(sprint, items), nil
 }
 
-func (s *sprintService) GetHistory(id uuid.UUID) ([]response.SprintHistoryResponse, error) {
+// GetHistory returns the sprint's history, newest first. When a page is requested it
+// also returns the cursor of the next page, or "" on the last one.
+func (s *sprintService) GetHistory(id uuid.UUID, params *request.HistoryQueryParams) ([]response.SprintHistoryResponse, string, error) {
 	// Check if sprint exists
 	sprint, err := s.sprintRepo.GetByID(id)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	if sprint == nil {
-		return nil, ErrSprintNotFound
+		return nil, "", ErrSprintNotFound
 	}
 
-	histories, err := s.sprintHistoryRepo.GetBySprintID(id)
+	// Fetch one extra entry to tell whether another page follows
+	limit, fetch := historyLimit(params)
+	histories, err := s.sprintHistoryRepo.GetBySprintID(id, params.Cursor, fetch)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	histories, next := historyPage(histories, limit, func(h models.SprintHistory) (time.Time, uuid.UUID) {
+		return h.Timestamp, h.ID
+	})
 
-	return response.ToSprintHistoryListResponse(histories), nil
+	return response.ToSprintHistoryListResponse(histories), next, nil
 }
 
 func (s *sprintService) GetReport(id uuid.UUID) (*response.SprintReportResponse, error) {
@@ -519,17 +550,41 @@ func (s *sprintService) GetReport(id uuid.UUID) (*response.SprintReportResponse,
 		return nil, err
 	}
 
+	workflow, err := loadWorkflow(s.workflowRepo, sprint.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate stats
 	totalItems := len(items)
 	completedItems := 0
 	totalStoryPoints := 0
 	completedStoryPoints := 0
-
-	for _, item := range items {
+	overdueItems := 0
+	overdueStoryPoints := 0
+	originalEstimate := 0
+	remainingEstimate := 0
+	day := today()
+	itemIDs := make([]uuid.UUID, len(items))
+
+	for i, item := range items {
+		itemIDs[i] = item.ID
 		if item.StoryPoints != nil {
 			totalStoryPoints += *item.StoryPoints
 		}
-		if item.Status == constants.ItemStatusDone {
+		if item.OriginalEstimate != nil {
+			originalEstimate += *item.OriginalEstimate
+		}
+		if item.RemainingEstimate != nil {
+			remainingEstimate += *item.RemainingEstimate
+		}
+		if isOverdue(&item, workflow, day) {
+			overdueItems++
+			if item.StoryPoints != nil {
+				overdueStoryPoints += *item.StoryPoints
+			}
+		}
+		if category, _ := workflow.Category(item.Status); category == constants.StatusCategoryDone {
 			completedItems++
 			if item.StoryPoints != nil {
 				completedStoryPoints += *item.StoryPoints
@@ -537,6 +592,12 @@ func (s *sprintService) GetReport(id uuid.UUID) (*response.SprintReportResponse,
 		}
 	}
 
+	// Time spent counts the work logged on the items during the sprint only
+	timeSpent, err := s.worklogRepo.SumByItems(itemIDs, calendarDay(sprint.StartDate), calendarDay(sprint.EndDate))
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate completion percentage
 	var completionPercentage float64
 	if totalItems > 0 {
@@ -556,6 +617,11 @@ func (s *sprintService) GetReport(id uuid.UUID) (*response.SprintReportResponse,
 		CompletedStoryPoints: completedStoryPoints,
 		Velocity:             velocity,
 		CompletionPercentage: completionPercentage,
+		OverdueItems:         overdueItems,
+		OverdueStoryPoints:   overdueStoryPoints,
+		OriginalEstimate:     originalEstimate,
+		RemainingEstimate:    remainingEstimate,
+		TimeSpent:            timeSpent,
 	}, nil
 }
 
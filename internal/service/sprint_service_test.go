@@ -0,0 +1,60 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/pkg/constants"
+)
+
+func TestSprintService_GetReport(t *testing.T) {
+	t.Run("should count the time logged during the sprint", func(t *testing.T) {
+		mockSprintRepo := new(MockSprintRepository)
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockWorklogRepo := new(MockWorklogRepository)
+		service := NewSprintService(mockSprintRepo, nil, nil, nil, mockWorkflowRepo, mockWorklogRepo)
+
+		sprint := &models.Sprint{
+			ID:        uuid.New(),
+			ProjectID: uuid.New(),
+			StartDate: time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC),
+			EndDate:   time.Date(2026, 3, 13, 17, 0, 0, 0, time.UTC),
+		}
+		five, sixty := 5, 60
+		items := []models.BacklogItem{
+			{ID: uuid.New(), Status: constants.ItemStatusDone, StoryPoints: &five, RemainingEstimate: &sixty, TimeSpent: 600},
+			{ID: uuid.New(), Status: constants.ItemStatusArchived, StoryPoints: &five, TimeSpent: 120},
+		}
+		mockSprintRepo.On("GetByID", sprint.ID).Return(sprint, nil)
+		mockSprintRepo.On("GetItemsBySprintID", sprint.ID).Return(items, nil)
+		mockWorkflowRepo.On("GetByProjectID", sprint.ProjectID).Return(nil, nil)
+		mockWorklogRepo.On("SumByItems", []uuid.UUID{items[0].ID, items[1].ID},
+			time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)).Return(240, nil)
+
+		result, err := service.GetReport(sprint.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 240, result.TimeSpent)
+		assert.Equal(t, 60, result.RemainingEstimate)
+		assert.Equal(t, 1, result.CompletedItems)
+		assert.Equal(t, 5, result.CompletedStoryPoints)
+		mockWorklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when sprint not found", func(t *testing.T) {
+		mockSprintRepo := new(MockSprintRepository)
+		service := NewSprintService(mockSprintRepo, nil, nil, nil, nil, nil)
+
+		id := uuid.New()
+		mockSprintRepo.On("GetByID", id).Return(nil, nil)
+
+		result, err := service.GetReport(id)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrSprintNotFound, err)
+	})
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/storage"
+)
+
+var (
+	ErrNotInTrash     = errors.New("not in the trash")
+	ErrProjectInTrash = errors.New("project is in the trash, restore the project first")
+)
+
+// TrashService lists and restores deleted projects, sprints and items, and purges
+// them for good once the retention period has passed
+type TrashService interface {
+	GetProjectTrash(projectID uuid.UUID) (*response.ProjectTrashResponse, error)
+	GetDeletedProjects() ([]response.TrashedProjectResponse, error)
+	RestoreItem(id uuid.UUID, userID uuid.UUID) (*response.RestoreResponse, error)
+	RestoreSprint(id uuid.UUID, userID uuid.UUID) (*response.RestoreResponse, error)
+	RestoreProject(id uuid.UUID, userID uuid.UUID) (*response.RestoreResponse, error)
+	Purge(ctx context.Context) (*repository.PurgeResult, error)
+}
+
+type trashService struct {
+	trashRepo      repository.TrashRepository
+	attachmentRepo repository.AttachmentRepository
+	store          storage.Storage
+	retention      time.Duration
+}
+
+// NewTrashService creates the trash service. A retention of zero keeps deleted rows
+// until they are restored.
+func NewTrashService(trashRepo repository.TrashRepository, attachmentRepo repository.AttachmentRepository, store storage.Storage, retention time.Duration) TrashService {
+	return &trashService{
+		trashRepo:      trashRepo,
+		attachmentRepo: attachmentRepo,
+		store:          store,
+		retention:      retention,
+	}
+}
+
+// GetProjectTrash returns the deleted sprints and items of a project. The project
+// may be in the trash itself, in which case this is what restoring it brings back
+// along with anything deleted before it.
+func (s *trashService) GetProjectTrash(projectID uuid.UUID) (*response.ProjectTrashResponse, error) {
+	project, err := s.trashRepo.GetProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	sprints, err := s.trashRepo.GetDeletedSprints(projectID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.trashRepo.GetDeletedItems(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.ProjectTrashResponse{
+		Sprints: response.ToTrashedSprintListResponse(sprints, s.retention),
+		Items:   response.ToTrashedItemListResponse(items, s.retention),
+	}
+	if project.DeletedAt.Valid {
+		resp.Project = response.ToTrashedProjectResponse(project, s.retention)
+	}
+	return resp, nil
+}
+
+func (s *trashService) GetDeletedProjects() ([]response.TrashedProjectResponse, error) {
+	projects, err := s.trashRepo.GetDeletedProjects()
+	if err != nil {
+		return nil, err
+	}
+	return response.ToTrashedProjectListResponse(projects, s.retention), nil
+}
+
+// RestoreItem takes a single item out of the trash. Items of a deleted project
+// come back with the project.
+func (s *trashService) RestoreItem(id uuid.UUID, userID uuid.UUID) (*response.RestoreResponse, error) {
+	item, err := s.trashRepo.GetItem(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+	if !item.DeletedAt.Valid {
+		return nil, ErrNotInTrash
+	}
+	if err := s.ensureProjectLive(item.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if err := s.trashRepo.RestoreItem(item.ID, restoredItemHistory(item, userID)); err != nil {
+		return nil, err
+	}
+
+	return &response.RestoreResponse{
+		SprintIDs: []uuid.UUID{},
+		ItemIDs:   []uuid.UUID{item.ID},
+	}, nil
+}
+
+// RestoreSprint takes a single sprint out of the trash. Sprints of a deleted
+// project come back with the project.
+func (s *trashService) RestoreSprint(id uuid.UUID, userID uuid.UUID) (*response.RestoreResponse, error) {
+	sprint, err := s.trashRepo.GetSprint(id)
+	if err != nil {
+		return nil, err
+	}
+	if sprint == nil {
+		return nil, ErrSprintNotFound
+	}
+	if !sprint.DeletedAt.Valid {
+		return nil, ErrNotInTrash
+	}
+	if err := s.ensureProjectLive(sprint.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if err := s.trashRepo.RestoreSprint(sprint.ID, restoredSprintHistory(sprint, userID)); err != nil {
+		return nil, err
+	}
+
+	return &response.RestoreResponse{
+		SprintIDs: []uuid.UUID{sprint.ID},
+		ItemIDs:   []uuid.UUID{},
+	}, nil
+}
+
+// RestoreProject takes a project out of the trash together with the sprints and
+// items that were deleted with it. Each restored sprint and item gets a history
+// entry.
+func (s *trashService) RestoreProject(id uuid.UUID, userID uuid.UUID) (*response.RestoreResponse, error) {
+	project, err := s.trashRepo.GetProject(id)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	if !project.DeletedAt.Valid {
+		return nil, ErrNotInTrash
+	}
+
+	sprints, items, err := s.trashRepo.GetDeletedWith(project)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.RestoreResponse{
+		ProjectID: &project.ID,
+		SprintIDs: make([]uuid.UUID, len(sprints)),
+		ItemIDs:   make([]uuid.UUID, len(items)),
+	}
+	sprintHistories := make([]models.SprintHistory, len(sprints))
+	for i := range sprints {
+		resp.SprintIDs[i] = sprints[i].ID
+		sprintHistories[i] = restoredSprintHistory(&sprints[i], userID)
+	}
+	itemHistories := make([]models.ItemHistory, len(items))
+	for i := range items {
+		resp.ItemIDs[i] = items[i].ID
+		itemHistories[i] = restoredItemHistory(&items[i], userID)
+	}
+
+	if err := s.trashRepo.RestoreProject(project, sprintHistories, itemHistories); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Purge permanently deletes everything that has been in the trash longer than the
+// retention period. Attachment files no remaining attachment uses are removed from
+// storage. It does nothing when the retention is zero.
+func (s *trashService) Purge(ctx context.Context) (*repository.PurgeResult, error) {
+	if s.retention <= 0 {
+		return &repository.PurgeResult{}, nil
+	}
+
+	result, err := s.trashRepo.Purge(time.Now().Add(-s.retention))
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make(map[string]bool, len(result.StorageKeys))
+	for _, key := range result.StorageKeys {
+		if removed[key] {
+			continue
+		}
+		removed[key] = true
+		// A file left behind is only wasted space; uploading the same content
+		// again reuses it
+		_ = releaseStoredFile(ctx, s.attachmentRepo, s.store, key)
+	}
+	return result, nil
+}
+
+// ensureProjectLive rejects restoring into a project that is in the trash
+func (s *trashService) ensureProjectLive(projectID uuid.UUID) error {
+	project, err := s.trashRepo.GetProject(projectID)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return ErrProjectNotFound
+	}
+	if project.DeletedAt.Valid {
+		return ErrProjectInTrash
+	}
+	return nil
+}
+
+func restoredItemHistory(item *models.BacklogItem, userID uuid.UUID) models.ItemHistory {
+	field := "deleted_at"
+	oldVal, _ := json.Marshal(item.DeletedAt.Time)
+	return models.ItemHistory{
+		ItemID:       item.ID,
+		UserID:       userID,
+		Action:       constants.ItemActionRestored,
+		FieldChanged: &field,
+		OldValue:     datatypes.JSON(oldVal),
+	}
+}
+
+func restoredSprintHistory(sprint *models.Sprint, userID uuid.UUID) models.SprintHistory {
+	oldVal, _ := json.Marshal(map[string]interface{}{"deleted_at": sprint.DeletedAt.Time})
+	return models.SprintHistory{
+		SprintID: sprint.ID,
+		UserID:   userID,
+		Action:   constants.SprintActionRestored,
+		OldValue: datatypes.JSON(oldVal),
+	}
+}
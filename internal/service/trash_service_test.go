@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+	"sprint-backlog/pkg/storage"
+)
+
+// MockTrashRepository is a mock implementation of TrashRepository
+type MockTrashRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrashRepository) GetProject(id uuid.UUID) (*models.Project, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Project), args.Error(1)
+}
+
+func (m *MockTrashRepository) GetSprint(id uuid.UUID) (*models.Sprint, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Sprint), args.Error(1)
+}
+
+func (m *MockTrashRepository) GetItem(id uuid.UUID) (*models.BacklogItem, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BacklogItem), args.Error(1)
+}
+
+func (m *MockTrashRepository) GetDeletedProjects() ([]models.Project, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Project), args.Error(1)
+}
+
+func (m *MockTrashRepository) GetDeletedSprints(projectID uuid.UUID) ([]models.Sprint, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]models.Sprint), args.Error(1)
+}
+
+func (m *MockTrashRepository) GetDeletedItems(projectID uuid.UUID) ([]models.BacklogItem, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]models.BacklogItem), args.Error(1)
+}
+
+func (m *MockTrashRepository) GetDeletedWith(project *models.Project) ([]models.Sprint, []models.BacklogItem, error) {
+	args := m.Called(project)
+	return args.Get(0).([]models.Sprint), args.Get(1).([]models.BacklogItem), args.Error(2)
+}
+
+func (m *MockTrashRepository) RestoreItem(id uuid.UUID, history models.ItemHistory) error {
+	args := m.Called(id, history)
+	return args.Error(0)
+}
+
+func (m *MockTrashRepository) RestoreSprint(id uuid.UUID, history models.SprintHistory) error {
+	args := m.Called(id, history)
+	return args.Error(0)
+}
+
+func (m *MockTrashRepository) RestoreProject(project *models.Project, sprintHistories []models.SprintHistory, itemHistories []models.ItemHistory) error {
+	args := m.Called(project, sprintHistories, itemHistories)
+	return args.Error(0)
+}
+
+func (m *MockTrashRepository) Purge(before time.Time) (*repository.PurgeResult, error) {
+	args := m.Called(before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.PurgeResult), args.Error(1)
+}
+
+const testRetention = 30 * 24 * time.Hour
+
+func deletedAt(t time.Time) gorm.DeletedAt {
+	return gorm.DeletedAt{Time: t, Valid: true}
+}
+
+func TestTrashService_GetProjectTrash(t *testing.T) {
+	t.Run("should list a deleted project's trash with purge times", func(t *testing.T) {
+		mockTrashRepo := new(MockTrashRepository)
+		service := NewTrashService(mockTrashRepo, nil, nil, testRetention)
+
+		deleted := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+		project := &models.Project{ID: uuid.New(), Key: "APP", DeletedAt: deletedAt(deleted)}
+		item := models.BacklogItem{ID: uuid.New(), Number: 4, Project: *project, DeletedAt: deletedAt(deleted)}
+		mockTrashRepo.On("GetProject", project.ID).Return(project, nil)
+		mockTrashRepo.On("GetDeletedSprints", project.ID).Return([]models.Sprint{}, nil)
+		mockTrashRepo.On("GetDeletedItems", project.ID).Return([]models.BacklogItem{item}, nil)
+
+		result, err := service.GetProjectTrash(project.ID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result.Project)
+		assert.Equal(t, deleted.Add(testRetention), *result.Project.PurgeAt)
+		assert.Len(t, result.Items, 1)
+		assert.Equal(t, "APP-4", result.Items[0].Key)
+		assert.Empty(t, result.Sprints)
+	})
+
+	t.Run("should not set the project or purge times of a live project without retention", func(t *testing.T) {
+		mockTrashRepo := new(MockTrashRepository)
+		service := NewTrashService(mockTrashRepo, nil, nil, 0)
+
+		project := &models.Project{ID: uuid.New()}
+		sprint := models.Sprint{ID: uuid.New(), DeletedAt: deletedAt(time.Now())}
+		mockTrashRepo.On("GetProject", project.ID).Return(project, nil)
+		mockTrashRepo.On("GetDeletedSprints", project.ID).Return([]models.Sprint{sprint}, nil)
+		mockTrashRepo.On("GetDeletedItems", project.ID).Return([]models.BacklogItem{}, nil)
+
+		result, err := service.GetProjectTrash(project.ID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.Project)
+		assert.Len(t, result.Sprints, 1)
+		assert.Nil(t, result.Sprints[0].PurgeAt)
+	})
+}
+
+func TestTrashService_RestoreItem(t *testing.T) {
+	t.Run("should restore a deleted item with history", func(t *testing.T) {
+		mockTrashRepo := new(MockTrashRepository)
+		service := NewTrashService(mockTrashRepo, nil, nil, testRetention)
+
+		userID := uuid.New()
+		project := &models.Project{ID: uuid.New()}
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: project.ID, DeletedAt: deletedAt(time.Now())}
+		mockTrashRepo.On("GetItem", item.ID).Return(item, nil)
+		mockTrashRepo.On("GetProject", project.ID).Return(project, nil)
+		mockTrashRepo.On("RestoreItem", item.ID, mock.MatchedBy(func(h models.ItemHistory) bool {
+			return h.ItemID == item.ID && h.UserID == userID && h.Action == constants.ItemActionRestored
+		})).Return(nil)
+
+		result, err := service.RestoreItem(item.ID, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{item.ID}, result.ItemIDs)
+		mockTrashRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject an item that is not deleted", func(t *testing.T) {
+		mockTrashRepo := new(MockTrashRepository)
+		service := NewTrashService(mockTrashRepo, nil, nil, testRetention)
+
+		item := &models.BacklogItem{ID: uuid.New()}
+		mockTrashRepo.On("GetItem", item.ID).Return(item, nil)
+
+		result, err := service.RestoreItem(item.ID, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrNotInTrash, err)
+	})
+
+	t.Run("should reject an item whose project is deleted", func(t *testing.T) {
+		mockTrashRepo := new(MockTrashRepository)
+		service := NewTrashService(mockTrashRepo, nil, nil, testRetention)
+
+		project := &models.Project{ID: uuid.New(), DeletedAt: deletedAt(time.Now())}
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: project.ID, DeletedAt: project.DeletedAt}
+		mockTrashRepo.On("GetItem", item.ID).Return(item, nil)
+		mockTrashRepo.On("GetProject", project.ID).Return(project, nil)
+
+		result, err := service.RestoreItem(item.ID, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrProjectInTrash, err)
+		mockTrashRepo.AssertNotCalled(t, "RestoreItem", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTrashService_RestoreSprint(t *testing.T) {
+	t.Run("should not find a missing sprint", func(t *testing.T) {
+		mockTrashRepo := new(MockTrashRepository)
+		service := NewTrashService(mockTrashRepo, nil, nil, testRetention)
+
+		id := uuid.New()
+		mockTrashRepo.On("GetSprint", id).Return(nil, nil)
+
+		result, err := service.RestoreSprint(id, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrSprintNotFound, err)
+	})
+}
+
+func TestTrashService_RestoreProject(t *testing.T) {
+	t.Run("should restore the sprints and items deleted with the project", func(t *testing.T) {
+		mockTrashRepo := new(MockTrashRepository)
+		service := NewTrashService(mockTrashRepo, nil, nil, testRetention)
+
+		userID := uuid.New()
+		project := &models.Project{ID: uuid.New(), DeletedAt: deletedAt(time.Now())}
+		sprints := []models.Sprint{{ID: uuid.New(), DeletedAt: project.DeletedAt}}
+		items := []models.BacklogItem{
+			{ID: uuid.New(), DeletedAt: project.DeletedAt},
+			{ID: uuid.New(), DeletedAt: project.DeletedAt},
+		}
+		mockTrashRepo.On("GetProject", project.ID).Return(project, nil)
+		mockTrashRepo.On("GetDeletedWith", project).Return(sprints, items, nil)
+		mockTrashRepo.On("RestoreProject", project,
+			mock.MatchedBy(func(h []models.SprintHistory) bool {
+				return len(h) == 1 && h[0].SprintID == sprints[0].ID && h[0].Action == constants.SprintActionRestored
+			}),
+			mock.MatchedBy(func(h []models.ItemHistory) bool {
+				return len(h) == 2 && h[1].ItemID == items[1].ID && h[1].Action == constants.ItemActionRestored
+			}),
+		).Return(nil)
+
+		result, err := service.RestoreProject(project.ID, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, project.ID, *result.ProjectID)
+		assert.Equal(t, []uuid.UUID{sprints[0].ID}, result.SprintIDs)
+		assert.Equal(t, []uuid.UUID{items[0].ID, items[1].ID}, result.ItemIDs)
+		mockTrashRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a project that is not deleted", func(t *testing.T) {
+		mockTrashRepo := new(MockTrashRepository)
+		service := NewTrashService(mockTrashRepo, nil, nil, testRetention)
+
+		project := &models.Project{ID: uuid.New()}
+		mockTrashRepo.On("GetProject", project.ID).Return(project, nil)
+
+		result, err := service.RestoreProject(project.ID, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrNotInTrash, err)
+	})
+}
+
+func TestTrashService_Purge(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should purge past retention and remove unshared files", func(t *testing.T) {
+		mockTrashRepo := new(MockTrashRepository)
+		mockAttachmentRepo := new(MockAttachmentRepository)
+		store, _ := storage.NewLocal(t.TempDir())
+		service := NewTrashService(mockTrashRepo, mockAttachmentRepo, store, testRetention)
+
+		unshared, shared := "attachments/aa/unshared", "attachments/bb/shared"
+		_ = store.Put(ctx, unshared, strings.NewReader("a"), 1, "text/plain")
+		_ = store.Put(ctx, shared, strings.NewReader("b"), 1, "text/plain")
+		mockTrashRepo.On("Purge", mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= testRetention && time.Since(before) < testRetention+time.Minute
+		})).Return(&repository.PurgeResult{Items: 2, StorageKeys: []string{unshared, shared, unshared}}, nil)
+		mockAttachmentRepo.On("ReleaseStorageKey", unshared).Return(int64(0), nil).Once()
+		mockAttachmentRepo.On("ReleaseStorageKey", shared).Return(int64(1), nil).Once()
+
+		result, err := service.Purge(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Items)
+		_, err = store.Get(ctx, unshared)
+		assert.Equal(t, storage.ErrNotFound, err)
+		_, err = store.Get(ctx, shared)
+		assert.NoError(t, err)
+		mockAttachmentRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not purge without retention", func(t *testing.T) {
+		mockTrashRepo := new(MockTrashRepository)
+		service := NewTrashService(mockTrashRepo, nil, nil, 0)
+
+		result, err := service.Purge(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Items)
+		mockTrashRepo.AssertNotCalled(t, "Purge", mock.Anything)
+	})
+}
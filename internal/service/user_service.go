@@ -1,11 +1,14 @@
 package service
 
 import (
+	"bytes"
 	"encoding/json"
 	"sort"
+	"time"
 
 	"github.com/google/uuid"
 
+	"sprint-backlog/internal/dto/request"
 	"sprint-backlog/internal/dto/response"
 	"sprint-backlog/internal/repository"
 )
@@ -13,24 +16,28 @@ import (
 type UserService interface {
 	GetAll() ([]response.UserResponse, error)
 	GetByID(id uuid.UUID) (*response.UserResponse, error)
-	GetActivities(userID uuid.UUID, limit int) (*response.UserActivitiesResponse, error)
+	GetActivities(userID uuid.UUID, params *request.ActivityQueryParams) (*response.UserActivitiesResponse, error)
+	GetDueItems(userID uuid.UUID, days int) (*response.DueItemsResponse, error)
 }
 
 type userService struct {
 	userRepo          repository.UserRepository
 	itemHistoryRepo   repository.ItemHistoryRepository
 	sprintHistoryRepo repository.SprintHistoryRepository
+	backlogRepo       repository.BacklogRepository
 }
 
 func NewUserService(
 	userRepo repository.UserRepository,
 	itemHistoryRepo repository.ItemHistoryRepository,
 	sprintHistoryRepo repository.SprintHistoryRepository,
+	backlogRepo repository.BacklogRepository,
 ) UserService {
 	return &userService{
 		userRepo:          userRepo,
 		itemHistoryRepo:   itemHistoryRepo,
 		sprintHistoryRepo: sprintHistoryRepo,
+		backlogRepo:       backlogRepo,
 	}
 }
 
@@ -55,15 +62,31 @@ func (s *userService) GetByID(id uuid.UUID) (*response.UserResponse, error) {
 	return response.ToUserResponse(user), nil
 }
 
-func (s *userService) GetActivities(userID uuid.UUID, limit int) (*response.UserActivitiesResponse, error) {
+// GetActivities merges the user's item and sprint history, newest first. All sources
+// share one ordering, so a cursor from the merged list continues each of them.
+func (s *userService) GetActivities(userID uuid.UUID, params *request.ActivityQueryParams) (*response.UserActivitiesResponse, error) {
+	// Each source returns at most one entry more than the page, which is enough to
+	// fill the merged page and tell whether another one follows
+	limit, fetch := params.Limit, 0
+	if limit > 0 {
+		fetch = limit + 1
+	}
+
 	// Get item histories for user
-	itemHistories, err := s.itemHistoryRepo.GetByUserID(userID, 0) // Get all, we'll limit after merging
+	itemHistories, err := s.itemHistoryRepo.GetByUserID(userID, params.Cursor, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include assignment changes other users made for this user
+	assignments, err := s.itemHistoryRepo.GetAssignmentsForUser(userID, params.Cursor, fetch)
 	if err != nil {
 		return nil, err
 	}
+	itemHistories = append(itemHistories, assignments...)
 
 	// Get sprint histories for user
-	sprintHistories, err := s.sprintHistoryRepo.GetByUserID(userID, 0) // Get all, we'll limit after merging
+	sprintHistories, err := s.sprintHistoryRepo.GetByUserID(userID, params.Cursor, fetch)
 	if err != nil {
 		return nil, err
 	}
@@ -151,20 +174,72 @@ func (s *userService) GetActivities(userID uuid.UUID, limit int) (*response.User
 		activities = append(activities, activity)
 	}
 
-	// Sort by timestamp descending
+	// Sort by timestamp descending, then by ID like the history queries
 	sort.Slice(activities, func(i, j int) bool {
-		return activities[i].Timestamp.After(activities[j].Timestamp)
+		if !activities[i].Timestamp.Equal(activities[j].Timestamp) {
+			return activities[i].Timestamp.After(activities[j].Timestamp)
+		}
+		return bytes.Compare(activities[i].ID[:], activities[j].ID[:]) > 0
 	})
 
 	// Apply limit
-	total := len(activities)
-	if limit > 0 && len(activities) > limit {
-		activities = activities[:limit]
-	}
+	activities, next := historyPage(activities, limit, func(a response.UserActivityResponse) (time.Time, uuid.UUID) {
+		return a.Timestamp, a.ID
+	})
 
-	return &response.UserActivitiesResponse{
+	result := &response.UserActivitiesResponse{
 		Activities: activities,
-		Total:      total,
 		Limit:      limit,
-	}, nil
+		NextCursor: next,
+		HasMore:    next != "",
+	}
+	if params.IncludeTotal {
+		total, err := s.countActivities(userID)
+		if err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+	return result, nil
+}
+
+// countActivities counts all item and sprint history entries listed as the user's activities
+func (s *userService) countActivities(userID uuid.UUID) (int, error) {
+	items, err := s.itemHistoryRepo.CountByUserID(userID)
+	if err != nil {
+		return 0, err
+	}
+	assignments, err := s.itemHistoryRepo.CountAssignmentsForUser(userID)
+	if err != nil {
+		return 0, err
+	}
+	sprints, err := s.sprintHistoryRepo.CountByUserID(userID)
+	if err != nil {
+		return 0, err
+	}
+	return int(items + assignments + sprints), nil
+}
+
+// GetDueItems lists the open items assigned to the user across all projects that
+// are overdue or due within the next days days. Done items are left out.
+func (s *userService) GetDueItems(userID uuid.UUID, days int) (*response.DueItemsResponse, error) {
+	day := today()
+	items, err := s.backlogRepo.GetDueForAssignee(userID, day.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.DueItemsResponse{
+		Overdue:  []response.DueItemResponse{},
+		Upcoming: []response.DueItemResponse{},
+		Days:     days,
+	}
+	for i := range items {
+		if items[i].DueDate.Before(day) {
+			resp.Overdue = append(resp.Overdue, response.ToDueItemResponse(&items[i]))
+		} else {
+			resp.Upcoming = append(resp.Upcoming, response.ToDueItemResponse(&items[i]))
+		}
+	}
+	return resp, nil
 }
@@ -7,8 +7,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/datatypes"
 
+	"sprint-backlog/internal/dto/request"
 	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
 	"sprint-backlog/pkg/constants"
 )
 
@@ -56,6 +59,11 @@ func (m *MockUserRepository) GetAll() ([]models.User, error) {
 	return args.Get(0).([]models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByHandles(handles []string) ([]models.User, error) {
+	args := m.Called(handles)
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
 // MockItemHistoryRepository for user service tests
 type MockItemHistoryRepository struct {
 	mock.Mock
@@ -66,16 +74,31 @@ func (m *MockItemHistoryRepository) Create(history *models.ItemHistory) error {
 	return args.Error(0)
 }
 
-func (m *MockItemHistoryRepository) GetByItemID(itemID uuid.UUID) ([]models.ItemHistory, error) {
-	args := m.Called(itemID)
+func (m *MockItemHistoryRepository) GetByItemID(itemID uuid.UUID, cursor string, limit int) ([]models.ItemHistory, error) {
+	args := m.Called(itemID, cursor, limit)
+	return args.Get(0).([]models.ItemHistory), args.Error(1)
+}
+
+func (m *MockItemHistoryRepository) GetByUserID(userID uuid.UUID, cursor string, limit int) ([]models.ItemHistory, error) {
+	args := m.Called(userID, cursor, limit)
 	return args.Get(0).([]models.ItemHistory), args.Error(1)
 }
 
-func (m *MockItemHistoryRepository) GetByUserID(userID uuid.UUID, limit int) ([]models.ItemHistory, error) {
-	args := m.Called(userID, limit)
+func (m *MockItemHistoryRepository) GetAssignmentsForUser(userID uuid.UUID, cursor string, limit int) ([]models.ItemHistory, error) {
+	args := m.Called(userID, cursor, limit)
 	return args.Get(0).([]models.ItemHistory), args.Error(1)
 }
 
+func (m *MockItemHistoryRepository) CountByUserID(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockItemHistoryRepository) CountAssignmentsForUser(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // MockSprintHistoryRepository for user service tests
 type MockSprintHistoryRepository struct {
 	mock.Mock
@@ -86,16 +109,21 @@ func (m *MockSprintHistoryRepository) Create(history *models.SprintHistory) erro
 	return args.Error(0)
 }
 
-func (m *MockSprintHistoryRepository) GetBySprintID(sprintID uuid.UUID) ([]models.SprintHistory, error) {
-	args := m.Called(sprintID)
+func (m *MockSprintHistoryRepository) GetBySprintID(sprintID uuid.UUID, cursor string, limit int) ([]models.SprintHistory, error) {
+	args := m.Called(sprintID, cursor, limit)
 	return args.Get(0).([]models.SprintHistory), args.Error(1)
 }
 
-func (m *MockSprintHistoryRepository) GetByUserID(userID uuid.UUID, limit int) ([]models.SprintHistory, error) {
-	args := m.Called(userID, limit)
+func (m *MockSprintHistoryRepository) GetByUserID(userID uuid.UUID, cursor string, limit int) ([]models.SprintHistory, error) {
+	args := m.Called(userID, cursor, limit)
 	return args.Get(0).([]models.SprintHistory), args.Error(1)
 }
 
+func (m *MockSprintHistoryRepository) CountByUserID(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockSprintHistoryRepository) GetAll(limit int) ([]models.SprintHistory, error) {
 	args := m.Called(limit)
 	return args.Get(0).([]models.SprintHistory), args.Error(1)
@@ -107,7 +135,7 @@ func TestUserService_GetAll(t *testing.T) {
 		mockItemHistoryRepo := new(MockItemHistoryRepository)
 		mockSprintHistoryRepo := new(MockSprintHistoryRepository)
 
-		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo)
+		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo, nil)
 
 		users := []models.User{
 			{ID: uuid.New(), Name: "User 1", Email: "user1@example.com", GoogleID: "g1"},
@@ -130,7 +158,7 @@ func TestUserService_GetAll(t *testing.T) {
 		mockItemHistoryRepo := new(MockItemHistoryRepository)
 		mockSprintHistoryRepo := new(MockSprintHistoryRepository)
 
-		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo)
+		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo, nil)
 
 		mockUserRepo.On("GetAll").Return([]models.User{}, nil)
 
@@ -148,7 +176,7 @@ func TestUserService_GetByID(t *testing.T) {
 		mockItemHistoryRepo := new(MockItemHistoryRepository)
 		mockSprintHistoryRepo := new(MockSprintHistoryRepository)
 
-		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo)
+		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo, nil)
 
 		userID := uuid.New()
 		user := &models.User{
@@ -174,7 +202,7 @@ func TestUserService_GetByID(t *testing.T) {
 		mockItemHistoryRepo := new(MockItemHistoryRepository)
 		mockSprintHistoryRepo := new(MockSprintHistoryRepository)
 
-		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo)
+		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo, nil)
 
 		userID := uuid.New()
 		mockUserRepo.On("GetByID", userID).Return(nil, nil)
@@ -193,7 +221,7 @@ func TestUserService_GetActivities(t *testing.T) {
 		mockItemHistoryRepo := new(MockItemHistoryRepository)
 		mockSprintHistoryRepo := new(MockSprintHistoryRepository)
 
-		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo)
+		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo, nil)
 
 		userID := uuid.New()
 		itemID := uuid.New()
@@ -231,14 +259,19 @@ func TestUserService_GetActivities(t *testing.T) {
 			},
 		}
 
-		mockItemHistoryRepo.On("GetByUserID", userID, 0).Return(itemHistories, nil)
-		mockSprintHistoryRepo.On("GetByUserID", userID, 0).Return(sprintHistories, nil)
+		mockItemHistoryRepo.On("GetByUserID", userID, "", 11).Return(itemHistories, nil)
+		mockItemHistoryRepo.On("GetAssignmentsForUser", userID, "", 11).Return([]models.ItemHistory{}, nil)
+		mockSprintHistoryRepo.On("GetByUserID", userID, "", 11).Return(sprintHistories, nil)
 
-		result, err := service.GetActivities(userID, 10)
+		mockItemHistoryRepo.On("CountByUserID", userID).Return(int64(1), nil)
+		mockItemHistoryRepo.On("CountAssignmentsForUser", userID).Return(int64(0), nil)
+		mockSprintHistoryRepo.On("CountByUserID", userID).Return(int64(1), nil)
+
+		result, err := service.GetActivities(userID, &request.ActivityQueryParams{Limit: 10, IncludeTotal: true})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
-		assert.Equal(t, 2, result.Total)
+		assert.Equal(t, 2, *result.Total)
 		assert.Len(t, result.Activities, 2)
 
 		// First activity should be the sprint action (newer)
@@ -258,7 +291,7 @@ func TestUserService_GetActivities(t *testing.T) {
 		mockItemHistoryRepo := new(MockItemHistoryRepository)
 		mockSprintHistoryRepo := new(MockSprintHistoryRepository)
 
-		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo)
+		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo, nil)
 
 		userID := uuid.New()
 
@@ -274,16 +307,23 @@ func TestUserService_GetActivities(t *testing.T) {
 			}
 		}
 
-		mockItemHistoryRepo.On("GetByUserID", userID, 0).Return(itemHistories, nil)
-		mockSprintHistoryRepo.On("GetByUserID", userID, 0).Return([]models.SprintHistory{}, nil)
+		mockItemHistoryRepo.On("GetByUserID", userID, "", 4).Return(itemHistories, nil)
+		mockItemHistoryRepo.On("GetAssignmentsForUser", userID, "", 4).Return([]models.ItemHistory{}, nil)
+		mockSprintHistoryRepo.On("GetByUserID", userID, "", 4).Return([]models.SprintHistory{}, nil)
+
+		mockItemHistoryRepo.On("CountByUserID", userID).Return(int64(5), nil)
+		mockItemHistoryRepo.On("CountAssignmentsForUser", userID).Return(int64(0), nil)
+		mockSprintHistoryRepo.On("CountByUserID", userID).Return(int64(0), nil)
 
-		result, err := service.GetActivities(userID, 3)
+		result, err := service.GetActivities(userID, &request.ActivityQueryParams{Limit: 3, IncludeTotal: true})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
-		assert.Equal(t, 5, result.Total)
+		assert.Equal(t, 5, *result.Total)
 		assert.Len(t, result.Activities, 3)
 		assert.Equal(t, 3, result.Limit)
+		assert.True(t, result.HasMore)
+		assert.Equal(t, repository.HistoryCursor(itemHistories[2].Timestamp, itemHistories[2].ID), result.NextCursor)
 
 		mockItemHistoryRepo.AssertExpectations(t)
 		mockSprintHistoryRepo.AssertExpectations(t)
@@ -294,21 +334,127 @@ func TestUserService_GetActivities(t *testing.T) {
 		mockItemHistoryRepo := new(MockItemHistoryRepository)
 		mockSprintHistoryRepo := new(MockSprintHistoryRepository)
 
-		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo)
+		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo, nil)
 
 		userID := uuid.New()
 
-		mockItemHistoryRepo.On("GetByUserID", userID, 0).Return([]models.ItemHistory{}, nil)
-		mockSprintHistoryRepo.On("GetByUserID", userID, 0).Return([]models.SprintHistory{}, nil)
+		mockItemHistoryRepo.On("GetByUserID", userID, "", 11).Return([]models.ItemHistory{}, nil)
+		mockItemHistoryRepo.On("GetAssignmentsForUser", userID, "", 11).Return([]models.ItemHistory{}, nil)
+		mockSprintHistoryRepo.On("GetByUserID", userID, "", 11).Return([]models.SprintHistory{}, nil)
+
+		mockItemHistoryRepo.On("CountByUserID", userID).Return(int64(0), nil)
+		mockItemHistoryRepo.On("CountAssignmentsForUser", userID).Return(int64(0), nil)
+		mockSprintHistoryRepo.On("CountByUserID", userID).Return(int64(0), nil)
 
-		result, err := service.GetActivities(userID, 10)
+		result, err := service.GetActivities(userID, &request.ActivityQueryParams{Limit: 10, IncludeTotal: true})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
-		assert.Equal(t, 0, result.Total)
+		assert.Equal(t, 0, *result.Total)
 		assert.Empty(t, result.Activities)
 
 		mockItemHistoryRepo.AssertExpectations(t)
 		mockSprintHistoryRepo.AssertExpectations(t)
 	})
+
+	t.Run("should include assignments made by other users", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockItemHistoryRepo := new(MockItemHistoryRepository)
+		mockSprintHistoryRepo := new(MockSprintHistoryRepository)
+
+		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo, nil)
+
+		userID := uuid.New()
+		itemID := uuid.New()
+		field := "assignee"
+
+		assignments := []models.ItemHistory{
+			{
+				ID:           uuid.New(),
+				ItemID:       itemID,
+				UserID:       uuid.New(),
+				Action:       constants.ItemActionAssigned,
+				FieldChanged: &field,
+				NewValue:     datatypes.JSON(`"` + userID.String() + `"`),
+				Timestamp:    time.Now(),
+				Item: models.BacklogItem{
+					ID:    itemID,
+					Title: "Assigned Item",
+					Type:  constants.ItemTypeStory,
+				},
+			},
+		}
+
+		mockItemHistoryRepo.On("GetByUserID", userID, "", 11).Return([]models.ItemHistory{}, nil)
+		mockItemHistoryRepo.On("GetAssignmentsForUser", userID, "", 11).Return(assignments, nil)
+		mockSprintHistoryRepo.On("GetByUserID", userID, "", 11).Return([]models.SprintHistory{}, nil)
+
+		mockItemHistoryRepo.On("CountByUserID", userID).Return(int64(0), nil)
+		mockItemHistoryRepo.On("CountAssignmentsForUser", userID).Return(int64(1), nil)
+		mockSprintHistoryRepo.On("CountByUserID", userID).Return(int64(0), nil)
+
+		result, err := service.GetActivities(userID, &request.ActivityQueryParams{Limit: 10, IncludeTotal: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *result.Total)
+		assert.Equal(t, string(constants.ItemActionAssigned), result.Activities[0].Action)
+		assert.Equal(t, userID.String(), result.Activities[0].NewValue)
+		assert.Equal(t, itemID, result.Activities[0].Item.ID)
+
+		mockItemHistoryRepo.AssertExpectations(t)
+		mockSprintHistoryRepo.AssertExpectations(t)
+	})
+	t.Run("should continue from a cursor without counting", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockItemHistoryRepo := new(MockItemHistoryRepository)
+		mockSprintHistoryRepo := new(MockSprintHistoryRepository)
+
+		service := NewUserService(mockUserRepo, mockItemHistoryRepo, mockSprintHistoryRepo, nil)
+
+		userID := uuid.New()
+		cursor := repository.HistoryCursor(time.Now(), uuid.New())
+		older := models.ItemHistory{ID: uuid.New(), UserID: userID, Action: constants.ItemActionUpdated, Timestamp: time.Now().Add(-time.Hour)}
+
+		mockItemHistoryRepo.On("GetByUserID", userID, cursor, 3).Return([]models.ItemHistory{older}, nil)
+		mockItemHistoryRepo.On("GetAssignmentsForUser", userID, cursor, 3).Return([]models.ItemHistory{}, nil)
+		mockSprintHistoryRepo.On("GetByUserID", userID, cursor, 3).Return([]models.SprintHistory{}, nil)
+
+		result, err := service.GetActivities(userID, &request.ActivityQueryParams{Cursor: cursor, Limit: 2})
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.Total)
+		assert.Len(t, result.Activities, 1)
+		assert.False(t, result.HasMore)
+		assert.Empty(t, result.NextCursor)
+		mockItemHistoryRepo.AssertNotCalled(t, "CountByUserID", mock.Anything)
+		mockItemHistoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_GetDueItems(t *testing.T) {
+	t.Run("should split overdue and upcoming items", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewUserService(nil, nil, nil, mockBacklogRepo)
+
+		userID := uuid.New()
+		day := today()
+		yesterday := day.AddDate(0, 0, -1)
+		nextWeek := day.AddDate(0, 0, 7)
+		items := []models.BacklogItem{
+			{ID: uuid.New(), Title: "Late", DueDate: &yesterday},
+			{ID: uuid.New(), Title: "Today", DueDate: &day},
+			{ID: uuid.New(), Title: "Soon", DueDate: &nextWeek},
+		}
+		mockBacklogRepo.On("GetDueForAssignee", userID, day.AddDate(0, 0, 14)).Return(items, nil)
+
+		result, err := service.GetDueItems(userID, 14)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 14, result.Days)
+		assert.Len(t, result.Overdue, 1)
+		assert.Equal(t, "Late", result.Overdue[0].Title)
+		assert.Len(t, result.Upcoming, 2)
+		assert.Equal(t, nextWeek.Format("2006-01-02"), result.Upcoming[1].DueDate)
+		mockBacklogRepo.AssertExpectations(t)
+	})
 }
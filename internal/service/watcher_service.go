@@ -0,0 +1,185 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+var (
+	ErrNotWatching = errors.New("user is not watching")
+)
+
+// WatcherService manages who follows the changes of items and sprints. Creators,
+// assignees and commenters are subscribed by the repositories that record them.
+type WatcherService interface {
+	Watch(entityType constants.WatchEntityType, entityID uuid.UUID, userID uuid.UUID) (*response.WatcherResponse, error)
+	Unwatch(entityType constants.WatchEntityType, entityID uuid.UUID, userID uuid.UUID) error
+	GetWatchers(entityType constants.WatchEntityType, entityID uuid.UUID) ([]response.WatcherResponse, error)
+	GetWatching(userID uuid.UUID, params *request.WatchingQueryParams) ([]response.WatchedEntityResponse, error)
+}
+
+type watcherService struct {
+	watcherRepo repository.WatcherRepository
+	backlogRepo repository.BacklogRepository
+	sprintRepo  repository.SprintRepository
+}
+
+func NewWatcherService(watcherRepo repository.WatcherRepository, backlogRepo repository.BacklogRepository, sprintRepo repository.SprintRepository) WatcherService {
+	return &watcherService{
+		watcherRepo: watcherRepo,
+		backlogRepo: backlogRepo,
+		sprintRepo:  sprintRepo,
+	}
+}
+
+// Watch subscribes the user to the entity. Watching an entity twice keeps the
+// original subscription.
+func (s *watcherService) Watch(entityType constants.WatchEntityType, entityID uuid.UUID, userID uuid.UUID) (*response.WatcherResponse, error) {
+	if err := s.ensureExists(entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	watcher := &models.Watcher{
+		EntityType: entityType,
+		EntityID:   entityID,
+		UserID:     userID,
+		Reason:     constants.WatchReasonManual,
+	}
+	if err := s.watcherRepo.Watch(watcher); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.watcherRepo.Get(entityType, entityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, ErrNotWatching
+	}
+	resp := response.ToWatcherResponse(stored)
+	return &resp, nil
+}
+
+// Unwatch removes the user's subscription, however it was made. Creators, assignees
+// and commenters are only subscribed again when they are newly involved.
+func (s *watcherService) Unwatch(entityType constants.WatchEntityType, entityID uuid.UUID, userID uuid.UUID) error {
+	if err := s.ensureExists(entityType, entityID); err != nil {
+		return err
+	}
+
+	removed, err := s.watcherRepo.Unwatch(entityType, entityID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotWatching
+	}
+	return nil
+}
+
+// GetWatchers returns the users watching the entity, earliest first
+func (s *watcherService) GetWatchers(entityType constants.WatchEntityType, entityID uuid.UUID) ([]response.WatcherResponse, error) {
+	if err := s.ensureExists(entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	watchers, err := s.watcherRepo.GetByEntity(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	return response.ToWatcherListResponse(watchers), nil
+}
+
+// GetWatching returns the items and sprints the user watches, most recently
+// watched first. Deleted entities are left out.
+func (s *watcherService) GetWatching(userID uuid.UUID, params *request.WatchingQueryParams) ([]response.WatchedEntityResponse, error) {
+	watchers, err := s.watcherRepo.GetByUser(userID, constants.WatchEntityType(params.Type))
+	if err != nil {
+		return nil, err
+	}
+
+	var itemIDs, sprintIDs []uuid.UUID
+	for _, w := range watchers {
+		switch w.EntityType {
+		case constants.WatchEntityItem:
+			itemIDs = append(itemIDs, w.EntityID)
+		case constants.WatchEntitySprint:
+			sprintIDs = append(sprintIDs, w.EntityID)
+		}
+	}
+
+	items, err := s.backlogRepo.GetByIDs(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	itemsByID := make(map[uuid.UUID]*models.BacklogItem, len(items))
+	for i := range items {
+		itemsByID[items[i].ID] = &items[i]
+	}
+
+	sprints, err := s.sprintRepo.GetByIDs(sprintIDs)
+	if err != nil {
+		return nil, err
+	}
+	sprintsByID := make(map[uuid.UUID]*models.Sprint, len(sprints))
+	for i := range sprints {
+		sprintsByID[sprints[i].ID] = &sprints[i]
+	}
+
+	resp := make([]response.WatchedEntityResponse, 0, len(watchers))
+	for _, w := range watchers {
+		entity := response.WatchedEntityResponse{
+			EntityType: w.EntityType,
+			EntityID:   w.EntityID,
+			Reason:     w.Reason,
+			CreatedAt:  w.CreatedAt,
+		}
+		switch w.EntityType {
+		case constants.WatchEntityItem:
+			item, ok := itemsByID[w.EntityID]
+			if !ok {
+				continue
+			}
+			entity.Item = response.ToWatchedItemSummary(item)
+		case constants.WatchEntitySprint:
+			sprint, ok := sprintsByID[w.EntityID]
+			if !ok {
+				continue
+			}
+			entity.Sprint = response.ToWatchedSprintSummary(sprint)
+		}
+		resp = append(resp, entity)
+	}
+	return resp, nil
+}
+
+// ensureExists returns the not found error of the entity type when the entity does
+// not exist
+func (s *watcherService) ensureExists(entityType constants.WatchEntityType, entityID uuid.UUID) error {
+	switch entityType {
+	case constants.WatchEntityItem:
+		item, err := s.backlogRepo.GetByID(entityID)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return ErrBacklogItemNotFound
+		}
+	case constants.WatchEntitySprint:
+		sprint, err := s.sprintRepo.GetByID(entityID)
+		if err != nil {
+			return err
+		}
+		if sprint == nil {
+			return ErrSprintNotFound
+		}
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+// MockWatcherRepository is a mock implementation of WatcherRepository
+type MockWatcherRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatcherRepository) Watch(watcher *models.Watcher) error {
+	args := m.Called(watcher)
+	return args.Error(0)
+}
+
+func (m *MockWatcherRepository) Unwatch(entityType constants.WatchEntityType, entityID, userID uuid.UUID) (bool, error) {
+	args := m.Called(entityType, entityID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWatcherRepository) Get(entityType constants.WatchEntityType, entityID, userID uuid.UUID) (*models.Watcher, error) {
+	args := m.Called(entityType, entityID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Watcher), args.Error(1)
+}
+
+func (m *MockWatcherRepository) GetByEntity(entityType constants.WatchEntityType, entityID uuid.UUID) ([]models.Watcher, error) {
+	args := m.Called(entityType, entityID)
+	return args.Get(0).([]models.Watcher), args.Error(1)
+}
+
+func (m *MockWatcherRepository) GetByUser(userID uuid.UUID, entityType constants.WatchEntityType) ([]models.Watcher, error) {
+	args := m.Called(userID, entityType)
+	return args.Get(0).([]models.Watcher), args.Error(1)
+}
+
+// MockSprintRepository is a mock implementation of SprintRepository
+type MockSprintRepository struct {
+	mock.Mock
+}
+
+func (m *MockSprintRepository) Create(sprint *models.Sprint) error {
+	args := m.Called(sprint)
+	return args.Error(0)
+}
+
+func (m *MockSprintRepository) GetByID(id uuid.UUID) (*models.Sprint, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Sprint), args.Error(1)
+}
+
+func (m *MockSprintRepository) GetByIDs(ids []uuid.UUID) ([]models.Sprint, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.Sprint), args.Error(1)
+}
+
+func (m *MockSprintRepository) GetByProjectID(projectID uuid.UUID, filters repository.SprintFilters) ([]models.Sprint, int64, error) {
+	args := m.Called(projectID, filters)
+	return args.Get(0).([]models.Sprint), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSprintRepository) GetAll(filters repository.SprintFilters) ([]models.Sprint, repository.PageInfo, error) {
+	args := m.Called(filters)
+	return args.Get(0).([]models.Sprint), args.Get(1).(repository.PageInfo), args.Error(2)
+}
+
+func (m *MockSprintRepository) GetActive(projectID uuid.UUID) (*models.Sprint, error) {
+	args := m.Called(projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Sprint), args.Error(1)
+}
+
+func (m *MockSprintRepository) Update(sprint *models.Sprint) error {
+	args := m.Called(sprint)
+	return args.Error(0)
+}
+
+func (m *MockSprintRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSprintRepository) UpdateStatus(id uuid.UUID, status constants.SprintStatus) error {
+	args := m.Called(id, status)
+	return args.Error(0)
+}
+
+func (m *MockSprintRepository) GetItemsBySprintID(sprintID uuid.UUID) ([]models.BacklogItem, error) {
+	args := m.Called(sprintID)
+	return args.Get(0).([]models.BacklogItem), args.Error(1)
+}
+
+func (m *MockSprintRepository) CalculateVelocity(sprintID uuid.UUID, doneStatuses []constants.ItemStatus) (int, error) {
+	args := m.Called(sprintID, doneStatuses)
+	return args.Int(0), args.Error(1)
+}
+
+func TestWatcherService_Watch(t *testing.T) {
+	t.Run("should subscribe the user manually", func(t *testing.T) {
+		mockWatcherRepo := new(MockWatcherRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWatcherService(mockWatcherRepo, mockBacklogRepo, nil)
+
+		itemID := uuid.New()
+		userID := uuid.New()
+		stored := &models.Watcher{
+			EntityType: constants.WatchEntityItem,
+			EntityID:   itemID,
+			UserID:     userID,
+			Reason:     constants.WatchReasonManual,
+			User:       models.User{ID: userID, Name: "Alice"},
+		}
+
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID}, nil)
+		mockWatcherRepo.On("Watch", mock.MatchedBy(func(w *models.Watcher) bool {
+			return w.EntityType == constants.WatchEntityItem && w.EntityID == itemID &&
+				w.UserID == userID && w.Reason == constants.WatchReasonManual
+		})).Return(nil)
+		mockWatcherRepo.On("Get", constants.WatchEntityItem, itemID, userID).Return(stored, nil)
+
+		result, err := service.Watch(constants.WatchEntityItem, itemID, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.WatchReasonManual, result.Reason)
+		assert.Equal(t, "Alice", result.User.Name)
+		mockWatcherRepo.AssertExpectations(t)
+	})
+
+	t.Run("should keep an automatic subscription", func(t *testing.T) {
+		mockWatcherRepo := new(MockWatcherRepository)
+		mockSprintRepo := new(MockSprintRepository)
+		service := NewWatcherService(mockWatcherRepo, nil, mockSprintRepo)
+
+		sprintID := uuid.New()
+		userID := uuid.New()
+
+		mockSprintRepo.On("GetByID", sprintID).Return(&models.Sprint{ID: sprintID}, nil)
+		mockWatcherRepo.On("Watch", mock.AnythingOfType("*models.Watcher")).Return(nil)
+		mockWatcherRepo.On("Get", constants.WatchEntitySprint, sprintID, userID).Return(&models.Watcher{
+			EntityType: constants.WatchEntitySprint,
+			EntityID:   sprintID,
+			UserID:     userID,
+			Reason:     constants.WatchReasonCreator,
+		}, nil)
+
+		result, err := service.Watch(constants.WatchEntitySprint, sprintID, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, constants.WatchReasonCreator, result.Reason)
+	})
+
+	t.Run("should return error when sprint not found", func(t *testing.T) {
+		mockWatcherRepo := new(MockWatcherRepository)
+		mockSprintRepo := new(MockSprintRepository)
+		service := NewWatcherService(mockWatcherRepo, nil, mockSprintRepo)
+
+		sprintID := uuid.New()
+		mockSprintRepo.On("GetByID", sprintID).Return(nil, nil)
+
+		result, err := service.Watch(constants.WatchEntitySprint, sprintID, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrSprintNotFound, err)
+		mockWatcherRepo.AssertNotCalled(t, "Watch", mock.Anything)
+	})
+}
+
+func TestWatcherService_Unwatch(t *testing.T) {
+	t.Run("should return error when the user is not watching", func(t *testing.T) {
+		mockWatcherRepo := new(MockWatcherRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWatcherService(mockWatcherRepo, mockBacklogRepo, nil)
+
+		itemID := uuid.New()
+		userID := uuid.New()
+
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID}, nil)
+		mockWatcherRepo.On("Unwatch", constants.WatchEntityItem, itemID, userID).Return(false, nil)
+
+		err := service.Unwatch(constants.WatchEntityItem, itemID, userID)
+
+		assert.Equal(t, ErrNotWatching, err)
+	})
+}
+
+func TestWatcherService_GetWatching(t *testing.T) {
+	t.Run("should describe watched items and sprints and skip deleted ones", func(t *testing.T) {
+		mockWatcherRepo := new(MockWatcherRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		mockSprintRepo := new(MockSprintRepository)
+		service := NewWatcherService(mockWatcherRepo, mockBacklogRepo, mockSprintRepo)
+
+		userID := uuid.New()
+		item := models.BacklogItem{ID: uuid.New(), Number: 3, Title: "Login", Status: constants.ItemStatusNew, Project: models.Project{Key: "APP"}}
+		sprint := models.Sprint{ID: uuid.New(), Name: "Sprint 1", Status: constants.SprintStatusActive}
+		deletedItemID := uuid.New()
+		now := time.Now()
+
+		mockWatcherRepo.On("GetByUser", userID, constants.WatchEntityType("")).Return([]models.Watcher{
+			{EntityType: constants.WatchEntityItem, EntityID: item.ID, UserID: userID, Reason: constants.WatchReasonAssignee, CreatedAt: now},
+			{EntityType: constants.WatchEntitySprint, EntityID: sprint.ID, UserID: userID, Reason: constants.WatchReasonCreator, CreatedAt: now},
+			{EntityType: constants.WatchEntityItem, EntityID: deletedItemID, UserID: userID, Reason: constants.WatchReasonManual, CreatedAt: now},
+		}, nil)
+		mockBacklogRepo.On("GetByIDs", []uuid.UUID{item.ID, deletedItemID}).Return([]models.BacklogItem{item}, nil)
+		mockSprintRepo.On("GetByIDs", []uuid.UUID{sprint.ID}).Return([]models.Sprint{sprint}, nil)
+
+		result, err := service.GetWatching(userID, &request.WatchingQueryParams{})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, "APP-3", result[0].Item.Key)
+		assert.Equal(t, constants.WatchReasonAssignee, result[0].Reason)
+		assert.Nil(t, result[0].Sprint)
+		assert.Equal(t, "Sprint 1", result[1].Sprint.Name)
+		assert.Nil(t, result[1].Item)
+	})
+}
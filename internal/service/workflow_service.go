@@ -0,0 +1,375 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+var (
+	ErrTransitionNotAllowed    = errors.New("status transition is not allowed by the project workflow")
+	ErrInvalidStatusCategory   = errors.New("status category must be todo, in_progress, done or archived")
+	ErrDuplicateWorkflowStatus = errors.New("workflow statuses must be unique")
+	ErrUnknownTransitionStatus = errors.New("transition refers to a status that is not in the workflow")
+	ErrWorkflowStatusInUse     = errors.New("items still use a status that is not in the new workflow")
+	ErrWIPLimitExceeded        = errors.New("status column has reached its work-in-progress limit")
+	ErrInvalidWIPLimit         = errors.New("work-in-progress limit must be at least 1")
+)
+
+type WorkflowService interface {
+	GetByProjectID(projectID uuid.UUID) (*response.WorkflowResponse, error)
+	Update(projectID uuid.UUID, req *request.UpdateWorkflowRequest) (*response.WorkflowResponse, error)
+	UpdateWIPLimits(projectID uuid.UUID, req *request.UpdateWIPLimitsRequest) (*response.WorkflowResponse, error)
+}
+
+type workflowService struct {
+	workflowRepo repository.WorkflowRepository
+	projectRepo  repository.ProjectRepository
+	backlogRepo  repository.BacklogRepository
+}
+
+func NewWorkflowService(
+	workflowRepo repository.WorkflowRepository,
+	projectRepo repository.ProjectRepository,
+	backlogRepo repository.BacklogRepository,
+) WorkflowService {
+	return &workflowService{
+		workflowRepo: workflowRepo,
+		projectRepo:  projectRepo,
+		backlogRepo:  backlogRepo,
+	}
+}
+
+func (s *workflowService) GetByProjectID(projectID uuid.UUID) (*response.WorkflowResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	workflow, err := loadWorkflow(s.workflowRepo, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ToWorkflowResponse(workflow), nil
+}
+
+func (s *workflowService) Update(projectID uuid.UUID, req *request.UpdateWorkflowRequest) (*response.WorkflowResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	// Build and validate statuses
+	statuses := make([]models.WorkflowStatus, 0, len(req.Statuses))
+	seen := make(map[constants.ItemStatus]bool, len(req.Statuses))
+	for _, st := range req.Statuses {
+		name := constants.ItemStatus(strings.TrimSpace(string(st.Name)))
+		if name == "" {
+			return nil, ErrInvalidStatus
+		}
+		if !st.Category.IsValid() {
+			return nil, ErrInvalidStatusCategory
+		}
+		if seen[name] {
+			return nil, ErrDuplicateWorkflowStatus
+		}
+		if st.WIPLimit != nil && *st.WIPLimit < 1 {
+			return nil, ErrInvalidWIPLimit
+		}
+		seen[name] = true
+		statuses = append(statuses, models.WorkflowStatus{Name: name, Category: st.Category, WIPLimit: st.WIPLimit})
+	}
+
+	// Build and validate transitions
+	transitions := make([]models.WorkflowTransition, 0, len(req.Transitions))
+	for _, t := range req.Transitions {
+		if !seen[t.From] || !seen[t.To] {
+			return nil, ErrUnknownTransitionStatus
+		}
+		transitions = append(transitions, models.WorkflowTransition{From: t.From, To: t.To})
+	}
+
+	workflow := &models.Workflow{
+		ProjectID:   projectID,
+		Statuses:    statuses,
+		Transitions: transitions,
+	}
+
+	// Items must not be left in a status that no longer exists
+	inUse, err := s.backlogRepo.CountOutsideStatuses(projectID, workflow.StatusNames())
+	if err != nil {
+		return nil, err
+	}
+	if inUse > 0 {
+		return nil, ErrWorkflowStatusInUse
+	}
+
+	existing, err := s.workflowRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		workflow.ID = existing.ID
+		workflow.CreatedAt = existing.CreatedAt
+	}
+
+	if err := s.workflowRepo.Save(workflow); err != nil {
+		return nil, err
+	}
+
+	return response.ToWorkflowResponse(workflow), nil
+}
+
+func (s *workflowService) UpdateWIPLimits(projectID uuid.UUID, req *request.UpdateWIPLimitsRequest) (*response.WorkflowResponse, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	// Projects on the default workflow get their own copy carrying the limits
+	workflow, err := loadWorkflow(s.workflowRepo, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for status, limit := range req.Limits {
+		if !workflow.HasStatus(status) {
+			return nil, ErrInvalidStatus
+		}
+		if limit != nil && *limit < 1 {
+			return nil, ErrInvalidWIPLimit
+		}
+	}
+
+	for i, st := range workflow.Statuses {
+		if limit, ok := req.Limits[st.Name]; ok {
+			workflow.Statuses[i].WIPLimit = limit
+		}
+	}
+
+	if err := s.workflowRepo.Save(workflow); err != nil {
+		return nil, err
+	}
+
+	return response.ToWorkflowResponse(workflow), nil
+}
+
+// defaultWorkflow is used for projects that have not defined their own workflow.
+// It mirrors the built-in item statuses and allows any transition.
+func defaultWorkflow(projectID uuid.UUID) *models.Workflow {
+	return &models.Workflow{
+		ProjectID: projectID,
+		Statuses: []models.WorkflowStatus{
+			{Name: constants.ItemStatusNew, Category: constants.StatusCategoryTodo},
+			{Name: constants.ItemStatusReady, Category: constants.StatusCategoryTodo},
+			{Name: constants.ItemStatusInProgress, Category: constants.StatusCategoryInProgress},
+			{Name: constants.ItemStatusDone, Category: constants.StatusCategoryDone},
+			{Name: constants.ItemStatusArchived, Category: constants.StatusCategoryArchived},
+		},
+	}
+}
+
+// loadWorkflow returns the project's workflow, falling back to the default workflow
+func loadWorkflow(workflowRepo repository.WorkflowRepository, projectID uuid.UUID) (*models.Workflow, error) {
+	workflow, err := workflowRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if workflow == nil {
+		return defaultWorkflow(projectID), nil
+	}
+	return workflow, nil
+}
+
+// workflowCache loads each project's workflow at most once while building a response
+type workflowCache struct {
+	repo      repository.WorkflowRepository
+	workflows map[uuid.UUID]*models.Workflow
+}
+
+func newWorkflowCache(repo repository.WorkflowRepository) *workflowCache {
+	return &workflowCache{repo: repo, workflows: make(map[uuid.UUID]*models.Workflow)}
+}
+
+func (c *workflowCache) get(projectID uuid.UUID) (*models.Workflow, error) {
+	if workflow, ok := c.workflows[projectID]; ok {
+		return workflow, nil
+	}
+	workflow, err := loadWorkflow(c.repo, projectID)
+	if err != nil {
+		return nil, err
+	}
+	c.workflows[projectID] = workflow
+	return workflow, nil
+}
+
+// validateTransition checks a status change against the project workflow
+func validateTransition(workflow *models.Workflow, from, to constants.ItemStatus) error {
+	if !workflow.HasStatus(to) {
+		return ErrInvalidStatus
+	}
+	if !workflow.CanTransition(from, to) {
+		return ErrTransitionNotAllowed
+	}
+	return nil
+}
+
+// wipOverride describes a work-in-progress limit that was knowingly exceeded
+type wipOverride struct {
+	Status constants.ItemStatus `json:"status"`
+	Limit  int                  `json:"limit"`
+	Load   int64                `json:"load"`
+}
+
+// checkWIPLimit verifies that moving the item into status keeps the column within its
+// limit. The column is counted like CountByStatus counts sprintID. With override a
+// move into a full column is allowed and the exceeded limit is returned for the
+// history; any other allowed move returns guards that check the limit again when the
+// move is written, so a concurrent move cannot exceed it unrecorded.
+func checkWIPLimit(backlogRepo repository.BacklogRepository, workflow *models.Workflow, item *models.BacklogItem, status constants.ItemStatus, sprintID *uuid.UUID, override bool) (*wipOverride, []repository.WIPGuard, error) {
+	limit := workflow.WIPLimit(status)
+	if limit == nil || item.Status == status {
+		return nil, nil, nil
+	}
+
+	counts, err := backlogRepo.CountByStatus(item.ProjectID, sprintID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	load := counts[status]
+	if load < int64(*limit) {
+		return nil, []repository.WIPGuard{wipGuard(item.ProjectID, sprintID, status, *limit)}, nil
+	}
+	if !override {
+		return nil, nil, ErrWIPLimitExceeded
+	}
+
+	return &wipOverride{Status: status, Limit: *limit, Load: load}, nil, nil
+}
+
+// wipGuard rejects a write that leaves the column above its limit
+func wipGuard(projectID uuid.UUID, sprintID *uuid.UUID, status constants.ItemStatus, limit int) repository.WIPGuard {
+	return repository.WIPGuard{
+		ProjectID: projectID,
+		SprintID:  sprintID,
+		Status:    status,
+		Check: func(load int64) error {
+			if load > int64(limit) {
+				return ErrWIPLimitExceeded
+			}
+			return nil
+		},
+	}
+}
+
+// itemWIPScope returns the scope WIP limits are checked in for an item: its sprint, or
+// the items without a sprint, as the sprint and backlog boards show them
+func itemWIPScope(sprintID *uuid.UUID) *uuid.UUID {
+	if sprintID == nil {
+		return &uuid.Nil
+	}
+	return sprintID
+}
+
+// history builds the item history entry recording the override
+func (o *wipOverride) history(itemID, userID uuid.UUID) models.ItemHistory {
+	newVal, _ := json.Marshal(o)
+	field := "status"
+	return models.ItemHistory{
+		ItemID:       itemID,
+		UserID:       userID,
+		Action:       constants.ItemActionWIPLimitOverridden,
+		FieldChanged: &field,
+		NewValue:     datatypes.JSON(newVal),
+	}
+}
+
+// wipTracker checks work-in-progress limits for a batch of status changes. Column
+// loads are counted once per scope and updated as items are accepted, so items moved
+// earlier in the batch count towards the limits checked for later ones.
+type wipTracker struct {
+	backlogRepo repository.BacklogRepository
+	counts      map[wipScope]map[constants.ItemStatus]int64
+	guarded     map[wipColumn]bool
+	guards      []repository.WIPGuard
+}
+
+// wipScope identifies where a column is counted: a sprint, or the items without a
+// sprint when SprintID is uuid.Nil
+type wipScope struct {
+	ProjectID uuid.UUID
+	SprintID  uuid.UUID
+}
+
+// wipColumn is a column within a scope
+type wipColumn struct {
+	wipScope
+	Status constants.ItemStatus
+}
+
+func newWIPTracker(backlogRepo repository.BacklogRepository) *wipTracker {
+	return &wipTracker{
+		backlogRepo: backlogRepo,
+		counts:      make(map[wipScope]map[constants.ItemStatus]int64),
+		guarded:     make(map[wipColumn]bool),
+	}
+}
+
+// check works like checkWIPLimit for the item's current sprint and, when the move is
+// allowed, records it in the tracked loads
+func (t *wipTracker) check(workflow *models.Workflow, item *models.BacklogItem, status constants.ItemStatus, override bool) (*wipOverride, error) {
+	limit := workflow.WIPLimit(status)
+	if limit == nil || item.Status == status {
+		return nil, nil
+	}
+
+	sprintID := itemWIPScope(item.SprintID)
+	scope := wipScope{ProjectID: item.ProjectID, SprintID: *sprintID}
+	counts, ok := t.counts[scope]
+	if !ok {
+		var err error
+		if counts, err = t.backlogRepo.CountByStatus(item.ProjectID, sprintID); err != nil {
+			return nil, err
+		}
+		t.counts[scope] = counts
+	}
+
+	load := counts[status]
+	var exceeded *wipOverride
+	if load >= int64(*limit) {
+		if !override {
+			return nil, ErrWIPLimitExceeded
+		}
+		exceeded = &wipOverride{Status: status, Limit: *limit, Load: load}
+	}
+
+	column := wipColumn{wipScope: scope, Status: status}
+	if exceeded == nil && !t.guarded[column] {
+		t.guarded[column] = true
+		t.guards = append(t.guards, wipGuard(item.ProjectID, sprintID, status, *limit))
+	}
+
+	counts[status]++
+	counts[item.Status]--
+	return exceeded, nil
+}
@@ -0,0 +1,462 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+// MockWorkflowRepository is a mock implementation of WorkflowRepository
+type MockWorkflowRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkflowRepository) GetByProjectID(projectID uuid.UUID) (*models.Workflow, error) {
+	args := m.Called(projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workflow), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) Save(workflow *models.Workflow) error {
+	args := m.Called(workflow)
+	return args.Error(0)
+}
+
+// MockBacklogRepository is a mock implementation of BacklogRepository
+type MockBacklogRepository struct {
+	mock.Mock
+}
+
+func (m *MockBacklogRepository) Create(item *models.BacklogItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) CreateWithChildren(item *models.BacklogItem, children []models.BacklogItem) error {
+	args := m.Called(item, children)
+	return args.Error(0)
+}
+
+// CreateClone numbers the clones from 1 like an empty project would before building
+// their histories
+func (m *MockBacklogRepository) CreateClone(item *models.BacklogItem, children []models.BacklogItem, links []models.ItemLink, histories func(item *models.BacklogItem, children []models.BacklogItem) []models.ItemHistory) error {
+	item.Number = 1
+	for i := range children {
+		children[i].Number = i + 2
+	}
+	args := m.Called(item, children, links, histories(item, children))
+	return args.Error(0)
+}
+
+// MoveToProject numbers the items from 1 like an empty project would before
+// building their histories
+func (m *MockBacklogRepository) MoveToProject(items []models.BacklogItem, histories func(placed []models.BacklogItem) []models.ItemHistory) error {
+	for i := range items {
+		items[i].Number = i + 1
+	}
+	args := m.Called(items, histories(items))
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) GetByID(id uuid.UUID) (*models.BacklogItem, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BacklogItem), args.Error(1)
+}
+
+func (m *MockBacklogRepository) GetByKey(projectKey string, number int) (*models.BacklogItem, error) {
+	args := m.Called(projectKey, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BacklogItem), args.Error(1)
+}
+
+func (m *MockBacklogRepository) GetByProjectID(projectID uuid.UUID, filters repository.BacklogFilters) ([]models.BacklogItem, int64, error) {
+	args := m.Called(projectID, filters)
+	return args.Get(0).([]models.BacklogItem), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBacklogRepository) GetBySprintID(sprintID uuid.UUID) ([]models.BacklogItem, error) {
+	args := m.Called(sprintID)
+	return args.Get(0).([]models.BacklogItem), args.Error(1)
+}
+
+func (m *MockBacklogRepository) GetAll(filters repository.BacklogFilters) ([]models.BacklogItem, repository.PageInfo, error) {
+	args := m.Called(filters)
+	return args.Get(0).([]models.BacklogItem), args.Get(1).(repository.PageInfo), args.Error(2)
+}
+
+func (m *MockBacklogRepository) Update(item *models.BacklogItem, columns []string, guards ...repository.WIPGuard) error {
+	args := m.Called(item, columns)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) UpdateStatus(id uuid.UUID, status constants.ItemStatus, guards ...repository.WIPGuard) error {
+	args := m.Called(id, status)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) UpdatePriority(id uuid.UUID, priority constants.Priority) error {
+	args := m.Called(id, priority)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) AddLabel(id uuid.UUID, label string) error {
+	args := m.Called(id, label)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) RemoveLabel(id uuid.UUID, label string) error {
+	args := m.Called(id, label)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) GetMaxPosition(projectID uuid.UUID) (int, error) {
+	args := m.Called(projectID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBacklogRepository) MoveToColumn(id uuid.UUID, column repository.BoardColumn, index int, histories []models.ItemHistory, guards ...repository.WIPGuard) error {
+	args := m.Called(id, column, index, histories)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) CountOutsideStatuses(projectID uuid.UUID, statuses []constants.ItemStatus) (int64, error) {
+	args := m.Called(projectID, statuses)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBacklogRepository) UpdateParent(id uuid.UUID, parentID *uuid.UUID) error {
+	args := m.Called(id, parentID)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) UpdateDueDate(id uuid.UUID, dueDate *time.Time) error {
+	args := m.Called(id, dueDate)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) GetDueForAssignee(userID uuid.UUID, until time.Time) ([]models.BacklogItem, error) {
+	args := m.Called(userID, until)
+	return args.Get(0).([]models.BacklogItem), args.Error(1)
+}
+
+func (m *MockBacklogRepository) GetChildren(parentID uuid.UUID) ([]models.BacklogItem, error) {
+	args := m.Called(parentID)
+	return args.Get(0).([]models.BacklogItem), args.Error(1)
+}
+
+func (m *MockBacklogRepository) GetAncestorIDs(id uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(id)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockBacklogRepository) GetChildStats(parentIDs []uuid.UUID) ([]repository.ChildStats, error) {
+	args := m.Called(parentIDs)
+	return args.Get(0).([]repository.ChildStats), args.Error(1)
+}
+
+func (m *MockBacklogRepository) MoveRank(id, anchorID uuid.UUID, after bool) (string, error) {
+	args := m.Called(id, anchorID, after)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockBacklogRepository) GetByIDs(ids []uuid.UUID) ([]models.BacklogItem, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.BacklogItem), args.Error(1)
+}
+
+// ApplyBulk passes the items stubbed for GetByIDs to change and records the returned
+// changes; nothing is recorded when change fails
+func (m *MockBacklogRepository) ApplyBulk(ids []uuid.UUID, change repository.BulkChanger) error {
+	items, err := m.GetByIDs(ids)
+	if err != nil {
+		return err
+	}
+	changes, err := change(items)
+	if err != nil {
+		return err
+	}
+	args := m.Called(ids, changes)
+	return args.Error(0)
+}
+
+func (m *MockBacklogRepository) GetSearchHighlights(ids []uuid.UUID, search string) ([]repository.SearchHighlight, error) {
+	args := m.Called(ids, search)
+	return args.Get(0).([]repository.SearchHighlight), args.Error(1)
+}
+
+func (m *MockBacklogRepository) CountByStatus(projectID uuid.UUID, sprintID *uuid.UUID) (map[constants.ItemStatus]int64, error) {
+	args := m.Called(projectID, sprintID)
+	return args.Get(0).(map[constants.ItemStatus]int64), args.Error(1)
+}
+
+func TestWorkflowService_GetByProjectID(t *testing.T) {
+	t.Run("should return default workflow when project has none", func(t *testing.T) {
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWorkflowService(mockWorkflowRepo, mockProjectRepo, mockBacklogRepo)
+
+		projectID := uuid.New()
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(nil, nil)
+
+		result, err := service.GetByProjectID(projectID)
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsDefault)
+		assert.Len(t, result.Statuses, 5)
+		assert.Empty(t, result.Transitions)
+		mockWorkflowRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when project not found", func(t *testing.T) {
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWorkflowService(mockWorkflowRepo, mockProjectRepo, mockBacklogRepo)
+
+		projectID := uuid.New()
+		mockProjectRepo.On("GetByID", projectID).Return(nil, nil)
+
+		result, err := service.GetByProjectID(projectID)
+
+		assert.Equal(t, ErrProjectNotFound, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestWorkflowService_Update(t *testing.T) {
+	t.Run("should save a valid workflow", func(t *testing.T) {
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWorkflowService(mockWorkflowRepo, mockProjectRepo, mockBacklogRepo)
+
+		projectID := uuid.New()
+		req := &request.UpdateWorkflowRequest{
+			Statuses: []request.WorkflowStatusRequest{
+				{Name: "To Do", Category: constants.StatusCategoryTodo},
+				{Name: "Doing", Category: constants.StatusCategoryInProgress},
+				{Name: "Shipped", Category: constants.StatusCategoryDone},
+			},
+			Transitions: []request.WorkflowTransitionRequest{
+				{From: "To Do", To: "Doing"},
+				{From: "Doing", To: "Shipped"},
+			},
+		}
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockBacklogRepo.On("CountOutsideStatuses", projectID, []constants.ItemStatus{"To Do", "Doing", "Shipped"}).Return(int64(0), nil)
+		mockWorkflowRepo.On("GetByProjectID", projectID).Return(nil, nil)
+		mockWorkflowRepo.On("Save", mock.AnythingOfType("*models.Workflow")).Return(nil)
+
+		result, err := service.Update(projectID, req)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Statuses, 3)
+		assert.Len(t, result.Transitions, 2)
+		mockWorkflowRepo.AssertExpectations(t)
+		mockBacklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject duplicate statuses", func(t *testing.T) {
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWorkflowService(mockWorkflowRepo, mockProjectRepo, mockBacklogRepo)
+
+		projectID := uuid.New()
+		req := &request.UpdateWorkflowRequest{
+			Statuses: []request.WorkflowStatusRequest{
+				{Name: "Open", Category: constants.StatusCategoryTodo},
+				{Name: "Open", Category: constants.StatusCategoryDone},
+			},
+		}
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+
+		result, err := service.Update(projectID, req)
+
+		assert.Equal(t, ErrDuplicateWorkflowStatus, err)
+		assert.Nil(t, result)
+		mockWorkflowRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("should reject transitions to unknown statuses", func(t *testing.T) {
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWorkflowService(mockWorkflowRepo, mockProjectRepo, mockBacklogRepo)
+
+		projectID := uuid.New()
+		req := &request.UpdateWorkflowRequest{
+			Statuses: []request.WorkflowStatusRequest{
+				{Name: "Open", Category: constants.StatusCategoryTodo},
+			},
+			Transitions: []request.WorkflowTransitionRequest{
+				{From: "Open", To: "Closed"},
+			},
+		}
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+
+		result, err := service.Update(projectID, req)
+
+		assert.Equal(t, ErrUnknownTransitionStatus, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should reject removing a status that items still use", func(t *testing.T) {
+		mockWorkflowRepo := new(MockWorkflowRepository)
+		mockProjectRepo := new(MockProjectRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWorkflowService(mockWorkflowRepo, mockProjectRepo, mockBacklogRepo)
+
+		projectID := uuid.New()
+		req := &request.UpdateWorkflowRequest{
+			Statuses: []request.WorkflowStatusRequest{
+				{Name: "Open", Category: constants.StatusCategoryTodo},
+				{Name: "Closed", Category: constants.StatusCategoryDone},
+			},
+		}
+
+		mockProjectRepo.On("GetByID", projectID).Return(&models.Project{ID: projectID}, nil)
+		mockBacklogRepo.On("CountOutsideStatuses", projectID, mock.Anything).Return(int64(3), nil)
+
+		result, err := service.Update(projectID, req)
+
+		assert.Equal(t, ErrWorkflowStatusInUse, err)
+		assert.Nil(t, result)
+		mockWorkflowRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestDefaultWorkflow(t *testing.T) {
+	workflow := defaultWorkflow(uuid.New())
+
+	// Archived items are closed without counting as completed work
+	assert.Equal(t, []constants.ItemStatus{constants.ItemStatusDone}, workflow.StatusesIn(constants.StatusCategoryDone))
+	category, _ := workflow.Category(constants.ItemStatusArchived)
+	assert.Equal(t, constants.StatusCategoryArchived, category)
+	assert.True(t, category.IsClosed())
+}
+
+func TestValidateTransition(t *testing.T) {
+	workflow := &models.Workflow{
+		Statuses: []models.WorkflowStatus{
+			{Name: "Open", Category: constants.StatusCategoryTodo},
+			{Name: "Doing", Category: constants.StatusCategoryInProgress},
+			{Name: "Closed", Category: constants.StatusCategoryDone},
+		},
+		Transitions: []models.WorkflowTransition{
+			{From: "Open", To: "Doing"},
+			{From: "Doing", To: "Closed"},
+		},
+	}
+
+	assert.NoError(t, validateTransition(workflow, "Open", "Doing"))
+	assert.NoError(t, validateTransition(workflow, "Doing", "Doing"))
+	assert.Equal(t, ErrTransitionNotAllowed, validateTransition(workflow, "Open", "Closed"))
+	assert.Equal(t, ErrInvalidStatus, validateTransition(workflow, "Open", "Archived"))
+
+	// Without transitions any move between known statuses is allowed
+	workflow.Transitions = nil
+	assert.NoError(t, validateTransition(workflow, "Open", "Closed"))
+}
+
+func TestCheckWIPLimit(t *testing.T) {
+	limit := 2
+	workflow := &models.Workflow{
+		Statuses: []models.WorkflowStatus{
+			{Name: "Open", Category: constants.StatusCategoryTodo},
+			{Name: "Doing", Category: constants.StatusCategoryInProgress, WIPLimit: &limit},
+		},
+	}
+
+	t.Run("should allow a move below the limit", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Status: "Open"}
+		mockBacklogRepo.On("CountByStatus", item.ProjectID, (*uuid.UUID)(nil)).
+			Return(map[constants.ItemStatus]int64{"Doing": 1}, nil)
+
+		override, guards, err := checkWIPLimit(mockBacklogRepo, workflow, item, "Doing", nil, false)
+
+		assert.NoError(t, err)
+		assert.Nil(t, override)
+		assert.Len(t, guards, 1)
+		assert.Equal(t, constants.ItemStatus("Doing"), guards[0].Status)
+		assert.NoError(t, guards[0].Check(2))
+		assert.Equal(t, ErrWIPLimitExceeded, guards[0].Check(3))
+	})
+
+	t.Run("should reject a move into a full column", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Status: "Open"}
+		mockBacklogRepo.On("CountByStatus", item.ProjectID, (*uuid.UUID)(nil)).
+			Return(map[constants.ItemStatus]int64{"Doing": 2}, nil)
+
+		override, _, err := checkWIPLimit(mockBacklogRepo, workflow, item, "Doing", nil, false)
+
+		assert.Equal(t, ErrWIPLimitExceeded, err)
+		assert.Nil(t, override)
+	})
+
+	t.Run("should report an explicit override", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Status: "Open"}
+		mockBacklogRepo.On("CountByStatus", item.ProjectID, (*uuid.UUID)(nil)).
+			Return(map[constants.ItemStatus]int64{"Doing": 2}, nil)
+
+		override, _, err := checkWIPLimit(mockBacklogRepo, workflow, item, "Doing", nil, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, override.Limit)
+		assert.Equal(t, int64(2), override.Load)
+		assert.Equal(t, constants.ItemActionWIPLimitOverridden, override.history(item.ID, uuid.New()).Action)
+	})
+
+	t.Run("should guard an override below the limit", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Status: "Open"}
+		mockBacklogRepo.On("CountByStatus", item.ProjectID, (*uuid.UUID)(nil)).
+			Return(map[constants.ItemStatus]int64{"Doing": 1}, nil)
+
+		override, guards, err := checkWIPLimit(mockBacklogRepo, workflow, item, "Doing", nil, true)
+
+		assert.NoError(t, err)
+		assert.Nil(t, override)
+		assert.Len(t, guards, 1)
+		assert.Equal(t, ErrWIPLimitExceeded, guards[0].Check(3))
+	})
+
+	t.Run("should ignore columns without a limit", func(t *testing.T) {
+		mockBacklogRepo := new(MockBacklogRepository)
+		item := &models.BacklogItem{ID: uuid.New(), ProjectID: uuid.New(), Status: "Doing"}
+
+		override, _, err := checkWIPLimit(mockBacklogRepo, workflow, item, "Open", nil, false)
+
+		assert.NoError(t, err)
+		assert.Nil(t, override)
+		mockBacklogRepo.AssertNotCalled(t, "CountByStatus", mock.Anything, mock.Anything)
+	})
+}
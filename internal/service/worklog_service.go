@@ -0,0 +1,277 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/dto/response"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+var (
+	ErrWorklogNotFound       = errors.New("worklog not found")
+	ErrWorklogForbidden      = errors.New("only the author can change a worklog")
+	ErrInvalidWorklogDate    = errors.New("work date must be a date in YYYY-MM-DD format and not in the future")
+	ErrInvalidTimesheetRange = errors.New("timesheet range must start before it ends and span at most a year")
+)
+
+// timesheetWeeks is the number of weeks, up to and including the current one, that a
+// timesheet covers by default
+const timesheetWeeks = 4
+
+type WorklogService interface {
+	Create(itemID uuid.UUID, req *request.CreateWorklogRequest, userID uuid.UUID) (*response.WorklogResponse, error)
+	GetByItemID(itemID uuid.UUID) (*response.ItemWorklogsResponse, error)
+	Update(id uuid.UUID, req *request.UpdateWorklogRequest, userID uuid.UUID) (*response.WorklogResponse, error)
+	Delete(id uuid.UUID, userID uuid.UUID) error
+	GetTimesheet(userID uuid.UUID, params *request.TimesheetQueryParams) (*response.TimesheetResponse, error)
+}
+
+type worklogService struct {
+	worklogRepo repository.WorklogRepository
+	backlogRepo repository.BacklogRepository
+}
+
+func NewWorklogService(worklogRepo repository.WorklogRepository, backlogRepo repository.BacklogRepository) WorklogService {
+	return &worklogService{
+		worklogRepo: worklogRepo,
+		backlogRepo: backlogRepo,
+	}
+}
+
+// Create logs work on an item. The minutes are added to the item's time spent and
+// taken off its remaining estimate.
+func (s *worklogService) Create(itemID uuid.UUID, req *request.CreateWorklogRequest, userID uuid.UUID) (*response.WorklogResponse, error) {
+	date, err := parseWorkDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.backlogRepo.GetByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	worklog := &models.Worklog{
+		ID:      uuid.New(),
+		ItemID:  itemID,
+		UserID:  userID,
+		Minutes: req.Minutes,
+		Date:    date,
+	}
+	if note := strings.TrimSpace(req.Note); note != "" {
+		worklog.Note = &note
+	}
+
+	history := worklogHistory(worklog, constants.ItemActionWorkLogged, userID)
+	if err := s.worklogRepo.Create(worklog, history); err != nil {
+		return nil, err
+	}
+
+	return s.worklogResponse(worklog.ID)
+}
+
+// GetByItemID returns the item's worklogs with the time logged in total and per user
+func (s *worklogService) GetByItemID(itemID uuid.UUID) (*response.ItemWorklogsResponse, error) {
+	item, err := s.backlogRepo.GetByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrBacklogItemNotFound
+	}
+
+	worklogs, err := s.worklogRepo.GetByItemID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ToItemWorklogsResponse(item, worklogs), nil
+}
+
+// Update edits a worklog and moves the difference in minutes to the item's time spent
+func (s *worklogService) Update(id uuid.UUID, req *request.UpdateWorklogRequest, userID uuid.UUID) (*response.WorklogResponse, error) {
+	worklog, err := s.worklogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if worklog == nil {
+		return nil, ErrWorklogNotFound
+	}
+	if worklog.UserID != userID {
+		return nil, ErrWorklogForbidden
+	}
+
+	if req.Date != "" {
+		date, err := parseWorkDate(req.Date)
+		if err != nil {
+			return nil, err
+		}
+		worklog.Date = date
+	}
+
+	delta := req.Minutes - worklog.Minutes
+	worklog.Minutes = req.Minutes
+	worklog.Note = nil
+	if note := strings.TrimSpace(req.Note); note != "" {
+		worklog.Note = &note
+	}
+
+	history := worklogHistory(worklog, constants.ItemActionWorklogEdited, userID)
+	if err := s.worklogRepo.Update(worklog, delta, history); err != nil {
+		return nil, err
+	}
+
+	return s.worklogResponse(worklog.ID)
+}
+
+// Delete removes a worklog and gives its minutes back to the item's remaining estimate
+func (s *worklogService) Delete(id uuid.UUID, userID uuid.UUID) error {
+	worklog, err := s.worklogRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if worklog == nil {
+		return ErrWorklogNotFound
+	}
+	if worklog.UserID != userID {
+		return ErrWorklogForbidden
+	}
+
+	history := worklogHistory(worklog, constants.ItemActionWorklogDeleted, userID)
+	return s.worklogRepo.Delete(worklog, history)
+}
+
+// GetTimesheet returns the time a user logged per week and item across projects.
+// Without a range it covers the current week and the three before it.
+func (s *worklogService) GetTimesheet(userID uuid.UUID, params *request.TimesheetQueryParams) (*response.TimesheetResponse, error) {
+	to := today()
+	if params.To != "" {
+		day, err := time.Parse(constants.DateLayout, params.To)
+		if err != nil {
+			return nil, ErrInvalidTimesheetRange
+		}
+		to = day
+	}
+	from := weekStart(to).AddDate(0, 0, -7*(timesheetWeeks-1))
+	if params.From != "" {
+		day, err := time.Parse(constants.DateLayout, params.From)
+		if err != nil {
+			return nil, ErrInvalidTimesheetRange
+		}
+		from = day
+	}
+	if to.Before(from) || to.After(from.AddDate(1, 0, 0)) {
+		return nil, ErrInvalidTimesheetRange
+	}
+
+	rows, err := s.worklogRepo.GetWeeklyByUser(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the logged items for their keys and titles
+	var itemIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, row := range rows {
+		if !seen[row.ItemID] {
+			seen[row.ItemID] = true
+			itemIDs = append(itemIDs, row.ItemID)
+		}
+	}
+	items, err := s.backlogRepo.GetByIDs(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*models.BacklogItem, len(items))
+	for i := range items {
+		byID[items[i].ID] = &items[i]
+	}
+
+	resp := &response.TimesheetResponse{
+		UserID: userID,
+		From:   from.Format(constants.DateLayout),
+		To:     to.Format(constants.DateLayout),
+		Weeks:  []response.TimesheetWeekResponse{},
+	}
+	for _, row := range rows {
+		week := row.WeekStart.Format(constants.DateLayout)
+		if n := len(resp.Weeks); n == 0 || resp.Weeks[n-1].WeekStart != week {
+			resp.Weeks = append(resp.Weeks, response.TimesheetWeekResponse{WeekStart: week})
+		}
+		entry := response.TimesheetItemResponse{ItemID: row.ItemID, Minutes: row.Minutes}
+		if item := byID[row.ItemID]; item != nil {
+			entry.Key = item.Key()
+			entry.Title = item.Title
+			entry.ProjectID = item.ProjectID
+		}
+		current := &resp.Weeks[len(resp.Weeks)-1]
+		current.Items = append(current.Items, entry)
+		current.TotalMinutes += row.Minutes
+		resp.TotalMinutes += row.Minutes
+	}
+	return resp, nil
+}
+
+func (s *worklogService) worklogResponse(id uuid.UUID) (*response.WorklogResponse, error) {
+	worklog, err := s.worklogRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if worklog == nil {
+		return nil, ErrWorklogNotFound
+	}
+	return response.ToWorklogResponse(worklog), nil
+}
+
+// parseWorkDate parses the day work was done; empty means today. Work cannot be
+// logged ahead of time.
+func parseWorkDate(value string) (time.Time, error) {
+	if value == "" {
+		return today(), nil
+	}
+	day, err := time.Parse(constants.DateLayout, value)
+	if err != nil || day.After(today()) {
+		return time.Time{}, ErrInvalidWorklogDate
+	}
+	return day, nil
+}
+
+// weekStart returns the Monday of the day's week
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// worklogHistory builds the item history entry for a worklog event
+func worklogHistory(worklog *models.Worklog, action constants.ItemAction, userID uuid.UUID) models.ItemHistory {
+	field := "worklog"
+	value, _ := json.Marshal(map[string]interface{}{
+		"worklog_id": worklog.ID,
+		"minutes":    worklog.Minutes,
+		"date":       worklog.Date.Format(constants.DateLayout),
+	})
+	history := models.ItemHistory{
+		ItemID:       worklog.ItemID,
+		UserID:       userID,
+		Action:       action,
+		FieldChanged: &field,
+	}
+	if action == constants.ItemActionWorklogDeleted {
+		history.OldValue = datatypes.JSON(value)
+	} else {
+		history.NewValue = datatypes.JSON(value)
+	}
+	return history
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sprint-backlog/internal/dto/request"
+	"sprint-backlog/internal/models"
+	"sprint-backlog/internal/repository"
+	"sprint-backlog/pkg/constants"
+)
+
+// MockWorklogRepository is a mock implementation of WorklogRepository
+type MockWorklogRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorklogRepository) Create(worklog *models.Worklog, history models.ItemHistory) error {
+	args := m.Called(worklog, history)
+	return args.Error(0)
+}
+
+func (m *MockWorklogRepository) GetByID(id uuid.UUID) (*models.Worklog, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Worklog), args.Error(1)
+}
+
+func (m *MockWorklogRepository) GetByItemID(itemID uuid.UUID) ([]models.Worklog, error) {
+	args := m.Called(itemID)
+	return args.Get(0).([]models.Worklog), args.Error(1)
+}
+
+func (m *MockWorklogRepository) Update(worklog *models.Worklog, delta int, history models.ItemHistory) error {
+	args := m.Called(worklog, delta, history)
+	return args.Error(0)
+}
+
+func (m *MockWorklogRepository) Delete(worklog *models.Worklog, history models.ItemHistory) error {
+	args := m.Called(worklog, history)
+	return args.Error(0)
+}
+
+func (m *MockWorklogRepository) GetWeeklyByUser(userID uuid.UUID, from, to time.Time) ([]repository.WeeklyItemTime, error) {
+	args := m.Called(userID, from, to)
+	return args.Get(0).([]repository.WeeklyItemTime), args.Error(1)
+}
+
+func (m *MockWorklogRepository) SumByItems(itemIDs []uuid.UUID, from, to time.Time) (int, error) {
+	args := m.Called(itemIDs, from, to)
+	return args.Int(0), args.Error(1)
+}
+
+func TestWorklogService_Create(t *testing.T) {
+	t.Run("should log work for today by default", func(t *testing.T) {
+		mockWorklogRepo := new(MockWorklogRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWorklogService(mockWorklogRepo, mockBacklogRepo)
+
+		itemID := uuid.New()
+		userID := uuid.New()
+		stored := &models.Worklog{}
+
+		mockBacklogRepo.On("GetByID", itemID).Return(&models.BacklogItem{ID: itemID}, nil)
+		mockWorklogRepo.On("Create", mock.MatchedBy(func(w *models.Worklog) bool {
+			return w.ItemID == itemID && w.UserID == userID && w.Minutes == 90 &&
+				w.Date.Equal(today()) && *w.Note == "Pairing"
+		}), mock.MatchedBy(func(h models.ItemHistory) bool {
+			return h.Action == constants.ItemActionWorkLogged && *h.FieldChanged == "worklog"
+		})).Run(func(args mock.Arguments) {
+			*stored = *args.Get(0).(*models.Worklog)
+		}).Return(nil)
+		mockWorklogRepo.On("GetByID", mock.AnythingOfType("uuid.UUID")).Return(stored, nil)
+
+		result, err := service.Create(itemID, &request.CreateWorklogRequest{Minutes: 90, Note: " Pairing "}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 90, result.Minutes)
+		assert.Equal(t, today().Format(constants.DateLayout), result.Date)
+		mockWorklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject work logged in the future", func(t *testing.T) {
+		mockWorklogRepo := new(MockWorklogRepository)
+		service := NewWorklogService(mockWorklogRepo, nil)
+
+		tomorrow := today().AddDate(0, 0, 1).Format(constants.DateLayout)
+		result, err := service.Create(uuid.New(), &request.CreateWorklogRequest{Minutes: 30, Date: tomorrow}, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrInvalidWorklogDate, err)
+		mockWorklogRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWorklogService_Update(t *testing.T) {
+	t.Run("should pass the change in minutes to the repository", func(t *testing.T) {
+		mockWorklogRepo := new(MockWorklogRepository)
+		service := NewWorklogService(mockWorklogRepo, nil)
+
+		userID := uuid.New()
+		worklog := &models.Worklog{ID: uuid.New(), ItemID: uuid.New(), UserID: userID, Minutes: 60, Date: today()}
+
+		mockWorklogRepo.On("GetByID", worklog.ID).Return(worklog, nil)
+		mockWorklogRepo.On("Update", worklog, -15, mock.AnythingOfType("models.ItemHistory")).Return(nil)
+
+		result, err := service.Update(worklog.ID, &request.UpdateWorklogRequest{Minutes: 45}, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 45, result.Minutes)
+		mockWorklogRepo.AssertExpectations(t)
+	})
+
+	t.Run("should only let the author edit", func(t *testing.T) {
+		mockWorklogRepo := new(MockWorklogRepository)
+		service := NewWorklogService(mockWorklogRepo, nil)
+
+		worklog := &models.Worklog{ID: uuid.New(), UserID: uuid.New(), Minutes: 60}
+		mockWorklogRepo.On("GetByID", worklog.ID).Return(worklog, nil)
+
+		result, err := service.Update(worklog.ID, &request.UpdateWorklogRequest{Minutes: 45}, uuid.New())
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrWorklogForbidden, err)
+		mockWorklogRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestWorklogService_GetByItemID(t *testing.T) {
+	t.Run("should sum time in total and per user", func(t *testing.T) {
+		mockWorklogRepo := new(MockWorklogRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWorklogService(mockWorklogRepo, mockBacklogRepo)
+
+		estimate := 480
+		item := &models.BacklogItem{ID: uuid.New(), OriginalEstimate: &estimate}
+		alice := models.User{ID: uuid.New(), Name: "Alice"}
+		bob := models.User{ID: uuid.New(), Name: "Bob"}
+
+		mockBacklogRepo.On("GetByID", item.ID).Return(item, nil)
+		mockWorklogRepo.On("GetByItemID", item.ID).Return([]models.Worklog{
+			{ID: uuid.New(), ItemID: item.ID, UserID: alice.ID, User: alice, Minutes: 60},
+			{ID: uuid.New(), ItemID: item.ID, UserID: bob.ID, User: bob, Minutes: 30},
+			{ID: uuid.New(), ItemID: item.ID, UserID: alice.ID, User: alice, Minutes: 45},
+		}, nil)
+
+		result, err := service.GetByItemID(item.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 135, result.TotalMinutes)
+		assert.Len(t, result.ByUser, 2)
+		assert.Equal(t, "Alice", result.ByUser[0].User.Name)
+		assert.Equal(t, 105, result.ByUser[0].Minutes)
+		assert.Equal(t, 30, result.ByUser[1].Minutes)
+		assert.Equal(t, &estimate, result.OriginalEstimate)
+	})
+}
+
+func TestWorklogService_GetTimesheet(t *testing.T) {
+	t.Run("should group time by week", func(t *testing.T) {
+		mockWorklogRepo := new(MockWorklogRepository)
+		mockBacklogRepo := new(MockBacklogRepository)
+		service := NewWorklogService(mockWorklogRepo, mockBacklogRepo)
+
+		userID := uuid.New()
+		from := time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC)
+		item := models.BacklogItem{ID: uuid.New(), Number: 7, Title: "Checkout", Project: models.Project{Key: "SHOP"}}
+		other := uuid.New()
+
+		mockWorklogRepo.On("GetWeeklyByUser", userID, from, to).Return([]repository.WeeklyItemTime{
+			{WeekStart: from, ItemID: item.ID, Minutes: 120},
+			{WeekStart: from, ItemID: other, Minutes: 30},
+			{WeekStart: from.AddDate(0, 0, 7), ItemID: item.ID, Minutes: 60},
+		}, nil)
+		mockBacklogRepo.On("GetByIDs", []uuid.UUID{item.ID, other}).Return([]models.BacklogItem{item}, nil)
+
+		result, err := service.GetTimesheet(userID, &request.TimesheetQueryParams{From: "2026-09-28", To: "2026-10-11"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 210, result.TotalMinutes)
+		assert.Len(t, result.Weeks, 2)
+		assert.Equal(t, "2026-09-28", result.Weeks[0].WeekStart)
+		assert.Equal(t, 150, result.Weeks[0].TotalMinutes)
+		assert.Equal(t, "SHOP-7", result.Weeks[0].Items[0].Key)
+		assert.Empty(t, result.Weeks[0].Items[1].Key)
+		assert.Equal(t, "2026-10-05", result.Weeks[1].WeekStart)
+	})
+
+	t.Run("should reject ranges that end before they start", func(t *testing.T) {
+		service := NewWorklogService(nil, nil)
+
+		result, err := service.GetTimesheet(uuid.New(), &request.TimesheetQueryParams{From: "2026-10-11", To: "2026-09-28"})
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrInvalidTimesheetRange, err)
+	})
+}
+
+func TestWeekStart(t *testing.T) {
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), weekStart(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), weekStart(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), weekStart(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)))
+}
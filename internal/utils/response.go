@@ -44,6 +44,7 @@ type ErrorResponse struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
 	Error   ErrorDetail `json:"error,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
 }
 
 // PaginationMeta represents pagination metadata
@@ -61,6 +62,20 @@ type PaginatedResponse struct {
 	Meta    PaginationMeta `json:"meta"`
 }
 
+// CursorMeta represents cursor pagination metadata
+type CursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// CursorPaginatedResponse represents a cursor-paginated API response
+type CursorPaginatedResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+	Meta    CursorMeta  `json:"meta"`
+}
+
 // RespondSuccess sends a success response
 func RespondSuccess(c *gin.Context, statusCode int, message string, data interface{}) {
 	c.JSON(statusCode, SuccessResponse{
@@ -82,6 +97,19 @@ func RespondError(c *gin.Context, statusCode int, message string, code string, d
 	})
 }
 
+// RespondErrorWithData sends an error response that also carries a payload, such as
+// per-item results explaining why a request was rejected
+func RespondErrorWithData(c *gin.Context, statusCode int, message string, code string, data interface{}) {
+	c.JSON(statusCode, ErrorResponse{
+		Success: false,
+		Message: message,
+		Error: ErrorDetail{
+			Code: code,
+		},
+		Data: data,
+	})
+}
+
 // RespondBadRequest sends a 400 Bad Request response
 func RespondBadRequest(c *gin.Context, message string, details string) {
 	RespondError(c, http.StatusBadRequest, message, "BAD_REQUEST", details)
@@ -129,3 +157,17 @@ func RespondPaginated(c *gin.Context, data interface{}, page, perPage, total int
 		},
 	})
 }
+
+// RespondCursorPaginated sends one page of a cursor-paginated list; nextCursor is
+// empty on the last page
+func RespondCursorPaginated(c *gin.Context, data interface{}, limit int, nextCursor string) {
+	c.JSON(http.StatusOK, CursorPaginatedResponse{
+		Success: true,
+		Data:    data,
+		Meta: CursorMeta{
+			Limit:      limit,
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != "",
+		},
+	})
+}
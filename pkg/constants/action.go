@@ -11,9 +11,25 @@ const (
 	ItemActionSprintAssigned     ItemAction = "SprintAssigned"
 	ItemActionSprintRemoved      ItemAction = "SprintRemoved"
 	ItemActionCommentAdded       ItemAction = "CommentAdded"
+	ItemActionCommentEdited      ItemAction = "CommentEdited"
+	ItemActionCommentDeleted     ItemAction = "CommentDeleted"
 	ItemActionLabelAdded         ItemAction = "LabelAdded"
 	ItemActionLabelRemoved       ItemAction = "LabelRemoved"
 	ItemActionDescriptionUpdated ItemAction = "DescriptionUpdated"
+	ItemActionWIPLimitOverridden ItemAction = "WIPLimitOverridden"
+	ItemActionAssigned           ItemAction = "Assigned"
+	ItemActionUnassigned         ItemAction = "Unassigned"
+	ItemActionLinkAdded          ItemAction = "LinkAdded"
+	ItemActionLinkRemoved        ItemAction = "LinkRemoved"
+	ItemActionDeleted            ItemAction = "Deleted"
+	ItemActionAttachmentAdded    ItemAction = "AttachmentAdded"
+	ItemActionAttachmentRemoved  ItemAction = "AttachmentRemoved"
+	ItemActionWorkLogged         ItemAction = "WorkLogged"
+	ItemActionWorklogEdited      ItemAction = "WorklogEdited"
+	ItemActionWorklogDeleted     ItemAction = "WorklogDeleted"
+	ItemActionCloned             ItemAction = "Cloned"
+	ItemActionMoved              ItemAction = "Moved"
+	ItemActionRestored           ItemAction = "Restored"
 )
 
 // SprintAction represents actions that can be performed on a sprint
@@ -27,4 +43,5 @@ const (
 	SprintActionItemMoved   SprintAction = "ItemMoved"
 	SprintActionCompleted   SprintAction = "Completed"
 	SprintActionCancelled   SprintAction = "Cancelled"
+	SprintActionRestored    SprintAction = "Restored"
 )
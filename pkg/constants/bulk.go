@@ -0,0 +1,23 @@
+package constants
+
+// BulkOperation represents the change applied to every item of a bulk request
+type BulkOperation string
+
+const (
+	BulkOperationSetStatus      BulkOperation = "set_status"
+	BulkOperationSetPriority    BulkOperation = "set_priority"
+	BulkOperationSetSprint      BulkOperation = "set_sprint"
+	BulkOperationAddLabel       BulkOperation = "add_label"
+	BulkOperationRemoveLabel    BulkOperation = "remove_label"
+	BulkOperationSetStoryPoints BulkOperation = "set_story_points"
+	BulkOperationDelete         BulkOperation = "delete"
+)
+
+func (o BulkOperation) IsValid() bool {
+	switch o {
+	case BulkOperationSetStatus, BulkOperationSetPriority, BulkOperationSetSprint, BulkOperationAddLabel,
+		BulkOperationRemoveLabel, BulkOperationSetStoryPoints, BulkOperationDelete:
+		return true
+	}
+	return false
+}
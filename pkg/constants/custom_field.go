@@ -0,0 +1,26 @@
+package constants
+
+// CustomFieldType is the kind of value a project's custom field holds
+type CustomFieldType string
+
+const (
+	CustomFieldText        CustomFieldType = "text"
+	CustomFieldNumber      CustomFieldType = "number"
+	CustomFieldDate        CustomFieldType = "date"
+	CustomFieldSelect      CustomFieldType = "select"
+	CustomFieldMultiSelect CustomFieldType = "multi_select"
+	CustomFieldUser        CustomFieldType = "user"
+)
+
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomFieldText, CustomFieldNumber, CustomFieldDate, CustomFieldSelect, CustomFieldMultiSelect, CustomFieldUser:
+		return true
+	}
+	return false
+}
+
+// HasOptions reports whether values of this type are picked from a list of options
+func (t CustomFieldType) HasOptions() bool {
+	return t == CustomFieldSelect || t == CustomFieldMultiSelect
+}
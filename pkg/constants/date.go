@@ -0,0 +1,4 @@
+package constants
+
+// DateLayout is the format of calendar dates, such as due dates, in requests and responses
+const DateLayout = "2006-01-02"
@@ -0,0 +1,29 @@
+package constants
+
+// EstimationScale is the set of values a project's items are estimated with
+type EstimationScale string
+
+const (
+	// EstimationScaleLinear allows any whole number of story points from 0 to 100
+	EstimationScaleLinear      EstimationScale = "linear"
+	EstimationScaleFibonacci   EstimationScale = "fibonacci"
+	EstimationScalePowersOfTwo EstimationScale = "powers_of_two"
+	// EstimationScaleTShirt estimates with sizes such as S, M and L that map to story points
+	EstimationScaleTShirt EstimationScale = "tshirt"
+	// EstimationScaleCustom estimates with the project's own labels and points
+	EstimationScaleCustom EstimationScale = "custom"
+)
+
+func (s EstimationScale) IsValid() bool {
+	switch s {
+	case EstimationScaleLinear, EstimationScaleFibonacci, EstimationScalePowersOfTwo, EstimationScaleTShirt, EstimationScaleCustom:
+		return true
+	}
+	return false
+}
+
+// IsLabelled reports whether items are estimated with labels that map to story
+// points rather than with the points themselves
+func (s EstimationScale) IsLabelled() bool {
+	return s == EstimationScaleTShirt || s == EstimationScaleCustom
+}
@@ -0,0 +1,41 @@
+package constants
+
+// LinkType represents the relation between two linked backlog items
+type LinkType string
+
+const (
+	LinkTypeBlocks         LinkType = "blocks"
+	LinkTypeIsBlockedBy    LinkType = "is_blocked_by"
+	LinkTypeRelatesTo      LinkType = "relates_to"
+	LinkTypeDuplicates     LinkType = "duplicates"
+	LinkTypeIsDuplicatedBy LinkType = "is_duplicated_by"
+)
+
+func (t LinkType) IsValid() bool {
+	switch t {
+	case LinkTypeBlocks, LinkTypeIsBlockedBy, LinkTypeRelatesTo, LinkTypeDuplicates, LinkTypeIsDuplicatedBy:
+		return true
+	}
+	return false
+}
+
+// Inverse returns the link type as seen from the other item
+func (t LinkType) Inverse() LinkType {
+	switch t {
+	case LinkTypeBlocks:
+		return LinkTypeIsBlockedBy
+	case LinkTypeIsBlockedBy:
+		return LinkTypeBlocks
+	case LinkTypeDuplicates:
+		return LinkTypeIsDuplicatedBy
+	case LinkTypeIsDuplicatedBy:
+		return LinkTypeDuplicates
+	}
+	return t
+}
+
+// IsStored reports whether links of this type are stored as is. The inverse
+// types are stored as their forward type with source and target swapped.
+func (t LinkType) IsStored() bool {
+	return t == LinkTypeBlocks || t == LinkTypeRelatesTo || t == LinkTypeDuplicates
+}
@@ -22,16 +22,32 @@ func (p Priority) IsValid() bool {
 type ItemType string
 
 const (
-	ItemTypeStory ItemType = "Story"
-	ItemTypeBug   ItemType = "Bug"
-	ItemTypeTask  ItemType = "Task"
-	ItemTypeEpic  ItemType = "Epic"
+	ItemTypeStory   ItemType = "Story"
+	ItemTypeBug     ItemType = "Bug"
+	ItemTypeTask    ItemType = "Task"
+	ItemTypeEpic    ItemType = "Epic"
+	ItemTypeSubtask ItemType = "Subtask"
 )
 
 func (t ItemType) IsValid() bool {
 	switch t {
-	case ItemTypeStory, ItemTypeBug, ItemTypeTask, ItemTypeEpic:
+	case ItemTypeStory, ItemTypeBug, ItemTypeTask, ItemTypeEpic, ItemTypeSubtask:
 		return true
 	}
 	return false
 }
+
+// CanParent reports whether an item of this type may have a child of the given type.
+// Epics hold stories and bugs, and stories hold subtasks. Tasks are planned like
+// stories, so epics hold them too, and bugs and tasks can be broken down into
+// subtasks like stories. Epics and subtasks never have a parent and a child
+// respectively, so the hierarchy is at most three levels deep.
+func (t ItemType) CanParent(child ItemType) bool {
+	switch t {
+	case ItemTypeEpic:
+		return child == ItemTypeStory || child == ItemTypeBug || child == ItemTypeTask
+	case ItemTypeStory, ItemTypeBug, ItemTypeTask:
+		return child == ItemTypeSubtask
+	}
+	return false
+}
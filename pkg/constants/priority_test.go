@@ -0,0 +1,39 @@
+package constants
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemType_CanParent(t *testing.T) {
+	t.Run("should allow the item hierarchy", func(t *testing.T) {
+		pairs := [][2]ItemType{
+			{ItemTypeEpic, ItemTypeStory},
+			{ItemTypeEpic, ItemTypeBug},
+			{ItemTypeEpic, ItemTypeTask},
+			{ItemTypeStory, ItemTypeSubtask},
+			{ItemTypeBug, ItemTypeSubtask},
+			{ItemTypeTask, ItemTypeSubtask},
+		}
+		for _, p := range pairs {
+			assert.True(t, p[0].CanParent(p[1]), p)
+		}
+	})
+
+	t.Run("should reject everything else", func(t *testing.T) {
+		pairs := [][2]ItemType{
+			{ItemTypeEpic, ItemTypeEpic},
+			{ItemTypeEpic, ItemTypeSubtask},
+			{ItemTypeStory, ItemTypeStory},
+			{ItemTypeStory, ItemTypeTask},
+			{ItemTypeBug, ItemTypeStory},
+			{ItemTypeTask, ItemTypeBug},
+			{ItemTypeSubtask, ItemTypeSubtask},
+			{ItemTypeStory, ItemTypeEpic},
+		}
+		for _, p := range pairs {
+			assert.False(t, p[0].CanParent(p[1]), p)
+		}
+	})
+}
@@ -19,6 +19,31 @@ func (s ItemStatus) IsValid() bool {
 	return false
 }
 
+// StatusCategory groups item statuses of a project workflow
+type StatusCategory string
+
+const (
+	StatusCategoryTodo       StatusCategory = "todo"
+	StatusCategoryInProgress StatusCategory = "in_progress"
+	StatusCategoryDone       StatusCategory = "done"
+	// StatusCategoryArchived holds items put away without being completed; they
+	// need no more work but do not count towards velocity or progress
+	StatusCategoryArchived StatusCategory = "archived"
+)
+
+func (c StatusCategory) IsValid() bool {
+	switch c {
+	case StatusCategoryTodo, StatusCategoryInProgress, StatusCategoryDone, StatusCategoryArchived:
+		return true
+	}
+	return false
+}
+
+// IsClosed reports whether items in the category need no more work
+func (c StatusCategory) IsClosed() bool {
+	return c == StatusCategoryDone || c == StatusCategoryArchived
+}
+
 // SprintStatus represents the status of a sprint
 type SprintStatus string
 
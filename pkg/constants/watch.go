@@ -0,0 +1,23 @@
+package constants
+
+// WatchEntityType represents the kind of entity a user can watch
+type WatchEntityType string
+
+const (
+	WatchEntityItem   WatchEntityType = "item"
+	WatchEntitySprint WatchEntityType = "sprint"
+)
+
+func (t WatchEntityType) IsValid() bool {
+	return t == WatchEntityItem || t == WatchEntitySprint
+}
+
+// WatchReason records why a user watches an entity
+type WatchReason string
+
+const (
+	WatchReasonManual    WatchReason = "manual"
+	WatchReasonCreator   WatchReason = "creator"
+	WatchReasonAssignee  WatchReason = "assignee"
+	WatchReasonCommenter WatchReason = "commenter"
+)
@@ -0,0 +1,50 @@
+// Package cursor encodes keyset pagination positions as opaque tokens.
+//
+// A token holds the sort key values of the last row of a page, so the next page
+// can continue strictly after that row no matter how many rows were added or
+// removed in between. Tokens also record which ordering they were issued for and
+// are rejected when used with another one.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// MaxLength bounds the size of a token accepted by Decode
+const MaxLength = 1024
+
+var ErrInvalid = errors.New("cursor is invalid or does not match the requested ordering")
+
+type payload struct {
+	Sort   string    `json:"s"`
+	Values []*string `json:"v"`
+}
+
+// Encode returns the token for the position described by values under the given
+// sort. A nil value stands for a NULL sort key.
+func Encode(sort string, values []*string) string {
+	data, _ := json.Marshal(payload{Sort: sort, Values: values})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode returns the position stored in token. It fails with ErrInvalid when the
+// token is malformed, was issued for another sort or does not hold n values.
+func Decode(token, sort string, n int) ([]*string, error) {
+	if len(token) > MaxLength {
+		return nil, ErrInvalid
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, ErrInvalid
+	}
+	if p.Sort != sort || len(p.Values) != n {
+		return nil, ErrInvalid
+	}
+	return p.Values, nil
+}
@@ -0,0 +1,54 @@
+package cursor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	t.Run("should round-trip values including NULLs", func(t *testing.T) {
+		rank, id := "i", "9b0c7c1e-2a1f-4d6b-8a51-3c1f0e8d7a42"
+		token := Encode("items", []*string{&rank, nil, &id})
+
+		values, err := Decode(token, "items", 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*string{&rank, nil, &id}, values)
+	})
+
+	t.Run("should produce URL-safe tokens", func(t *testing.T) {
+		value := strings.Repeat("?/+", 20)
+		token := Encode("items", []*string{&value})
+
+		assert.NotContains(t, token, "+")
+		assert.NotContains(t, token, "/")
+		assert.NotContains(t, token, "=")
+	})
+
+	t.Run("should reject a token issued for another sort", func(t *testing.T) {
+		value := "1"
+		token := Encode("created", []*string{&value})
+
+		_, err := Decode(token, "updated", 1)
+
+		assert.Equal(t, ErrInvalid, err)
+	})
+
+	t.Run("should reject a token with the wrong number of values", func(t *testing.T) {
+		value := "1"
+		token := Encode("items", []*string{&value})
+
+		_, err := Decode(token, "items", 2)
+
+		assert.Equal(t, ErrInvalid, err)
+	})
+
+	t.Run("should reject malformed tokens", func(t *testing.T) {
+		for _, token := range []string{"", "not a token", "e30", strings.Repeat("a", MaxLength+1)} {
+			_, err := Decode(token, "items", 1)
+			assert.Equal(t, ErrInvalid, err, token)
+		}
+	})
+}
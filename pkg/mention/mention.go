@@ -0,0 +1,35 @@
+// Package mention extracts @mentions from comment text.
+//
+// A mention is an @ followed by a user handle: either a full email address such as
+// @jane.doe@example.com or the local part of one such as @jane.doe. The @ must start
+// the text or follow a character that cannot be part of an address, so plain email
+// addresses in the text are not mistaken for mentions.
+package mention
+
+import (
+	"regexp"
+	"strings"
+)
+
+var pattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9._%+\-@])@([A-Za-z0-9._%+\-]+(?:@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)+)?)`)
+
+// Parse returns the distinct handles mentioned in the text, lowercased and in order
+// of first appearance. Trailing dots are dropped so that a mention may end a sentence.
+func Parse(text string) []string {
+	var handles []string
+	seen := make(map[string]bool)
+	for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+		handle := strings.ToLower(strings.TrimRight(match[1], "."))
+		if handle == "" || seen[handle] {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+// IsEmail reports whether a handle is a full email address rather than a local part
+func IsEmail(handle string) bool {
+	return strings.Contains(handle, "@")
+}
@@ -0,0 +1,28 @@
+package mention
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should extract handles and full addresses", func(t *testing.T) {
+		handles := Parse("@Jane can you check this with @bob.smith@Example.com?")
+		assert.Equal(t, []string{"jane", "bob.smith@example.com"}, handles)
+	})
+
+	t.Run("should drop duplicates and trailing dots", func(t *testing.T) {
+		handles := Parse("Thanks @jane. Also @bob,@JANE and (@carol).")
+		assert.Equal(t, []string{"jane", "bob", "carol"}, handles)
+	})
+
+	t.Run("should ignore plain email addresses and lone at signs", func(t *testing.T) {
+		assert.Empty(t, Parse("mail jane@example.com @ 10am, or @."))
+	})
+}
+
+func TestIsEmail(t *testing.T) {
+	assert.True(t, IsEmail("jane@example.com"))
+	assert.False(t, IsEmail("jane"))
+}
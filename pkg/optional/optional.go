@@ -0,0 +1,11 @@
+// Package optional holds helpers for values stored as pointers, where nil means the
+// value is unset.
+package optional
+
+// Equal reports whether two optional values are both unset or equal
+func Equal[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,22 @@
+package optional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	one, otherOne, two := 1, 1, 2
+
+	t.Run("should compare set values", func(t *testing.T) {
+		assert.True(t, Equal(&one, &otherOne))
+		assert.False(t, Equal(&one, &two))
+	})
+
+	t.Run("should treat unset values as equal only to each other", func(t *testing.T) {
+		assert.True(t, Equal[int](nil, nil))
+		assert.False(t, Equal(&one, nil))
+		assert.False(t, Equal(nil, &one))
+	})
+}
@@ -0,0 +1,84 @@
+// Package query parses the backlog query language, for example
+//
+//	priority in (High, Critical) AND status != Done AND label = api AND points >= 5 ORDER BY updated DESC
+//
+// into an AST. Parsing is purely syntactic: field names and values are checked
+// when the AST is compiled against a schema.
+package query
+
+// Query is a parsed query: an optional condition followed by optional sort terms
+type Query struct {
+	Where   Expr
+	OrderBy []OrderTerm
+}
+
+// Expr is a boolean expression: *Binary, *Not or *Comparison
+type Expr interface {
+	expr()
+}
+
+// LogicalOp combines two expressions
+type LogicalOp string
+
+const (
+	And LogicalOp = "AND"
+	Or  LogicalOp = "OR"
+)
+
+// Binary is the conjunction or disjunction of two expressions
+type Binary struct {
+	Op    LogicalOp
+	Left  Expr
+	Right Expr
+}
+
+// Not negates an expression
+type Not struct {
+	Expr Expr
+}
+
+// Operator compares a field with its values
+type Operator string
+
+const (
+	Equal          Operator = "="
+	NotEqual       Operator = "!="
+	Less           Operator = "<"
+	LessOrEqual    Operator = "<="
+	Greater        Operator = ">"
+	GreaterOrEqual Operator = ">="
+	Contains       Operator = "~"
+	NotContains    Operator = "!~"
+	In             Operator = "IN"
+	NotIn          Operator = "NOT IN"
+	IsEmpty        Operator = "IS EMPTY"
+	IsNotEmpty     Operator = "IS NOT EMPTY"
+)
+
+// Comparison tests a field. In and NotIn have one or more values, IsEmpty and
+// IsNotEmpty have none and every other operator has exactly one.
+type Comparison struct {
+	Field  string
+	Op     Operator
+	Values []Value
+	Pos    int
+}
+
+// Value is a literal as written in the query. Quoted is true for string literals,
+// which are never treated as keywords.
+type Value struct {
+	Text   string
+	Quoted bool
+	Pos    int
+}
+
+// OrderTerm sorts results by a field
+type OrderTerm struct {
+	Field string
+	Desc  bool
+	Pos   int
+}
+
+func (*Binary) expr()     {}
+func (*Not) expr()        {}
+func (*Comparison) expr() {}
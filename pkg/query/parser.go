@@ -0,0 +1,397 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxLength limits the size of a query
+const MaxLength = 2000
+
+// SyntaxError reports where and why a query could not be parsed. Pos is the
+// 1-based character position of the offending token.
+type SyntaxError struct {
+	Pos     int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Message)
+}
+
+// Errorf returns a SyntaxError at pos. Compilers use it to report unknown fields
+// and invalid values in the same format as parse errors.
+func Errorf(pos int, format string, args ...interface{}) error {
+	return &SyntaxError{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// describe renders the token for error messages
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return fmt.Sprintf("string %q", t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// is reports whether the token is the given keyword, ignoring case
+func (t token) is(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+var keywords = []string{"AND", "OR", "NOT", "IN", "IS", "EMPTY", "ORDER", "BY", "ASC", "DESC"}
+
+func isKeyword(text string) bool {
+	for _, keyword := range keywords {
+		if strings.EqualFold(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordRune reports whether r may appear in an unquoted word such as a field name,
+// label, number, date or UUID
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.:+", r)
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		pos := utf8.RuneCountInString(input[:i]) + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			i++
+		case r == '"' || r == '\'':
+			// Quoted string; a doubled quote stands for the quote itself
+			var sb strings.Builder
+			j := i + 1
+			closed := false
+			for j < len(input) {
+				if rune(input[j]) == r {
+					if j+1 < len(input) && rune(input[j+1]) == r {
+						sb.WriteRune(r)
+						j += 2
+						continue
+					}
+					closed = true
+					j++
+					break
+				}
+				sb.WriteByte(input[j])
+				j++
+			}
+			if !closed {
+				return nil, &SyntaxError{Pos: pos, Message: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: pos})
+			i = j
+		case strings.ContainsRune("=!<>~", r):
+			op := string(r)
+			if i+1 < len(input) {
+				if two := input[i : i+2]; two == "!=" || two == "<=" || two == ">=" || two == "!~" {
+					op = two
+				}
+			}
+			if op == "!" {
+				return nil, &SyntaxError{Pos: pos, Message: `unexpected "!", did you mean "!=" or "!~"?`}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+			i += len(op)
+		case isWordRune(r):
+			j := i
+			for j < len(input) {
+				next, nextSize := utf8.DecodeRuneInString(input[j:])
+				if !isWordRune(next) {
+					break
+				}
+				j += nextSize
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: input[i:j], pos: pos})
+			i = j
+		default:
+			return nil, &SyntaxError{Pos: pos, Message: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: utf8.RuneCountInString(input) + 1}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse parses a query. An empty query matches everything.
+func Parse(input string) (*Query, error) {
+	if len(input) > MaxLength {
+		return nil, &SyntaxError{Pos: MaxLength + 1, Message: fmt.Sprintf("query is longer than %d characters", MaxLength)}
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	q := &Query{}
+	if p.peek().kind != tokenEOF && !p.peek().is("ORDER") {
+		if q.Where, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek().is("ORDER") {
+		p.next()
+		if !p.peek().is("BY") {
+			return nil, p.unexpected(`"BY" after "ORDER"`)
+		}
+		p.next()
+		for {
+			term, err := p.parseOrderTerm()
+			if err != nil {
+				return nil, err
+			}
+			q.OrderBy = append(q.OrderBy, term)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, p.unexpected(`"AND", "OR", "ORDER BY" or end of query`)
+	}
+	return q, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) unexpected(expected string) error {
+	t := p.peek()
+	return &SyntaxError{Pos: t.pos, Message: fmt.Sprintf("expected %s but found %s", expected, t.describe())}
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: Or, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: And, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if p.peek().is("NOT") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	if p.peek().kind == tokenLParen {
+		open := p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			if p.peek().kind == tokenEOF {
+				return nil, &SyntaxError{Pos: open.pos, Message: "unclosed parenthesis"}
+			}
+			return nil, p.unexpected(`")"`)
+		}
+		p.next()
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	field := p.peek()
+	if field.kind != tokenWord || isKeyword(field.text) {
+		return nil, p.unexpected("a field name")
+	}
+	p.next()
+	cmp := &Comparison{Field: strings.ToLower(field.text), Pos: field.pos}
+
+	t := p.peek()
+	switch {
+	case t.kind == tokenOperator:
+		p.next()
+		cmp.Op = Operator(t.text)
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		cmp.Values = []Value{value}
+
+	case t.is("IN"):
+		p.next()
+		cmp.Op = In
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		cmp.Values = values
+
+	case t.is("NOT"):
+		p.next()
+		if !p.peek().is("IN") {
+			return nil, p.unexpected(`"IN" after "NOT"`)
+		}
+		p.next()
+		cmp.Op = NotIn
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		cmp.Values = values
+
+	case t.is("IS"):
+		p.next()
+		cmp.Op = IsEmpty
+		if p.peek().is("NOT") {
+			p.next()
+			cmp.Op = IsNotEmpty
+		}
+		if !p.peek().is("EMPTY") {
+			return nil, p.unexpected(`"EMPTY"`)
+		}
+		p.next()
+
+	default:
+		return nil, p.unexpected(fmt.Sprintf("an operator after %q", field.text))
+	}
+
+	return cmp, nil
+}
+
+func (p *parser) parseValue() (Value, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenString:
+		p.next()
+		return Value{Text: t.text, Quoted: true, Pos: t.pos}, nil
+	case t.kind == tokenWord && !isKeyword(t.text):
+		p.next()
+		return Value{Text: t.text, Pos: t.pos}, nil
+	}
+	return Value{}, p.unexpected("a value (quote values that contain spaces or keywords)")
+}
+
+func (p *parser) parseList() ([]Value, error) {
+	if p.peek().kind != tokenLParen {
+		return nil, p.unexpected(`"(" to start a list of values`)
+	}
+	p.next()
+
+	var values []Value
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		if p.peek().kind == tokenComma {
+			p.next()
+			continue
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, p.unexpected(`"," or ")"`)
+		}
+		p.next()
+		return values, nil
+	}
+}
+
+func (p *parser) parseOrderTerm() (OrderTerm, error) {
+	field := p.peek()
+	if field.kind != tokenWord || isKeyword(field.text) {
+		return OrderTerm{}, p.unexpected("a field name to order by")
+	}
+	p.next()
+
+	term := OrderTerm{Field: strings.ToLower(field.text), Pos: field.pos}
+	switch {
+	case p.peek().is("ASC"):
+		p.next()
+	case p.peek().is("DESC"):
+		p.next()
+		term.Desc = true
+	}
+	return term, nil
+}
@@ -0,0 +1,86 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should parse comparisons joined by AND with an order", func(t *testing.T) {
+		q, err := Parse("priority in (High, Critical) AND status != Done AND label = api AND points >= 5 ORDER BY updated DESC")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []OrderTerm{{Field: "updated", Desc: true, Pos: 90}}, q.OrderBy)
+
+		// AND is left-associative: (((priority AND status) AND label) AND points)
+		top, ok := q.Where.(*Binary)
+		assert.True(t, ok)
+		assert.Equal(t, And, top.Op)
+		points := top.Right.(*Comparison)
+		assert.Equal(t, "points", points.Field)
+		assert.Equal(t, GreaterOrEqual, points.Op)
+		assert.Equal(t, "5", points.Values[0].Text)
+
+		priority := top.Left.(*Binary).Left.(*Binary).Left.(*Comparison)
+		assert.Equal(t, In, priority.Op)
+		assert.Equal(t, []Value{{Text: "High", Pos: 14}, {Text: "Critical", Pos: 20}}, priority.Values)
+	})
+
+	t.Run("should bind AND tighter than OR", func(t *testing.T) {
+		q, err := Parse("type = Bug OR type = Story and points > 3")
+
+		assert.NoError(t, err)
+		top := q.Where.(*Binary)
+		assert.Equal(t, Or, top.Op)
+		assert.Equal(t, And, top.Right.(*Binary).Op)
+	})
+
+	t.Run("should parse parentheses, NOT and emptiness checks", func(t *testing.T) {
+		q, err := Parse(`NOT (sprint is empty OR assignee IS NOT EMPTY) AND label not in ("tech debt", 'it''s')`)
+
+		assert.NoError(t, err)
+		top := q.Where.(*Binary)
+		not := top.Left.(*Not)
+		inner := not.Expr.(*Binary)
+		assert.Equal(t, IsEmpty, inner.Left.(*Comparison).Op)
+		assert.Equal(t, IsNotEmpty, inner.Right.(*Comparison).Op)
+
+		labels := top.Right.(*Comparison)
+		assert.Equal(t, NotIn, labels.Op)
+		assert.Equal(t, "tech debt", labels.Values[0].Text)
+		assert.True(t, labels.Values[0].Quoted)
+		assert.Equal(t, "it's", labels.Values[1].Text)
+	})
+
+	t.Run("should accept an empty query and a bare order", func(t *testing.T) {
+		q, err := Parse("  ")
+		assert.NoError(t, err)
+		assert.Nil(t, q.Where)
+
+		q, err = Parse("order by priority desc, created")
+		assert.NoError(t, err)
+		assert.Nil(t, q.Where)
+		assert.Len(t, q.OrderBy, 2)
+		assert.False(t, q.OrderBy[1].Desc)
+	})
+
+	t.Run("should report syntax errors with positions", func(t *testing.T) {
+		cases := map[string]string{
+			"status =":                    "syntax error at position 9: expected a value (quote values that contain spaces or keywords) but found end of query",
+			"status Done":                 `syntax error at position 8: expected an operator after "status" but found "Done"`,
+			"(type = Bug":                 "syntax error at position 1: unclosed parenthesis",
+			"type in (Bug Story)":         `syntax error at position 14: expected "," or ")" but found "Story"`,
+			`title ~ "login`:              "syntax error at position 9: unterminated string",
+			"points ! 3":                  `syntax error at position 8: unexpected "!", did you mean "!=" or "!~"?`,
+			"type = Bug ORDER priority":   `syntax error at position 18: expected "BY" after "ORDER" but found "priority"`,
+			"type = Bug type = Story":     `syntax error at position 12: expected "AND", "OR", "ORDER BY" or end of query but found "type"`,
+			"AND type = Bug":              `syntax error at position 1: expected a field name but found "AND"`,
+			"status = Done; DROP TABLE x": `syntax error at position 14: unexpected character ';'`,
+		}
+		for input, message := range cases {
+			_, err := Parse(input)
+			assert.EqualError(t, err, message, input)
+		}
+	})
+}
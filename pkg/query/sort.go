@@ -0,0 +1,61 @@
+package query
+
+import (
+	"strings"
+)
+
+// ParseSort parses a sort parameter: a comma-separated list of fields, each
+// optionally followed by ":asc" or ":desc", e.g. "priority:desc,updated_at".
+// Fields sort ascending by default. Field names are lowercased and may appear once.
+func ParseSort(spec string) ([]OrderTerm, error) {
+	if len(spec) > MaxLength {
+		return nil, Errorf(MaxLength+1, "sort is longer than %d characters", MaxLength)
+	}
+
+	var terms []OrderTerm
+	seen := make(map[string]bool)
+	pos := 1
+	for _, part := range strings.Split(spec, ",") {
+		start := pos + len(part) - len(strings.TrimLeft(part, " "))
+		pos += len(part) + 1
+
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, Errorf(start, "expected a field name")
+		}
+
+		field, dir, hasDir := strings.Cut(part, ":")
+		if !isSortField(field) {
+			return nil, Errorf(start, "invalid field name %q", field)
+		}
+		term := OrderTerm{Field: strings.ToLower(field), Pos: start}
+		if hasDir {
+			switch strings.ToLower(dir) {
+			case "asc":
+			case "desc":
+				term.Desc = true
+			default:
+				return nil, Errorf(start+len(field)+1, "expected asc or desc, found %q", dir)
+			}
+		}
+
+		if seen[term.Field] {
+			return nil, Errorf(start, "field %q is sorted by more than once", term.Field)
+		}
+		seen[term.Field] = true
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+func isSortField(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	t.Run("should parse fields with optional directions", func(t *testing.T) {
+		terms, err := ParseSort("priority:desc, Updated_At,title:ASC")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []OrderTerm{
+			{Field: "priority", Desc: true, Pos: 1},
+			{Field: "updated_at", Pos: 16},
+			{Field: "title", Pos: 27},
+		}, terms)
+	})
+
+	t.Run("should reject an unknown direction", func(t *testing.T) {
+		_, err := ParseSort("priority:down")
+
+		assert.EqualError(t, err, `syntax error at position 10: expected asc or desc, found "down"`)
+	})
+
+	t.Run("should reject empty terms", func(t *testing.T) {
+		_, err := ParseSort("priority,,title")
+
+		assert.EqualError(t, err, "syntax error at position 10: expected a field name")
+	})
+
+	t.Run("should reject invalid field names", func(t *testing.T) {
+		_, err := ParseSort("title;drop")
+
+		assert.EqualError(t, err, `syntax error at position 1: invalid field name "title;drop"`)
+	})
+
+	t.Run("should reject repeated fields", func(t *testing.T) {
+		_, err := ParseSort("rank,RANK:desc")
+
+		assert.EqualError(t, err, `syntax error at position 6: field "rank" is sorted by more than once`)
+	})
+}
@@ -0,0 +1,170 @@
+// Package rank generates lexicographically ordered keys for manual item ordering.
+//
+// Keys are base-36 strings compared byte by byte. A key can always be generated
+// between any two existing keys, so moving an item only rewrites that item's key.
+// Keys grow when the same gap is split repeatedly; Spread produces a fresh,
+// evenly spaced set of keys once they get too long. Appending with After steps by a
+// fixed increment instead of splitting the gap to the end, so it does not grow keys.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+// MaxLength is the key length above which a project's keys should be rebalanced
+const MaxLength = 24
+
+// stepDigits is the minimum number of digits After steps at, leaving room for
+// 36^4 appends after a one-digit key
+const stepDigits = 4
+
+var (
+	ErrInvalidRange = errors.New("rank: lower key must sort before upper key")
+	ErrInvalidKey   = errors.New("rank: key must be non-empty base-36 without trailing zeros")
+)
+
+// Between returns a key that sorts strictly between a and b.
+// An empty a means the start of the list and an empty b means its end.
+func Between(a, b string) (string, error) {
+	if err := validate(a); err != nil {
+		return "", err
+	}
+	if err := validate(b); err != nil {
+		return "", err
+	}
+	if a != "" && b != "" && a >= b {
+		return "", ErrInvalidRange
+	}
+	return midpoint(a, b), nil
+}
+
+// Initial returns the key for the first item of an empty list
+func Initial() string {
+	return midpoint("", "")
+}
+
+// After returns a key that sorts after a. It adds one to the last digit of a,
+// padded to at least four digits, so appending keeps keys the same length until
+// every key of that length after a is taken.
+func After(a string) (string, error) {
+	if err := validate(a); err != nil {
+		return "", err
+	}
+	if a == "" {
+		return Initial(), nil
+	}
+
+	buf := []byte(a)
+	for len(buf) < stepDigits {
+		buf = append(buf, '0')
+	}
+	for i := len(buf) - 1; i >= 0; i-- {
+		d := strings.IndexByte(digits, buf[i])
+		if d < base-1 {
+			buf[i] = digits[d+1]
+			return strings.TrimRight(string(buf), "0"), nil
+		}
+		buf[i] = '0'
+	}
+	// a is all z's: only a longer key sorts after it
+	return midpoint(a, ""), nil
+}
+
+// Before returns a key that sorts before b
+func Before(b string) (string, error) {
+	return Between("", b)
+}
+
+// Spread returns n evenly spaced keys in ascending order, leaving room to insert
+// many items between any two of them before keys grow
+func Spread(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	// Use enough digits to leave a gap of at least one full digit between keys
+	width, capacity := 1, base
+	for capacity < (n+1)*base {
+		width++
+		capacity *= base
+	}
+	step := capacity / (n + 1)
+
+	keys := make([]string, n)
+	buf := make([]byte, width)
+	for i := range keys {
+		value := step * (i + 1)
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = digits[value%base]
+			value /= base
+		}
+		keys[i] = strings.TrimRight(string(buf), "0")
+	}
+	return keys
+}
+
+// midpoint assumes a < b, or b empty, and that neither key has trailing zeros
+func midpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix, treating a as padded with zeros
+		n := 0
+		for n < len(b) && charAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(suffix(a, n), b[n:])
+		}
+	}
+
+	low := 0
+	if a != "" {
+		low = strings.IndexByte(digits, a[0])
+	}
+	high := base
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+
+	if high-low > 1 {
+		return string(digits[(low+high+1)/2])
+	}
+
+	// The first digits are adjacent: either b's first digit alone fits, or
+	// keep a's first digit and find a key after the rest of a
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(digits[low]) + midpoint(suffix(a, 1), "")
+}
+
+func validate(key string) error {
+	if strings.HasSuffix(key, "0") {
+		return ErrInvalidKey
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// charAt returns the byte at i, treating positions past the end as '0'
+func charAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return '0'
+}
+
+func suffix(key string, n int) string {
+	if n >= len(key) {
+		return ""
+	}
+	return key[n:]
+}
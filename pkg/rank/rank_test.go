@@ -0,0 +1,156 @@
+package rank
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBetween(t *testing.T) {
+	t.Run("should return a key strictly between the bounds", func(t *testing.T) {
+		cases := [][2]string{
+			{"", ""},
+			{"", "i"},
+			{"i", ""},
+			{"a", "b"},
+			{"a", "a1"},
+			{"a", "a01"},
+			{"az", "b"},
+			{"z", ""},
+			{"zzz", ""},
+			{"", "001"},
+			{"1", "2"},
+		}
+		for _, c := range cases {
+			key, err := Between(c[0], c[1])
+			assert.NoError(t, err, c)
+			if c[0] != "" {
+				assert.Less(t, c[0], key, c)
+			}
+			if c[1] != "" {
+				assert.Less(t, key, c[1], c)
+			}
+			assert.NoError(t, validate(key), c)
+		}
+	})
+
+	t.Run("should reject bounds in the wrong order", func(t *testing.T) {
+		_, err := Between("b", "a")
+		assert.Equal(t, ErrInvalidRange, err)
+
+		_, err = Between("a", "a")
+		assert.Equal(t, ErrInvalidRange, err)
+	})
+
+	t.Run("should reject malformed keys", func(t *testing.T) {
+		_, err := Between("a0", "")
+		assert.Equal(t, ErrInvalidKey, err)
+
+		_, err = Between("", "A")
+		assert.Equal(t, ErrInvalidKey, err)
+	})
+
+	t.Run("should keep order under random inserts", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		keys := []string{Initial()}
+		for i := 0; i < 2000; i++ {
+			pos := rng.Intn(len(keys) + 1)
+			lower, upper := "", ""
+			if pos > 0 {
+				lower = keys[pos-1]
+			}
+			if pos < len(keys) {
+				upper = keys[pos]
+			}
+			key, err := Between(lower, upper)
+			assert.NoError(t, err)
+			keys = append(keys[:pos], append([]string{key}, keys[pos:]...)...)
+		}
+		assert.True(t, sort.StringsAreSorted(keys))
+	})
+}
+
+func TestAfterBefore(t *testing.T) {
+	key := Initial()
+	for i := 0; i < 100; i++ {
+		next, err := After(key)
+		assert.NoError(t, err)
+		assert.Less(t, key, next)
+		key = next
+	}
+
+	key = Initial()
+	for i := 0; i < 100; i++ {
+		prev, err := Before(key)
+		assert.NoError(t, err)
+		assert.Less(t, prev, key)
+		key = prev
+	}
+}
+
+func TestAfter(t *testing.T) {
+	t.Run("should not grow keys when appending", func(t *testing.T) {
+		key := Initial()
+		for i := 0; i < 2000; i++ {
+			next, err := After(key)
+			assert.NoError(t, err)
+			assert.Less(t, key, next)
+			assert.LessOrEqual(t, len(next), stepDigits)
+			assert.NoError(t, validate(next))
+			key = next
+		}
+	})
+
+	t.Run("should step at the last digit of long keys", func(t *testing.T) {
+		key, err := After("a1b2c3")
+		assert.NoError(t, err)
+		assert.Equal(t, "a1b2c4", key)
+
+		key, err = After("i0zz")
+		assert.NoError(t, err)
+		assert.Equal(t, "i1", key)
+	})
+
+	t.Run("should extend a key with no successor of its length", func(t *testing.T) {
+		key, err := After("zzzz")
+		assert.NoError(t, err)
+		assert.Less(t, "zzzz", key)
+		assert.NoError(t, validate(key))
+	})
+
+	t.Run("should reject malformed keys", func(t *testing.T) {
+		_, err := After("a0")
+		assert.Equal(t, ErrInvalidKey, err)
+	})
+}
+
+func TestSpread(t *testing.T) {
+	t.Run("should return sorted unique valid keys", func(t *testing.T) {
+		for _, n := range []int{1, 2, 35, 36, 1000} {
+			keys := Spread(n)
+			assert.Len(t, keys, n)
+			assert.True(t, sort.StringsAreSorted(keys))
+			for i, key := range keys {
+				assert.NoError(t, validate(key))
+				if i > 0 {
+					assert.NotEqual(t, keys[i-1], key)
+				}
+			}
+		}
+	})
+
+	t.Run("should leave room between neighbours", func(t *testing.T) {
+		keys := Spread(100)
+		for i := 1; i < len(keys); i++ {
+			key, err := Between(keys[i-1], keys[i])
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, len(key), len(keys[i])+1)
+		}
+	})
+
+	t.Run("should return nothing for an empty list", func(t *testing.T) {
+		assert.Empty(t, Spread(0))
+	})
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store, read and delete objects", func(t *testing.T) {
+		store, err := NewLocal(t.TempDir())
+		assert.NoError(t, err)
+
+		assert.NoError(t, store.Put(ctx, "attachments/ab/abc", strings.NewReader("hello"), 5, "text/plain"))
+
+		r, err := store.Get(ctx, "attachments/ab/abc")
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(r)
+		r.Close()
+		assert.Equal(t, "hello", string(data))
+
+		assert.NoError(t, store.Delete(ctx, "attachments/ab/abc"))
+		_, err = store.Get(ctx, "attachments/ab/abc")
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, store.Delete(ctx, "attachments/ab/abc"))
+	})
+
+	t.Run("should not keep short writes", func(t *testing.T) {
+		store, err := NewLocal(t.TempDir())
+		assert.NoError(t, err)
+
+		assert.Error(t, store.Put(ctx, "short", strings.NewReader("abc"), 5, ""))
+		_, err = store.Get(ctx, "short")
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("should reject keys outside the root", func(t *testing.T) {
+		store, err := NewLocal(t.TempDir())
+		assert.NoError(t, err)
+
+		for _, key := range []string{"../escape", "/abs", "a//b", "a/./b", ""} {
+			assert.Equal(t, ErrInvalidKey, store.Put(ctx, key, strings.NewReader(""), 0, ""), key)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+// Package storage stores binary objects such as attachment files under string keys.
+//
+// Keys are slash-separated paths of letters, digits, dots, dashes and underscores,
+// e.g. attachments/ab/ab12...; they never start with a slash or contain "..".
+// Backends are safe for concurrent use. Writing a key that exists replaces it.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("storage: object not found")
+	ErrInvalidKey = errors.New("storage: invalid object key")
+)
+
+// Storage is a backend for binary objects
+type Storage interface {
+	// Put stores size bytes read from r under key
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key; the caller must close it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+(/[A-Za-z0-9._-]+)*$`)
+
+// ValidKey reports whether key can be used with every backend
+func ValidKey(key string) bool {
+	if len(key) > 512 || !keyPattern.MatchString(key) {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}